@@ -1,9 +1,9 @@
 package main
 
 import (
+	"context"
 	"net/http"
 
-	"github.com/casbin/casbin/v2"
 	"github.com/gorilla/mux"
 	"github.com/ryvasa/go-restaurant/internal/delivery/http/middleware"
 	"github.com/ryvasa/go-restaurant/internal/delivery/http/routes"
@@ -25,8 +25,16 @@ func main() {
 	if err != nil {
 		logger.Log.Fatal("Failed to initialize handlers:", err)
 	}
+
+	// Start background jobs
+	jobs, err := di.InitializeScheduler()
+	if err != nil {
+		logger.Log.Fatal("Failed to initialize scheduler:", err)
+	}
+	jobs.Start(context.Background())
+
 	// Initialize Casbin
-	enforcer, err := casbin.NewEnforcer("pkg/config/casbin/model.conf", "pkg/config/casbin/policy.csv")
+	enforcer, err := config.NewEnforcer()
 	if err != nil {
 		logger.Log.Fatal("Failed to initialize Casbin:", err)
 	}
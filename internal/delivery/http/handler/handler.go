@@ -1,16 +1,31 @@
 package handler
 
+import (
+	"context"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
 type Handlers struct {
-	MenuHandler        MenuHandler
-	UserHandler        UserHandler
-	ReviewHandler      ReviewHandler
-	AuthHandler        AuthHandler
-	OrderHandler       OrderHandler
-	TableHandler       TableHandler
-	ReservationHandler ReservationHandler
-	RecipeHandler      RecipeHandler
-	InventoryHandler   InventoryHandler
-	IngredientHandler  IngredientHandler
+	MenuHandler            MenuHandler
+	UserHandler            UserHandler
+	ReviewHandler          ReviewHandler
+	AuthHandler            AuthHandler
+	OrderHandler           OrderHandler
+	TableHandler           TableHandler
+	ReservationHandler     ReservationHandler
+	RecipeHandler          RecipeHandler
+	InventoryHandler       InventoryHandler
+	IngredientHandler      IngredientHandler
+	SupplierHandler        SupplierHandler
+	PurchaseOrderHandler   PurchaseOrderHandler
+	ReportHandler          ReportHandler
+	WasteHandler           WasteHandler
+	StocktakeHandler       StocktakeHandler
+	StorageLocationHandler StorageLocationHandler
+	TaxRateHandler         TaxRateHandler
 }
 
 func NewHandlers(
@@ -24,18 +39,57 @@ func NewHandlers(
 	recipeHandler RecipeHandler,
 	inventoryHandler InventoryHandler,
 	ingIngredientHandler IngredientHandler,
+	supplierHandler SupplierHandler,
+	purchaseOrderHandler PurchaseOrderHandler,
+	reportHandler ReportHandler,
+	wasteHandler WasteHandler,
+	stocktakeHandler StocktakeHandler,
+	storageLocationHandler StorageLocationHandler,
+	taxRateHandler TaxRateHandler,
 
 ) *Handlers {
 	return &Handlers{
-		MenuHandler:        menuHandler,
-		UserHandler:        userHandler,
-		ReviewHandler:      reviewHandler,
-		AuthHandler:        authHandler,
-		OrderHandler:       orderHandler,
-		TableHandler:       tableHandler,
-		ReservationHandler: reservationHandler,
-		RecipeHandler:      recipeHandler,
-		InventoryHandler:   inventoryHandler,
-		IngredientHandler:  ingIngredientHandler,
+		MenuHandler:            menuHandler,
+		UserHandler:            userHandler,
+		ReviewHandler:          reviewHandler,
+		AuthHandler:            authHandler,
+		OrderHandler:           orderHandler,
+		TableHandler:           tableHandler,
+		ReservationHandler:     reservationHandler,
+		RecipeHandler:          recipeHandler,
+		InventoryHandler:       inventoryHandler,
+		IngredientHandler:      ingIngredientHandler,
+		SupplierHandler:        supplierHandler,
+		PurchaseOrderHandler:   purchaseOrderHandler,
+		ReportHandler:          reportHandler,
+		WasteHandler:           wasteHandler,
+		StocktakeHandler:       stocktakeHandler,
+		StorageLocationHandler: storageLocationHandler,
+		TaxRateHandler:         taxRateHandler,
+	}
+}
+
+// getUserFromContext reads the user id and role set by AuthenticationMiddleware
+func getUserFromContext(ctx context.Context) (uuid.UUID, string, error) {
+	claims, ok := ctx.Value("user").(jwt.MapClaims)
+	if !ok {
+		return uuid.UUID{}, "", utils.NewUnauthorizedError("Invalid user context")
+	}
+
+	userIdStr, ok := claims["sub"].(string)
+	if !ok {
+		return uuid.UUID{}, "", utils.NewUnauthorizedError("Invalid user ID")
+	}
+
+	role, ok := claims["role"].(string)
+	if !ok {
+		return uuid.UUID{}, "", utils.NewUnauthorizedError("Invalid user role")
 	}
+
+	userId, err := uuid.Parse(userIdStr)
+	if err != nil {
+		return uuid.UUID{}, "", utils.NewUnauthorizedError("Invalid user ID")
+	}
+
+	return userId, role, nil
 }
@@ -7,4 +7,12 @@ type IngredientHandler interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	Restore(w http.ResponseWriter, r *http.Request)
+	GetConversions(w http.ResponseWriter, r *http.Request)
+	CreateConversion(w http.ResponseWriter, r *http.Request)
+	DeleteConversion(w http.ResponseWriter, r *http.Request)
+	UpdateCost(w http.ResponseWriter, r *http.Request)
+	GetCostHistory(w http.ResponseWriter, r *http.Request)
+	GetNutrition(w http.ResponseWriter, r *http.Request)
+	UpdateNutrition(w http.ResponseWriter, r *http.Request)
+	DeleteNutrition(w http.ResponseWriter, r *http.Request)
 }
@@ -90,3 +90,150 @@ func (h *IngredientHandlerImpl) Restore(w http.ResponseWriter, r *http.Request)
 
 	utils.HttpResponse(w, http.StatusOK, ingredient, nil)
 }
+
+func (h *IngredientHandlerImpl) GetConversions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	conversions, err := h.ingredientUsecase.GetConversions(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get unit conversions")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, conversions, nil)
+}
+
+func (h *IngredientHandlerImpl) CreateConversion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	var req dto.CreateIngredientUnitConversionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	conversion, err := h.ingredientUsecase.CreateConversion(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create unit conversion")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, conversion, nil)
+}
+
+func (h *IngredientHandlerImpl) DeleteConversion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	conversionId := utils.ValidateIdParam(w, r, mux.Vars(r)["conversionId"])
+
+	err := h.ingredientUsecase.DeleteConversion(ctx, id, conversionId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete unit conversion")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Unit conversion deleted successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
+
+func (h *IngredientHandlerImpl) UpdateCost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	var req dto.UpdateIngredientCostRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	ingredient, err := h.ingredientUsecase.UpdateCost(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update ingredient cost")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, ingredient, nil)
+}
+
+func (h *IngredientHandlerImpl) GetCostHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	histories, err := h.ingredientUsecase.GetCostHistory(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient cost history")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, histories, nil)
+}
+
+func (h *IngredientHandlerImpl) GetNutrition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	nutrition, err := h.ingredientUsecase.GetNutrition(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient nutrition")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, nutrition, nil)
+}
+
+func (h *IngredientHandlerImpl) UpdateNutrition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	var req dto.UpdateIngredientNutritionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	nutrition, err := h.ingredientUsecase.UpdateNutrition(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update ingredient nutrition")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, nutrition, nil)
+}
+
+func (h *IngredientHandlerImpl) DeleteNutrition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	err := h.ingredientUsecase.DeleteNutrition(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete ingredient nutrition")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	res := map[string]string{"message": "Ingredient nutrition deleted successfully"}
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
@@ -7,7 +7,15 @@ type InventoryHandler interface {
 	GetOneByIngredientId(w http.ResponseWriter, r *http.Request)
 	GetOneById(w http.ResponseWriter, r *http.Request)
 	Update(w http.ResponseWriter, r *http.Request)
+	CreateAdjustment(w http.ResponseWriter, r *http.Request)
+	GetMovements(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	Restore(w http.ResponseWriter, r *http.Request)
 	CalculateMenuPortions(w http.ResponseWriter, r *http.Request)
+	GetMenuAvailability(w http.ResponseWriter, r *http.Request)
+	GetLowStock(w http.ResponseWriter, r *http.Request)
+	GetLots(w http.ResponseWriter, r *http.Request)
+	GetExpiringLots(w http.ResponseWriter, r *http.Request)
+	Transfer(w http.ResponseWriter, r *http.Request)
+	GetTransfers(w http.ResponseWriter, r *http.Request)
 }
@@ -29,7 +29,13 @@ func (h *InventoryHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
 		return
 	}
-	createdInventory, err := h.invetoryUsecase.Create(ctx, req)
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	createdInventory, err := h.invetoryUsecase.Create(ctx, req, actorId)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to create inventory")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -79,7 +85,13 @@ func (h *InventoryHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
 		return
 	}
-	updatedInventory, err := h.invetoryUsecase.Update(ctx, id, req)
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	updatedInventory, err := h.invetoryUsecase.Update(ctx, id, actorId, req)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to update inventory")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -89,6 +101,46 @@ func (h *InventoryHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 
 }
 
+func (h *InventoryHandlerImpl) CreateAdjustment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+	var req dto.CreateInventoryAdjustmentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	inventory, err := h.invetoryUsecase.CreateAdjustment(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create inventory adjustment")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, inventory, nil)
+}
+
+func (h *InventoryHandlerImpl) GetMovements(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+	ledger, err := h.invetoryUsecase.GetMovements(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get inventory movements")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, ledger, nil)
+}
+
 func (h *InventoryHandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
 
 	ctx := r.Context()
@@ -136,3 +188,95 @@ func (h *InventoryHandlerImpl) CalculateMenuPortions(w http.ResponseWriter, r *h
 	}
 	utils.HttpResponse(w, http.StatusOK, total, nil)
 }
+
+func (h *InventoryHandlerImpl) GetMenuAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	availability, err := h.invetoryUsecase.GetMenuAvailability(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get menu availability")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, availability, nil)
+}
+
+func (h *InventoryHandlerImpl) GetLowStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	items, err := h.invetoryUsecase.GetLowStock(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get low stock")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, items, nil)
+}
+
+func (h *InventoryHandlerImpl) GetLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+	lots, err := h.invetoryUsecase.GetLots(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get inventory lots")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, lots, nil)
+}
+
+func (h *InventoryHandlerImpl) GetExpiringLots(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := dto.GetExpiringLotsRequest{
+		Days: r.URL.Query().Get("days"),
+	}
+
+	lots, err := h.invetoryUsecase.GetExpiringLots(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get expiring lots")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, lots, nil)
+}
+
+func (h *InventoryHandlerImpl) Transfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateStockTransferRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	transfer, err := h.invetoryUsecase.Transfer(ctx, req, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to transfer stock")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, transfer, nil)
+}
+
+func (h *InventoryHandlerImpl) GetTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	req := dto.GetStockTransfersRequest{
+		IngredientId: query.Get("ingredient_id"),
+		LocationId:   query.Get("location_id"),
+	}
+
+	transfers, err := h.invetoryUsecase.GetTransfers(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get stock transfers")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, transfers, nil)
+}
@@ -3,9 +3,9 @@ package handler
 import (
 	"mime/multipart"
 	"net/http"
-	"strconv"
 
 	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
 	"github.com/ryvasa/go-restaurant/internal/model/dto"
 	"github.com/ryvasa/go-restaurant/internal/usecase"
 	"github.com/ryvasa/go-restaurant/pkg/logger"
@@ -25,7 +25,12 @@ func NewMenuHandler(menuUsecase usecase.MenuUsecase) MenuHandler {
 func (h *MenuHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	menus, err := h.menuUsecase.GetAll(ctx)
+	query := r.URL.Query()
+	req := dto.GetMenuRequest{
+		ExcludeAllergens: query.Get("exclude_allergens"),
+		Diets:            query.Get("diets"),
+	}
+	menus, err := h.menuUsecase.GetAll(ctx, req)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to get all menu")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -51,7 +56,7 @@ func (h *MenuHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
 		Category:    r.FormValue("category"),
 	}
 
-	price, err := strconv.ParseFloat(r.FormValue("price"), 64)
+	price, err := domain.ParseMoney(r.FormValue("price"))
 	if err != nil {
 		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid price format"))
 		return
@@ -119,7 +124,7 @@ func (h *MenuHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 	// Parse price
 	reqPrice := r.FormValue("price")
 	if reqPrice != "" {
-		price, err := strconv.ParseFloat(reqPrice, 64)
+		price, err := domain.ParseMoney(reqPrice)
 		if err != nil {
 			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid price format"))
 			return
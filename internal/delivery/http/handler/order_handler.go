@@ -5,6 +5,17 @@ import "net/http"
 type OrderHandler interface {
 	Create(w http.ResponseWriter, r *http.Request)
 	GetOneById(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetStatusHistory(w http.ResponseWriter, r *http.Request)
 	UpdateOrderStatus(w http.ResponseWriter, r *http.Request)
+	Cancel(w http.ResponseWriter, r *http.Request)
 	UpdatePayment(w http.ResponseWriter, r *http.Request)
+	GetPayments(w http.ResponseWriter, r *http.Request)
+	CreatePayment(w http.ResponseWriter, r *http.Request)
+	CapturePayment(w http.ResponseWriter, r *http.Request)
+	PaymentWebhook(w http.ResponseWriter, r *http.Request)
+	GetRefunds(w http.ResponseWriter, r *http.Request)
+	CreateRefund(w http.ResponseWriter, r *http.Request)
+	GetVoids(w http.ResponseWriter, r *http.Request)
+	CreateVoid(w http.ResponseWriter, r *http.Request)
 }
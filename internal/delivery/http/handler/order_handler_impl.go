@@ -2,7 +2,9 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
@@ -13,6 +15,12 @@ import (
 	"github.com/ryvasa/go-restaurant/utils"
 )
 
+// paymentSignatureHeader carries the signature of a payment webhook body
+const paymentSignatureHeader = "X-Payment-Signature"
+
+// maxPaymentWebhookSize caps the body of a payment webhook
+const maxPaymentWebhookSize = 1 << 20
+
 type OrderHandlerImpl struct {
 	orderUsecase usecase.OrderUsecase
 }
@@ -72,7 +80,14 @@ func (h *OrderHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
 
 	id := utils.ValidateIdParam(w, r, idStr)
 
-	order, err := h.orderUsecase.GetOneById(ctx, id)
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	order, err := h.orderUsecase.GetOneById(ctx, id, userId, role)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to get order")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -82,20 +97,128 @@ func (h *OrderHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
 	utils.HttpResponse(w, http.StatusOK, order, nil)
 }
 
+func (h *OrderHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	query := r.URL.Query()
+	req := dto.GetOrdersRequest{
+		UserId:        query.Get("user_id"),
+		Status:        query.Get("status"),
+		PaymentStatus: query.Get("payment_status"),
+		CreatedFrom:   query.Get("created_from"),
+		CreatedTo:     query.Get("created_to"),
+		SortBy:        query.Get("sort_by"),
+		SortOrder:     query.Get("sort_order"),
+	}
+
+	if page := query.Get("page"); page != "" {
+		req.Page, err = strconv.Atoi(page)
+		if err != nil {
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid page format"))
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid limit format"))
+			return
+		}
+	}
+
+	orders, err := h.orderUsecase.GetAll(ctx, req, userId, role)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get orders")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, orders, nil)
+}
+
+func (h *OrderHandlerImpl) GetStatusHistory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	histories, err := h.orderUsecase.GetStatusHistory(ctx, id, userId, role)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order status history")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, histories, nil)
+}
+
 func (h *OrderHandlerImpl) UpdateOrderStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	var req dto.UpdateOrderStatusDto
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		logger.Log.WithError(err).Error("Error invalid request body")
 		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
 	}
 	idStr := mux.Vars(r)["id"]
 
 	id := utils.ValidateIdParam(w, r, idStr)
 
-	order, err := h.orderUsecase.UpdateOrderStatus(ctx, id, req)
+	actorId, _, err := getUserFromContext(ctx)
 	if err != nil {
-		logger.Log.WithError(err).Error("Error failed to get order")
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	order, err := h.orderUsecase.UpdateOrderStatus(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order status")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, order, nil)
+}
+
+func (h *OrderHandlerImpl) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CancelOrderDto
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Log.WithError(err).Error("Error invalid request body")
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+			return
+		}
+	}
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	order, err := h.orderUsecase.Cancel(ctx, id, userId, role, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to cancel order")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
 		return
 	}
@@ -109,17 +232,226 @@ func (h *OrderHandlerImpl) UpdatePayment(w http.ResponseWriter, r *http.Request)
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		logger.Log.WithError(err).Error("Error invalid request body")
 		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
 	}
 	idStr := mux.Vars(r)["id"]
 
 	id := utils.ValidateIdParam(w, r, idStr)
 
-	order, err := h.orderUsecase.UpdatePayment(ctx, id, req)
+	actorId, _, err := getUserFromContext(ctx)
 	if err != nil {
-		logger.Log.WithError(err).Error("Error failed to get order")
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	order, err := h.orderUsecase.UpdatePayment(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order payment")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
 		return
 	}
 
 	utils.HttpResponse(w, http.StatusOK, order, nil)
 }
+
+func (h *OrderHandlerImpl) GetPayments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	payments, err := h.orderUsecase.GetPayments(ctx, id, userId, role)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payments")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, payments, nil)
+}
+
+func (h *OrderHandlerImpl) CreatePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreatePaymentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	payment, err := h.orderUsecase.CreatePayment(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create payment")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusCreated, payment, nil)
+}
+
+func (h *OrderHandlerImpl) CapturePayment(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+	paymentId := utils.ValidateIdParam(w, r, mux.Vars(r)["paymentId"])
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	payment, err := h.orderUsecase.CapturePayment(ctx, id, paymentId, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to capture payment")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, payment, nil)
+}
+
+// PaymentWebhook receives events from the payment provider, the body is read
+// as is because the signature covers the raw bytes
+func (h *OrderHandlerImpl) PaymentWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	payload, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxPaymentWebhookSize))
+	if err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+
+	err = h.orderUsecase.HandlePaymentWebhook(ctx, payload, r.Header.Get(paymentSignatureHeader))
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to handle payment webhook")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, nil, nil)
+}
+
+func (h *OrderHandlerImpl) GetRefunds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	refunds, err := h.orderUsecase.GetRefunds(ctx, id, userId, role)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get refunds")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, refunds, nil)
+}
+
+func (h *OrderHandlerImpl) CreateRefund(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateRefundRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	actorId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	refund, err := h.orderUsecase.CreateRefund(ctx, id, actorId, role, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create refund")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusCreated, refund, nil)
+}
+
+func (h *OrderHandlerImpl) GetVoids(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	userId, role, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	voids, err := h.orderUsecase.GetVoids(ctx, id, userId, role)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order voids")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, voids, nil)
+}
+
+func (h *OrderHandlerImpl) CreateVoid(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateOrderVoidRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	voids, err := h.orderUsecase.CreateVoid(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to void order items")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusCreated, voids, nil)
+}
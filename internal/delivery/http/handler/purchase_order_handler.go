@@ -0,0 +1,13 @@
+package handler
+
+import "net/http"
+
+type PurchaseOrderHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetOneById(w http.ResponseWriter, r *http.Request)
+	Send(w http.ResponseWriter, r *http.Request)
+	Cancel(w http.ResponseWriter, r *http.Request)
+	Receive(w http.ResponseWriter, r *http.Request)
+	GenerateFromLowStock(w http.ResponseWriter, r *http.Request)
+}
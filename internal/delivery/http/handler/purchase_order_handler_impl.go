@@ -0,0 +1,146 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type PurchaseOrderHandlerImpl struct {
+	purchaseOrderUsecase usecase.PurchaseOrderUsecase
+}
+
+func NewPurchaseOrderHandler(purchaseOrderUsecase usecase.PurchaseOrderUsecase) PurchaseOrderHandler {
+	return &PurchaseOrderHandlerImpl{
+		purchaseOrderUsecase: purchaseOrderUsecase,
+	}
+}
+
+func (h *PurchaseOrderHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreatePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	purchaseOrder, err := h.purchaseOrderUsecase.Create(ctx, req, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create purchase order")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, purchaseOrder, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	req := dto.GetPurchaseOrdersRequest{
+		Status:     query.Get("status"),
+		SupplierId: query.Get("supplier_id"),
+	}
+
+	purchaseOrders, err := h.purchaseOrderUsecase.GetAll(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get purchase orders")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, purchaseOrders, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	purchaseOrder, err := h.purchaseOrderUsecase.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get purchase order")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, purchaseOrder, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) Send(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	purchaseOrder, err := h.purchaseOrderUsecase.Send(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to send purchase order")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, purchaseOrder, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	purchaseOrder, err := h.purchaseOrderUsecase.Cancel(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to cancel purchase order")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, purchaseOrder, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) Receive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.ReceivePurchaseOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	purchaseOrder, err := h.purchaseOrderUsecase.Receive(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to receive purchase order")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, purchaseOrder, nil)
+}
+
+func (h *PurchaseOrderHandlerImpl) GenerateFromLowStock(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	generated, err := h.purchaseOrderUsecase.GenerateFromLowStock(ctx, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to generate purchase orders")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, generated, nil)
+}
@@ -9,4 +9,13 @@ type RecipeHandler interface {
 	Update(w http.ResponseWriter, r *http.Request)
 	Delete(w http.ResponseWriter, r *http.Request)
 	Restore(w http.ResponseWriter, r *http.Request)
+	GetCost(w http.ResponseWriter, r *http.Request)
+	GetVersions(w http.ResponseWriter, r *http.Request)
+	GetVersion(w http.ResponseWriter, r *http.Request)
+	CreateVersion(w http.ResponseWriter, r *http.Request)
+	UpdateVersion(w http.ResponseWriter, r *http.Request)
+	PublishVersion(w http.ResponseWriter, r *http.Request)
+	DeleteVersion(w http.ResponseWriter, r *http.Request)
+	GetBatchSheet(w http.ResponseWriter, r *http.Request)
+	GetNutrition(w http.ResponseWriter, r *http.Request)
 }
@@ -30,7 +30,14 @@ func (h *RecipeHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	createdRecipe, err := h.recipeUsecase.Create(ctx, req)
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	createdRecipe, err := h.recipeUsecase.Create(ctx, req, actorId)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to create recipe")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -81,7 +88,14 @@ func (h *RecipeHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	updatedRecipe, err := h.recipeUsecase.Update(ctx, id, req)
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	updatedRecipe, err := h.recipeUsecase.Update(ctx, id, req, actorId)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error failed to update recipe")
 		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
@@ -126,3 +140,180 @@ func (h *RecipeHandlerImpl) Restore(w http.ResponseWriter, r *http.Request) {
 	utils.HttpResponse(w, http.StatusOK, recipe, nil)
 
 }
+
+func (h *RecipeHandlerImpl) GetCost(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idStr := mux.Vars(r)["id"]
+
+	id := utils.ValidateIdParam(w, r, idStr)
+	req := dto.GetRecipeCostRequest{
+		At: r.URL.Query().Get("at"),
+	}
+	recipeCost, err := h.recipeUsecase.GetCost(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get recipe cost")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, recipeCost, nil)
+}
+
+func (h *RecipeHandlerImpl) GetVersions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	versions, err := h.recipeUsecase.GetVersions(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get recipe versions")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, versions, nil)
+}
+
+func (h *RecipeHandlerImpl) GetVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	versionId := utils.ValidateIdParam(w, r, mux.Vars(r)["versionId"])
+
+	version, err := h.recipeUsecase.GetVersion(ctx, id, versionId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get recipe version")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, version, nil)
+}
+
+func (h *RecipeHandlerImpl) CreateVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateRecipeVersionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Log.WithError(err).Error("Error invalid request body")
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+			return
+		}
+	}
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	version, err := h.recipeUsecase.CreateVersion(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create recipe version")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusCreated, version, nil)
+}
+
+func (h *RecipeHandlerImpl) UpdateVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.UpdateRecipeVersionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	versionId := utils.ValidateIdParam(w, r, mux.Vars(r)["versionId"])
+
+	version, err := h.recipeUsecase.UpdateVersion(ctx, id, versionId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update recipe version")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, version, nil)
+}
+
+func (h *RecipeHandlerImpl) PublishVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.PublishRecipeVersionRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Log.WithError(err).Error("Error invalid request body")
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+			return
+		}
+	}
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	versionId := utils.ValidateIdParam(w, r, mux.Vars(r)["versionId"])
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	version, err := h.recipeUsecase.PublishVersion(ctx, id, versionId, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to publish recipe version")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, version, nil)
+}
+
+func (h *RecipeHandlerImpl) DeleteVersion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	versionId := utils.ValidateIdParam(w, r, mux.Vars(r)["versionId"])
+
+	err := h.recipeUsecase.DeleteVersion(ctx, id, versionId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete recipe version")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Recipe version deleted successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
+
+func (h *RecipeHandlerImpl) GetBatchSheet(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	query := r.URL.Query()
+	req := dto.GetRecipeBatchSheetRequest{
+		Quantity: query.Get("quantity"),
+		Unit:     query.Get("unit"),
+	}
+	batchSheet, err := h.recipeUsecase.GetBatchSheet(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get recipe batch sheet")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, batchSheet, nil)
+}
+
+func (h *RecipeHandlerImpl) GetNutrition(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	nutrition, err := h.recipeUsecase.GetNutrition(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get recipe nutrition")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	utils.HttpResponse(w, http.StatusOK, nutrition, nil)
+}
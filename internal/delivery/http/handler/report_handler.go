@@ -0,0 +1,9 @@
+package handler
+
+import "net/http"
+
+type ReportHandler interface {
+	GetMenuMargins(w http.ResponseWriter, r *http.Request)
+	GetWaste(w http.ResponseWriter, r *http.Request)
+	GetIngredientForecast(w http.ResponseWriter, r *http.Request)
+}
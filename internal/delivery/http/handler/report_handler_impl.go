@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type ReportHandlerImpl struct {
+	reportUsecase usecase.ReportUsecase
+}
+
+func NewReportHandler(reportUsecase usecase.ReportUsecase) ReportHandler {
+	return &ReportHandlerImpl{
+		reportUsecase: reportUsecase,
+	}
+}
+
+func (h *ReportHandlerImpl) GetMenuMargins(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	req := dto.GetMenuMarginsRequest{
+		TargetMargin: r.URL.Query().Get("target_margin"),
+	}
+
+	report, err := h.reportUsecase.GetMenuMargins(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get menu margins")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, report, nil)
+}
+
+func (h *ReportHandlerImpl) GetWaste(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	req := dto.GetWasteReportRequest{
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Period: query.Get("period"),
+	}
+
+	report, err := h.reportUsecase.GetWaste(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get waste report")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, report, nil)
+}
+
+func (h *ReportHandlerImpl) GetIngredientForecast(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	req := dto.GetIngredientForecastRequest{
+		Days:        query.Get("days"),
+		HistoryDays: query.Get("history_days"),
+		Window:      query.Get("window"),
+	}
+
+	report, err := h.reportUsecase.GetIngredientForecast(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient forecast")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, report, nil)
+}
@@ -0,0 +1,12 @@
+package handler
+
+import "net/http"
+
+type StocktakeHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetOneById(w http.ResponseWriter, r *http.Request)
+	SubmitCounts(w http.ResponseWriter, r *http.Request)
+	Close(w http.ResponseWriter, r *http.Request)
+	Cancel(w http.ResponseWriter, r *http.Request)
+}
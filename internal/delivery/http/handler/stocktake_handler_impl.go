@@ -0,0 +1,139 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type StocktakeHandlerImpl struct {
+	stocktakeUsecase usecase.StocktakeUsecase
+}
+
+func NewStocktakeHandler(stocktakeUsecase usecase.StocktakeUsecase) StocktakeHandler {
+	return &StocktakeHandlerImpl{
+		stocktakeUsecase: stocktakeUsecase,
+	}
+}
+
+func (h *StocktakeHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateStocktakeRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			logger.Log.WithError(err).Error("Error invalid request body")
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+			return
+		}
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	stocktake, err := h.stocktakeUsecase.Create(ctx, req, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create stocktake")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, stocktake, nil)
+}
+
+func (h *StocktakeHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	stocktakes, err := h.stocktakeUsecase.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get stocktakes")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, stocktakes, nil)
+}
+
+func (h *StocktakeHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	stocktake, err := h.stocktakeUsecase.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get stocktake")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, stocktake, nil)
+}
+
+func (h *StocktakeHandlerImpl) SubmitCounts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.SubmitStocktakeCountsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	stocktake, err := h.stocktakeUsecase.SubmitCounts(ctx, id, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to submit stocktake counts")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, stocktake, nil)
+}
+
+func (h *StocktakeHandlerImpl) Close(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	stocktake, err := h.stocktakeUsecase.Close(ctx, id, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to close stocktake")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, stocktake, nil)
+}
+
+func (h *StocktakeHandlerImpl) Cancel(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	stocktake, err := h.stocktakeUsecase.Cancel(ctx, id, actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to cancel stocktake")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, stocktake, nil)
+}
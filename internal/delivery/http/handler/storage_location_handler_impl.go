@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type StorageLocationHandlerImpl struct {
+	storageLocationUsecase usecase.StorageLocationUsecase
+}
+
+func NewStorageLocationHandler(storageLocationUsecase usecase.StorageLocationUsecase) StorageLocationHandler {
+	return &StorageLocationHandlerImpl{
+		storageLocationUsecase: storageLocationUsecase,
+	}
+}
+
+func (h *StorageLocationHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateStorageLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+
+	location, err := h.storageLocationUsecase.Create(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create storage location")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, location, nil)
+}
+
+func (h *StorageLocationHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	locations, err := h.storageLocationUsecase.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get storage locations")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, locations, nil)
+}
+
+func (h *StorageLocationHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	location, err := h.storageLocationUsecase.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get storage location")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, location, nil)
+}
+
+func (h *StorageLocationHandlerImpl) GetInventory(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	inventories, err := h.storageLocationUsecase.GetInventory(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get storage location inventory")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, inventories, nil)
+}
+
+func (h *StorageLocationHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.UpdateStorageLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+
+	location, err := h.storageLocationUsecase.Update(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update storage location")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, location, nil)
+}
+
+func (h *StorageLocationHandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	err := h.storageLocationUsecase.Delete(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete storage location")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Storage location deleted successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
@@ -0,0 +1,13 @@
+package handler
+
+import "net/http"
+
+type SupplierHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+	GetOneById(w http.ResponseWriter, r *http.Request)
+	Update(w http.ResponseWriter, r *http.Request)
+	Delete(w http.ResponseWriter, r *http.Request)
+	SetIngredient(w http.ResponseWriter, r *http.Request)
+	RemoveIngredient(w http.ResponseWriter, r *http.Request)
+}
@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type SupplierHandlerImpl struct {
+	supplierUsecase usecase.SupplierUsecase
+}
+
+func NewSupplierHandler(supplierUsecase usecase.SupplierUsecase) SupplierHandler {
+	return &SupplierHandlerImpl{
+		supplierUsecase: supplierUsecase,
+	}
+}
+
+func (h *SupplierHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	supplier, err := h.supplierUsecase.Create(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create supplier")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, supplier, nil)
+}
+
+func (h *SupplierHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	suppliers, err := h.supplierUsecase.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get suppliers")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, suppliers, nil)
+}
+
+func (h *SupplierHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	supplier, err := h.supplierUsecase.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get supplier")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, supplier, nil)
+}
+
+func (h *SupplierHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.UpdateSupplierRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	supplier, err := h.supplierUsecase.Update(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update supplier")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, supplier, nil)
+}
+
+func (h *SupplierHandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	err := h.supplierUsecase.Delete(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete supplier")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Supplier deleted successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
+
+func (h *SupplierHandlerImpl) SetIngredient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.SetSupplierIngredientRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	supplierIngredient, err := h.supplierUsecase.SetIngredient(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to set supplier ingredient")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, supplierIngredient, nil)
+}
+
+func (h *SupplierHandlerImpl) RemoveIngredient(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+	ingredientId := utils.ValidateIdParam(w, r, mux.Vars(r)["ingredientId"])
+
+	err := h.supplierUsecase.RemoveIngredient(ctx, id, ingredientId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to remove supplier ingredient")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Supplier ingredient removed successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
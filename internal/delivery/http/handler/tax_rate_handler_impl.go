@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type TaxRateHandlerImpl struct {
+	taxRateUsecase usecase.TaxRateUsecase
+}
+
+func NewTaxRateHandler(taxRateUsecase usecase.TaxRateUsecase) TaxRateHandler {
+	return &TaxRateHandlerImpl{
+		taxRateUsecase: taxRateUsecase,
+	}
+}
+
+func (h *TaxRateHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CreateTaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+
+	rate, err := h.taxRateUsecase.Create(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create tax rate")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, rate, nil)
+}
+
+func (h *TaxRateHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	rates, err := h.taxRateUsecase.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get tax rates")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, rates, nil)
+}
+
+func (h *TaxRateHandlerImpl) GetOneById(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	rate, err := h.taxRateUsecase.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get tax rate")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, rate, nil)
+}
+
+func (h *TaxRateHandlerImpl) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	var req dto.UpdateTaxRateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+
+	rate, err := h.taxRateUsecase.Update(ctx, id, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update tax rate")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, rate, nil)
+}
+
+func (h *TaxRateHandlerImpl) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id := utils.ValidateIdParam(w, r, mux.Vars(r)["id"])
+
+	err := h.taxRateUsecase.Delete(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete tax rate")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	res := map[string]string{"message": "Tax rate deleted successfully"}
+
+	utils.HttpResponse(w, http.StatusOK, res, nil)
+}
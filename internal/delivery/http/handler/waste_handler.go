@@ -0,0 +1,8 @@
+package handler
+
+import "net/http"
+
+type WasteHandler interface {
+	Create(w http.ResponseWriter, r *http.Request)
+	GetAll(w http.ResponseWriter, r *http.Request)
+}
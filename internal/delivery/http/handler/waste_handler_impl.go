@@ -0,0 +1,83 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type WasteHandlerImpl struct {
+	wasteUsecase usecase.WasteUsecase
+}
+
+func NewWasteHandler(wasteUsecase usecase.WasteUsecase) WasteHandler {
+	return &WasteHandlerImpl{
+		wasteUsecase: wasteUsecase,
+	}
+}
+
+func (h *WasteHandlerImpl) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req dto.CreateWasteLogRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Log.WithError(err).Error("Error invalid request body")
+		utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid request body"))
+		return
+	}
+	actorId, _, err := getUserFromContext(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting user from context")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+
+	wasteLog, err := h.wasteUsecase.Create(ctx, actorId, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create waste log")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusCreated, wasteLog, nil)
+}
+
+func (h *WasteHandlerImpl) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+	req := dto.GetWasteLogsRequest{
+		Reason:       query.Get("reason"),
+		IngredientId: query.Get("ingredient_id"),
+		MenuId:       query.Get("menu_id"),
+		CreatedFrom:  query.Get("created_from"),
+		CreatedTo:    query.Get("created_to"),
+	}
+
+	var err error
+	if page := query.Get("page"); page != "" {
+		req.Page, err = strconv.Atoi(page)
+		if err != nil {
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid page format"))
+			return
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		req.Limit, err = strconv.Atoi(limit)
+		if err != nil {
+			utils.HttpResponse(w, http.StatusBadRequest, nil, utils.NewValidationError("Invalid limit format"))
+			return
+		}
+	}
+
+	wasteLogs, err := h.wasteUsecase.GetAll(ctx, req)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get waste logs")
+		utils.HttpResponse(w, utils.GetErrorStatus(err), nil, err)
+		return
+	}
+	utils.HttpResponse(w, http.StatusOK, wasteLogs, nil)
+}
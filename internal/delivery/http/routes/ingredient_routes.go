@@ -10,4 +10,12 @@ func IngredientRoutes(protected *mux.Router, handler handler.IngredientHandler)
 	protected.HandleFunc("/ingredients/{id}", handler.Update).Methods("PATCH")
 	protected.HandleFunc("/ingredients/{id}", handler.Delete).Methods("DELETE")
 	protected.HandleFunc("/ingredients/{id}/restore", handler.Restore).Methods("PATCH")
+	protected.HandleFunc("/ingredients/{id}/conversions", handler.GetConversions).Methods("GET")
+	protected.HandleFunc("/ingredients/{id}/conversions", handler.CreateConversion).Methods("POST")
+	protected.HandleFunc("/ingredients/{id}/conversions/{conversionId}", handler.DeleteConversion).Methods("DELETE")
+	protected.HandleFunc("/ingredients/{id}/cost", handler.UpdateCost).Methods("PUT")
+	protected.HandleFunc("/ingredients/{id}/cost-history", handler.GetCostHistory).Methods("GET")
+	protected.HandleFunc("/ingredients/{id}/nutrition", handler.GetNutrition).Methods("GET")
+	protected.HandleFunc("/ingredients/{id}/nutrition", handler.UpdateNutrition).Methods("PUT")
+	protected.HandleFunc("/ingredients/{id}/nutrition", handler.DeleteNutrition).Methods("DELETE")
 }
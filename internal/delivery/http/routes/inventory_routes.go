@@ -7,9 +7,17 @@ import (
 
 func InventoryRoutes(protected *mux.Router, handler handler.InventoryHandler) {
 	protected.HandleFunc("/inventory", handler.Create).Methods("POST")
+	protected.HandleFunc("/inventory/availability", handler.GetMenuAvailability).Methods("GET")
+	protected.HandleFunc("/inventory/low-stock", handler.GetLowStock).Methods("GET")
+	protected.HandleFunc("/inventory/expiring", handler.GetExpiringLots).Methods("GET")
+	protected.HandleFunc("/inventory/transfers", handler.Transfer).Methods("POST")
+	protected.HandleFunc("/inventory/transfers", handler.GetTransfers).Methods("GET")
 	protected.HandleFunc("/inventory/{id}", handler.GetOneById).Methods("GET")
 	protected.HandleFunc("/inventory/{id}/ingredient", handler.GetOneByIngredientId).Methods("GET")
 	protected.HandleFunc("/inventory/{id}", handler.Update).Methods("PATCH")
+	protected.HandleFunc("/inventory/{id}/movements", handler.GetMovements).Methods("GET")
+	protected.HandleFunc("/inventory/{id}/lots", handler.GetLots).Methods("GET")
+	protected.HandleFunc("/inventory/{id}/adjustments", handler.CreateAdjustment).Methods("POST")
 	protected.HandleFunc("/inventory/{id}", handler.Delete).Methods("DELETE")
 	protected.HandleFunc("/inventory/{id}/restore", handler.Restore).Methods("PATCH")
 	protected.HandleFunc("/inventory/menu/{menu_id}", handler.CalculateMenuPortions).Methods("GET")
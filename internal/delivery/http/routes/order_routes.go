@@ -5,11 +5,25 @@ import (
 	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
 )
 
-func OrderRoutes(protected *mux.Router, handler handler.OrderHandler) {
+func OrderRoutes(public, protected *mux.Router, handler handler.OrderHandler) {
+	// payment provider, authenticated by the webhook signature
+	public.HandleFunc("/payments/webhook", handler.PaymentWebhook).Methods("POST")
+
 	protected.HandleFunc("/orders", handler.Create).Methods("POST")
+	protected.HandleFunc("/orders", handler.GetAll).Methods("GET")
 	protected.HandleFunc("/orders/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/orders/{id}/history", handler.GetStatusHistory).Methods("GET")
+	protected.HandleFunc("/orders/{id}/cancel", handler.Cancel).Methods("PATCH")
+	protected.HandleFunc("/orders/{id}/payments", handler.GetPayments).Methods("GET")
+	protected.HandleFunc("/orders/{id}/refunds", handler.GetRefunds).Methods("GET")
+	protected.HandleFunc("/orders/{id}/voids", handler.GetVoids).Methods("GET")
 
 	// staff and admin only
 	protected.HandleFunc("/orders/{id}/status", handler.UpdateOrderStatus).Methods("PATCH")
 	protected.HandleFunc("/orders/{id}/payment", handler.UpdatePayment).Methods("PATCH")
+	protected.HandleFunc("/orders/{id}/payments", handler.CreatePayment).Methods("POST")
+	protected.HandleFunc("/orders/{id}/payments/{paymentId}/capture", handler.CapturePayment).Methods("PATCH")
+	// refunds above the approval threshold also need a manager
+	protected.HandleFunc("/orders/{id}/refunds", handler.CreateRefund).Methods("POST")
+	protected.HandleFunc("/orders/{id}/voids", handler.CreateVoid).Methods("POST")
 }
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func PurchaseOrderRoutes(protected *mux.Router, handler handler.PurchaseOrderHandler) {
+	protected.HandleFunc("/purchase-orders", handler.Create).Methods("POST")
+	protected.HandleFunc("/purchase-orders", handler.GetAll).Methods("GET")
+	protected.HandleFunc("/purchase-orders/generate", handler.GenerateFromLowStock).Methods("POST")
+	protected.HandleFunc("/purchase-orders/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/purchase-orders/{id}/send", handler.Send).Methods("PATCH")
+	protected.HandleFunc("/purchase-orders/{id}/cancel", handler.Cancel).Methods("PATCH")
+	protected.HandleFunc("/purchase-orders/{id}/receive", handler.Receive).Methods("POST")
+}
@@ -9,7 +9,16 @@ func RecipeRoutes(protected *mux.Router, handler handler.RecipeHandler) {
 	protected.HandleFunc("/recipes", handler.Create).Methods("POST")
 	protected.HandleFunc("/recipes", handler.GetAll).Methods("GET")
 	protected.HandleFunc("/recipes/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/recipes/{id}/cost", handler.GetCost).Methods("GET")
+	protected.HandleFunc("/recipes/{id}/batch-sheet", handler.GetBatchSheet).Methods("GET")
+	protected.HandleFunc("/recipes/{id}/nutrition", handler.GetNutrition).Methods("GET")
 	protected.HandleFunc("/recipes/{id}", handler.Update).Methods("PATCH")
 	protected.HandleFunc("/recipes/{id}", handler.Delete).Methods("DELETE")
 	protected.HandleFunc("/recipes/{id}/restore", handler.Restore).Methods("PATCH")
+	protected.HandleFunc("/recipes/{id}/versions", handler.CreateVersion).Methods("POST")
+	protected.HandleFunc("/recipes/{id}/versions", handler.GetVersions).Methods("GET")
+	protected.HandleFunc("/recipes/{id}/versions/{versionId}", handler.GetVersion).Methods("GET")
+	protected.HandleFunc("/recipes/{id}/versions/{versionId}", handler.UpdateVersion).Methods("PATCH")
+	protected.HandleFunc("/recipes/{id}/versions/{versionId}", handler.DeleteVersion).Methods("DELETE")
+	protected.HandleFunc("/recipes/{id}/versions/{versionId}/publish", handler.PublishVersion).Methods("PATCH")
 }
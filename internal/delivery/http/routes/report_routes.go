@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func ReportRoutes(protected *mux.Router, handler handler.ReportHandler) {
+	protected.HandleFunc("/reports/menu-margins", handler.GetMenuMargins).Methods("GET")
+	protected.HandleFunc("/reports/waste", handler.GetWaste).Methods("GET")
+	protected.HandleFunc("/reports/ingredient-forecast", handler.GetIngredientForecast).Methods("GET")
+}
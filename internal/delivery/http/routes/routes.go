@@ -11,11 +11,18 @@ func NewRoutes(public, protected *mux.Router, handlers *handler.Handlers) {
 	UserRoutes(public, protected, handlers.UserHandler)
 	ReviewRoutes(public, protected, handlers.ReviewHandler)
 	AuthRoutes(public, handlers.AuthHandler)
-	OrderRoutes(protected, handlers.OrderHandler)
+	OrderRoutes(public, protected, handlers.OrderHandler)
 	TableRoutes(public, protected, handlers.TableHandler)
 	ReservationRoutes(public, protected, handlers.ReservationHandler)
 	RecipeRoutes(protected, handlers.RecipeHandler)
 	InventoryRoutes(protected, handlers.InventoryHandler)
 	IngredientRoutes(protected, handlers.IngredientHandler)
+	SupplierRoutes(protected, handlers.SupplierHandler)
+	PurchaseOrderRoutes(protected, handlers.PurchaseOrderHandler)
+	ReportRoutes(protected, handlers.ReportHandler)
+	WasteRoutes(protected, handlers.WasteHandler)
+	StocktakeRoutes(protected, handlers.StocktakeHandler)
+	StorageLocationRoutes(protected, handlers.StorageLocationHandler)
+	TaxRateRoutes(protected, handlers.TaxRateHandler)
 
 }
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func StocktakeRoutes(protected *mux.Router, handler handler.StocktakeHandler) {
+	protected.HandleFunc("/stocktakes", handler.Create).Methods("POST")
+	protected.HandleFunc("/stocktakes", handler.GetAll).Methods("GET")
+	protected.HandleFunc("/stocktakes/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/stocktakes/{id}/counts", handler.SubmitCounts).Methods("POST")
+	protected.HandleFunc("/stocktakes/{id}/close", handler.Close).Methods("PATCH")
+	protected.HandleFunc("/stocktakes/{id}/cancel", handler.Cancel).Methods("PATCH")
+}
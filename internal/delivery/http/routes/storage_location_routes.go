@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func StorageLocationRoutes(protected *mux.Router, handler handler.StorageLocationHandler) {
+	protected.HandleFunc("/storage-locations", handler.Create).Methods("POST")
+	protected.HandleFunc("/storage-locations", handler.GetAll).Methods("GET")
+	protected.HandleFunc("/storage-locations/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/storage-locations/{id}/inventory", handler.GetInventory).Methods("GET")
+	protected.HandleFunc("/storage-locations/{id}", handler.Update).Methods("PATCH")
+	protected.HandleFunc("/storage-locations/{id}", handler.Delete).Methods("DELETE")
+}
@@ -0,0 +1,16 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func SupplierRoutes(protected *mux.Router, handler handler.SupplierHandler) {
+	protected.HandleFunc("/suppliers", handler.Create).Methods("POST")
+	protected.HandleFunc("/suppliers", handler.GetAll).Methods("GET")
+	protected.HandleFunc("/suppliers/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/suppliers/{id}", handler.Update).Methods("PATCH")
+	protected.HandleFunc("/suppliers/{id}", handler.Delete).Methods("DELETE")
+	protected.HandleFunc("/suppliers/{id}/ingredients", handler.SetIngredient).Methods("PUT")
+	protected.HandleFunc("/suppliers/{id}/ingredients/{ingredientId}", handler.RemoveIngredient).Methods("DELETE")
+}
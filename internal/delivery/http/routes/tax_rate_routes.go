@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func TaxRateRoutes(protected *mux.Router, handler handler.TaxRateHandler) {
+	protected.HandleFunc("/tax-rates", handler.Create).Methods("POST")
+	protected.HandleFunc("/tax-rates", handler.GetAll).Methods("GET")
+	protected.HandleFunc("/tax-rates/{id}", handler.GetOneById).Methods("GET")
+	protected.HandleFunc("/tax-rates/{id}", handler.Update).Methods("PATCH")
+	protected.HandleFunc("/tax-rates/{id}", handler.Delete).Methods("DELETE")
+}
@@ -0,0 +1,11 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+)
+
+func WasteRoutes(protected *mux.Router, handler handler.WasteHandler) {
+	protected.HandleFunc("/waste", handler.Create).Methods("POST")
+	protected.HandleFunc("/waste", handler.GetAll).Methods("GET")
+}
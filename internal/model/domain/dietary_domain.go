@@ -0,0 +1,42 @@
+package domain
+
+// The 14 major allergens that have to be declared to guests
+const (
+	AllergenCelery      = "celery"
+	AllergenCrustaceans = "crustaceans"
+	AllergenEggs        = "eggs"
+	AllergenFish        = "fish"
+	AllergenGluten      = "gluten"
+	AllergenLupin       = "lupin"
+	AllergenMilk        = "milk"
+	AllergenMolluscs    = "molluscs"
+	AllergenMustard     = "mustard"
+	AllergenPeanuts     = "peanuts"
+	AllergenSesame      = "sesame"
+	AllergenSoy         = "soy"
+	AllergenSulphites   = "sulphites"
+	AllergenTreeNuts    = "tree_nuts"
+)
+
+var Allergens = []string{
+	AllergenCelery, AllergenCrustaceans, AllergenEggs, AllergenFish, AllergenGluten, AllergenLupin, AllergenMilk,
+	AllergenMolluscs, AllergenMustard, AllergenPeanuts, AllergenSesame, AllergenSoy, AllergenSulphites, AllergenTreeNuts,
+}
+
+// Diets an ingredient is tagged with, a vegan ingredient is also vegetarian
+const (
+	DietVegan      = "vegan"
+	DietVegetarian = "vegetarian"
+	DietHalal      = "halal"
+)
+
+var IngredientDiets = []string{DietVegan, DietVegetarian, DietHalal}
+
+// Diets a menu item fits because it does not contain an allergen
+const (
+	DietGlutenFree = "gluten_free"
+	DietDairyFree  = "dairy_free"
+	DietNutFree    = "nut_free"
+)
+
+var MenuDiets = []string{DietVegan, DietVegetarian, DietHalal, DietGlutenFree, DietDairyFree, DietNutFree}
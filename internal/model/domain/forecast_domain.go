@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MenuDailySales is the number of portions of a menu sold on one day,
+// cancelled orders are not counted
+type MenuDailySales struct {
+	MenuId   uuid.UUID `json:"menu_id"`
+	MenuName string    `json:"menu_name"`
+	Date     time.Time `json:"date"`
+	Quantity int       `json:"quantity"`
+}
+
+type MenuForecast struct {
+	MenuId            uuid.UUID `json:"menu_id"`
+	MenuName          string    `json:"menu_name"`
+	AverageDaily      float64   `json:"average_daily"`
+	ProjectedPortions float64   `json:"projected_portions"`
+	Daily             []float64 `json:"daily"`
+}
+
+// IngredientForecast compares the projected usage of an ingredient with the
+// stock on hand and on order, SuggestedOrder keeps the stock at the reorder
+// point by the end of the forecast and is rounded up to the reorder quantity
+type IngredientForecast struct {
+	IngredientId    uuid.UUID  `json:"ingredient_id"`
+	Name            string     `json:"name"`
+	Unit            string     `json:"unit"`
+	ProjectedUsage  float64    `json:"projected_usage"`
+	OnHand          float64    `json:"on_hand"`
+	OnOrder         float64    `json:"on_order"`
+	ReorderPoint    float64    `json:"reorder_point"`
+	ReorderQuantity float64    `json:"reorder_quantity"`
+	RunsOutOn       *time.Time `json:"runs_out_on,omitempty"`
+	SuggestedOrder  float64    `json:"suggested_order"`
+}
+
+// IngredientForecastReport projects Days days from From, learned from the
+// HistoryDays days before it with a Window day moving average
+type IngredientForecastReport struct {
+	From        time.Time            `json:"from"`
+	Days        int                  `json:"days"`
+	HistoryDays int                  `json:"history_days"`
+	Window      int                  `json:"window"`
+	Menus       []MenuForecast       `json:"menus"`
+	Ingredients []IngredientForecast `json:"ingredients"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	CostSourceManual        = "manual"
+	CostSourcePurchaseOrder = "purchase_order"
+)
+
+// IngredientCostHistory records every change of the cost of one base unit of
+// an ingredient, ReferenceId points to the purchase order for received costs
+type IngredientCostHistory struct {
+	Id                  uuid.UUID  `json:"id" validate:"required"`
+	IngredientId        uuid.UUID  `json:"ingredient_id" validate:"required"`
+	CostPerUnit         float64    `json:"cost_per_unit" validate:"gte=0"`
+	PreviousCostPerUnit float64    `json:"previous_cost_per_unit"`
+	Source              string     `json:"source" validate:"required,oneof=manual purchase_order"`
+	ReferenceId         *uuid.UUID `json:"reference_id,omitempty"`
+	ActorId             *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt           time.Time  `json:"created_at" validate:"required"`
+}
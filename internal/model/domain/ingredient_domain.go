@@ -6,10 +6,21 @@ import (
 	"github.com/google/uuid"
 )
 
+// Ingredient Allergens and Diets come from the lists in dietary_domain.go,
+// an ingredient without diets is not known to fit any diet. Until
+// AllergensReviewed is set an ingredient without allergens is not known to be
+// free of any.
 type Ingredient struct {
-	Id          uuid.UUID `json:"id" validate:"required"`
-	Name        string    `json:"name" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	CreatedAt   time.Time `json:"created_at" validate:"required"`
-	UpdatedAt   time.Time `json:"updated_at" validate:"required"`
+	Id                uuid.UUID `json:"id" validate:"required"`
+	Name              string    `json:"name" validate:"required"`
+	Description       string    `json:"description" validate:"required"`
+	BaseUnit          string    `json:"base_unit" validate:"required"`
+	ReorderPoint      float64   `json:"reorder_point"`
+	ReorderQuantity   float64   `json:"reorder_quantity"`
+	CostPerUnit       float64   `json:"cost_per_unit"`
+	Allergens         []string  `json:"allergens"`
+	AllergensReviewed bool      `json:"allergens_reviewed"`
+	Diets             []string  `json:"diets"`
+	CreatedAt         time.Time `json:"created_at" validate:"required"`
+	UpdatedAt         time.Time `json:"updated_at" validate:"required"`
 }
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IngredientUnitConversion defines a unit specific to one ingredient,
+// Factor is the amount of the ingredient's base unit in one Unit
+type IngredientUnitConversion struct {
+	Id           uuid.UUID `json:"id" validate:"required"`
+	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	Unit         string    `json:"unit" validate:"required"`
+	Factor       float64   `json:"factor" validate:"required,gt=0"`
+	CreatedAt    time.Time `json:"created_at" validate:"required"`
+	UpdatedAt    time.Time `json:"updated_at" validate:"required"`
+}
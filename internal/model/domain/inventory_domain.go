@@ -9,14 +9,46 @@ import (
 type Inventory struct {
 	Id           uuid.UUID `json:"id" validate:"required"`
 	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	LocationId   uuid.UUID `json:"location_id" validate:"required"`
+	LocationName string    `json:"location_name"`
+	FeedsKitchen bool      `json:"feeds_kitchen"`
 	Quantity     float64   `json:"quantity" validate:"required"`
+	Unit         string    `json:"unit"`
 	CreatedAt    time.Time `json:"created_at" validate:"required"`
 	UpdatedAt    time.Time `json:"updated_at" validate:"required"`
 }
 
+// IngredientStock is the stock of one ingredient across all locations,
+// KitchenQuantity only counts the locations that feed the kitchen
+type IngredientStock struct {
+	IngredientId    uuid.UUID   `json:"ingredient_id"`
+	Unit            string      `json:"unit"`
+	Quantity        float64     `json:"quantity"`
+	KitchenQuantity float64     `json:"kitchen_quantity"`
+	Locations       []Inventory `json:"locations"`
+}
+
 type InventoryMenu struct {
-	TotalPortions float64                  `json:"total_portions"`
-	Menu          Menu                     `json:"menu"`
-	Recipe        Recipe                   `json:"recipe"`
-	Ingredients   []SimpleRecipeIngredient `json:"ingredients"`
+	TotalPortions      float64                  `json:"total_portions"`
+	Menu               Menu                     `json:"menu"`
+	Recipe             Recipe                   `json:"recipe"`
+	Ingredients        []SimpleRecipeIngredient `json:"ingredients"`
+	LimitingIngredient *SimpleRecipeIngredient  `json:"limiting_ingredient,omitempty"`
+}
+
+type MenuAvailability struct {
+	Menu               Menu                    `json:"menu"`
+	Tracked            bool                    `json:"tracked"`
+	TotalPortions      float64                 `json:"total_portions"`
+	LimitingIngredient *SimpleRecipeIngredient `json:"limiting_ingredient,omitempty"`
+	SoldOut            bool                    `json:"sold_out"`
+}
+
+type MissingIngredient struct {
+	IngredientId uuid.UUID `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	Required     float64   `json:"required"`
+	Available    float64   `json:"available"`
+	Missing      float64   `json:"missing"`
+	Unit         string    `json:"unit"`
 }
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// InventoryLot is one batch of an ingredient in stock, Quantity is what is
+// left of InitialQuantity in the base unit of the ingredient. Lots without
+// ExpiresAt do not expire.
+type InventoryLot struct {
+	Id              uuid.UUID  `json:"id" validate:"required"`
+	InventoryId     uuid.UUID  `json:"inventory_id" validate:"required"`
+	IngredientId    uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Name            string     `json:"name"`
+	Unit            string     `json:"unit"`
+	LotNumber       *string    `json:"lot_number,omitempty"`
+	Quantity        float64    `json:"quantity" validate:"gte=0"`
+	InitialQuantity float64    `json:"initial_quantity" validate:"required,gt=0"`
+	ReceivedAt      time.Time  `json:"received_at" validate:"required"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	ReferenceId     *uuid.UUID `json:"reference_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at" validate:"required"`
+	UpdatedAt       time.Time  `json:"updated_at" validate:"required"`
+}
+
+// InventoryLotDraw is what a movement took out of one lot, Quantity is what
+// has not been put back yet
+type InventoryLotDraw struct {
+	Id          uuid.UUID `json:"id" validate:"required"`
+	LotId       uuid.UUID `json:"lot_id" validate:"required"`
+	InventoryId uuid.UUID `json:"inventory_id" validate:"required"`
+	ReferenceId uuid.UUID `json:"reference_id" validate:"required"`
+	Quantity    float64   `json:"quantity" validate:"gte=0"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MovementTypeReceipt    = "receipt"
+	MovementTypeSale       = "sale"
+	MovementTypeWaste      = "waste"
+	MovementTypeAdjustment = "adjustment"
+	MovementTypeTransfer   = "transfer"
+)
+
+// InventoryMovement is one append-only ledger entry, Quantity is signed:
+// positive movements add stock and negative movements take it out.
+type InventoryMovement struct {
+	Id           uuid.UUID  `json:"id" validate:"required"`
+	InventoryId  uuid.UUID  `json:"inventory_id" validate:"required"`
+	IngredientId uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Type         string     `json:"type" validate:"required,oneof=receipt sale waste adjustment transfer"`
+	Quantity     float64    `json:"quantity" validate:"required"`
+	Reason       *string    `json:"reason,omitempty"`
+	ReferenceId  *uuid.UUID `json:"reference_id,omitempty"`
+	ActorId      *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" validate:"required"`
+}
+
+type InventoryLedger struct {
+	Inventory     Inventory           `json:"inventory"`
+	LedgerBalance float64             `json:"ledger_balance"`
+	Movements     []InventoryMovement `json:"movements"`
+}
@@ -10,10 +10,23 @@ type Menu struct {
 	Id          uuid.UUID `json:"id" validate:"required"`
 	Name        string    `json:"name" validate:"required"`
 	Description string    `json:"description" validate:"required"`
-	Price       float64   `json:"price" validate:"required"`
+	Price       Money     `json:"price" validate:"required"`
 	Category    string    `json:"category" validate:"required"`
 	ImageURL    string    `json:"image_url" validate:"required"`
 	CreatedAt   time.Time `json:"created_at" validate:"required"`
 	UpdatedAt   time.Time `json:"updated_at" validate:"required"`
 	Rating      float64   `json:"rating" validate:"required"`
 }
+
+// MenuDetail is a menu item with the allergens of its recipe, the diets it
+// fits and the nutrition of one portion. Without a recipe nothing is known,
+// DietaryTracked is false, Nutrition is nil and the item never passes an
+// allergen or diet filter. While an ingredient's allergens are not reviewed
+// Allergens are the ones known so far and DietaryTracked is false too.
+type MenuDetail struct {
+	Menu
+	Allergens      []string          `json:"allergens"`
+	Diets          []string          `json:"diets"`
+	DietaryTracked bool              `json:"dietary_tracked"`
+	Nutrition      *PortionNutrition `json:"nutrition"`
+}
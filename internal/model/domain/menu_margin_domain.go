@@ -0,0 +1,59 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipeIngredientCost is the cost of one recipe line, UnitCost is the cost
+// of one base unit of the ingredient
+type RecipeIngredientCost struct {
+	IngredientId uuid.UUID `json:"ingredient_id"`
+	Name         string    `json:"name"`
+	BaseQuantity float64   `json:"base_quantity"`
+	BaseUnit     string    `json:"base_unit"`
+	UnitCost     float64   `json:"unit_cost"`
+	Cost         float64   `json:"cost"`
+}
+
+// RecipeCost is the food cost of one batch of a recipe priced with the
+// version in use at At or now, UnitCost is the cost of one yield unit, one
+// portion for a menu recipe. Ingredients without a cost are listed in
+// Uncosted, the total is then understated.
+type RecipeCost struct {
+	RecipeId      uuid.UUID              `json:"recipe_id"`
+	MenuId        *uuid.UUID             `json:"menu_id"`
+	VersionId     *uuid.UUID             `json:"version_id,omitempty"`
+	Version       int                    `json:"version"`
+	At            *time.Time             `json:"at,omitempty"`
+	Name          string                 `json:"name"`
+	YieldQuantity float64                `json:"yield_quantity"`
+	YieldUnit     string                 `json:"yield_unit"`
+	Ingredients   []RecipeIngredientCost `json:"ingredients"`
+	Uncosted      []string               `json:"uncosted"`
+	Total         float64                `json:"total"`
+	UnitCost      float64                `json:"unit_cost"`
+}
+
+// MenuMargin compares the food cost of a menu item with its price, margins
+// and percentages are relative to the price
+type MenuMargin struct {
+	MenuId          uuid.UUID  `json:"menu_id"`
+	Name            string     `json:"name"`
+	Category        string     `json:"category"`
+	RecipeId        *uuid.UUID `json:"recipe_id,omitempty"`
+	Price           float64    `json:"price"`
+	FoodCost        float64    `json:"food_cost"`
+	GrossMargin     float64    `json:"gross_margin"`
+	MarginPercent   float64    `json:"margin_percent"`
+	FoodCostPercent float64    `json:"food_cost_percent"`
+	BelowTarget     bool       `json:"below_target"`
+	Uncosted        []string   `json:"uncosted"`
+}
+
+type MenuMarginReport struct {
+	TargetMarginPercent float64      `json:"target_margin_percent"`
+	BelowTargetCount    int          `json:"below_target_count"`
+	Items               []MenuMargin `json:"items"`
+}
@@ -0,0 +1,186 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Money is an amount in hundredths of the currency it is kept in, the
+// currency code lives on the record holding it. It is stored as DECIMAL(15,2)
+// and written to JSON as a plain number with two decimals, so sums and
+// products of amounts are exact.
+type Money int64
+
+// moneyScale is the number of hundredths in one unit of a currency
+const moneyScale = 100
+
+// percentScale is how finely percentages are applied, rates are kept to four
+// decimals of a percent
+const percentScale = 10000
+
+// MoneyFromFloat rounds a float amount to the nearest hundredth, it is only
+// meant for values read from configuration
+func MoneyFromFloat(amount float64) Money {
+	return Money(math.Round(amount * moneyScale))
+}
+
+// ParseMoney reads a decimal amount such as 19.99 exactly, more than two
+// decimals is an error rather than being rounded away
+func ParseMoney(s string) (Money, error) {
+	s = strings.TrimSpace(s)
+	negative := strings.HasPrefix(s, "-")
+	digits := strings.TrimPrefix(strings.TrimPrefix(s, "-"), "+")
+	units, fraction, _ := strings.Cut(digits, ".")
+	if units == "" && fraction == "" {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if len(fraction) > 2 {
+		return 0, fmt.Errorf("amount %q has more than two decimals", s)
+	}
+	for _, part := range []string{units, fraction} {
+		for _, r := range part {
+			if r < '0' || r > '9' {
+				return 0, fmt.Errorf("invalid amount %q", s)
+			}
+		}
+	}
+
+	fraction += strings.Repeat("0", 2-len(fraction))
+	if units == "" {
+		units = "0"
+	}
+	value, err := strconv.ParseInt(units+fraction, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q", s)
+	}
+	if negative {
+		value = -value
+	}
+	return Money(value), nil
+}
+
+func (m Money) String() string {
+	sign := ""
+	value := int64(m)
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, value/moneyScale, value%moneyScale)
+}
+
+// Float64 is for reports that work in floats, such as margins against food
+// cost
+func (m Money) Float64() float64 {
+	return float64(m) / moneyScale
+}
+
+// Mul is the amount of quantity units priced at m
+func (m Money) Mul(quantity int) Money {
+	return m * Money(quantity)
+}
+
+// MulRatio scales the amount by numerator/denominator, rounding half away
+// from zero
+func (m Money) MulRatio(numerator, denominator int64) Money {
+	product := new(big.Int).Mul(big.NewInt(int64(m)), big.NewInt(numerator))
+	divisor := big.NewInt(denominator)
+	if divisor.Sign() < 0 {
+		divisor.Neg(divisor)
+		product.Neg(product)
+	}
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+	if new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(divisor) >= 0 {
+		quotient.Add(quotient, big.NewInt(int64(product.Sign())))
+	}
+	return Money(quotient.Int64())
+}
+
+// Div is an even share of the amount over parts, rounded to the hundredth
+func (m Money) Div(parts int) Money {
+	return m.MulRatio(1, int64(parts))
+}
+
+// MulPercent is percent of the amount
+func (m Money) MulPercent(percent float64) Money {
+	return m.MulRatio(percentUnits(percent), 100*percentScale)
+}
+
+// ExcludePercent takes a tax of percent out of an amount that includes it
+func (m Money) ExcludePercent(percent float64) Money {
+	return m.MulRatio(100*percentScale, 100*percentScale+percentUnits(percent))
+}
+
+func percentUnits(percent float64) int64 {
+	return int64(math.Round(percent * percentScale))
+}
+
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(m.String()), nil
+}
+
+// UnmarshalJSON takes a number or a quoted number
+func (m *Money) UnmarshalJSON(data []byte) error {
+	text := strings.Trim(string(data), `"`)
+	if text == "null" {
+		return nil
+	}
+	value, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// Scan reads a DECIMAL column, older FLOAT columns are rounded to the
+// hundredth
+func (m *Money) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*m = 0
+	case []byte:
+		return m.scanText(string(value))
+	case string:
+		return m.scanText(value)
+	case int64:
+		*m = Money(value * moneyScale)
+	case float64:
+		*m = MoneyFromFloat(value)
+	default:
+		return fmt.Errorf("cannot scan %T into Money", src)
+	}
+	return nil
+}
+
+func (m *Money) scanText(text string) error {
+	// DECIMAL columns with a larger scale carry trailing zeros
+	if units, fraction, ok := strings.Cut(text, "."); ok && len(fraction) > 2 {
+		fraction = strings.TrimRight(fraction, "0")
+		if len(fraction) > 2 {
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return err
+			}
+			*m = MoneyFromFloat(value)
+			return nil
+		}
+		text = units + "." + fraction
+	}
+	value, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+	*m = value
+	return nil
+}
+
+// Value writes the amount as a decimal string so the driver never passes it
+// through a float
+func (m Money) Value() (driver.Value, error) {
+	return m.String(), nil
+}
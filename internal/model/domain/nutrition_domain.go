@@ -0,0 +1,49 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Nutrition facts, protein fat and carbs in grams and sodium in milligrams
+type Nutrition struct {
+	Kcal    float64 `json:"kcal"`
+	Protein float64 `json:"protein_g"`
+	Fat     float64 `json:"fat_g"`
+	Carbs   float64 `json:"carbs_g"`
+	Sodium  float64 `json:"sodium_mg"`
+}
+
+// IngredientNutrition is the nutrition of one base unit of an ingredient
+type IngredientNutrition struct {
+	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	BaseUnit     string    `json:"base_unit"`
+	Nutrition
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RecipeNutrition is the nutrition of one batch of a recipe and of one yield
+// unit, one portion for a menu recipe. Ingredients without nutrition data are
+// listed in Missing and Complete is false, the facts are then understated.
+type RecipeNutrition struct {
+	RecipeId      uuid.UUID  `json:"recipe_id"`
+	Name          string     `json:"name"`
+	VersionId     *uuid.UUID `json:"version_id,omitempty"`
+	Version       int        `json:"version"`
+	YieldQuantity float64    `json:"yield_quantity"`
+	YieldUnit     string     `json:"yield_unit"`
+	Batch         Nutrition  `json:"batch"`
+	PerYieldUnit  Nutrition  `json:"per_yield_unit"`
+	Complete      bool       `json:"complete"`
+	Missing       []string   `json:"missing"`
+}
+
+// PortionNutrition is the nutrition of one portion of a menu item, see
+// RecipeNutrition for Complete and Missing
+type PortionNutrition struct {
+	Nutrition
+	Complete bool     `json:"complete"`
+	Missing  []string `json:"missing"`
+}
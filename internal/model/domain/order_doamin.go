@@ -6,13 +6,69 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	OrderStatusPending   = "pending"
+	OrderStatusAccepted  = "accepted"
+	OrderStatusPreparing = "preparing"
+	OrderStatusReady     = "ready"
+	OrderStatusServed    = "served"
+	OrderStatusPickedUp  = "picked_up"
+	OrderStatusCompleted = "completed"
+	OrderStatusCancelled = "cancelled"
+	OrderStatusRefunded  = "refunded"
+)
+
+const (
+	OrderPaymentPaid          = "paid"
+	OrderPaymentUnpaid        = "unpaid"
+	OrderPaymentPartiallyPaid = "partially_paid"
+)
+
+const (
+	OrderTypeDineIn   = "dine_in"
+	OrderTypeTakeaway = "takeaway"
+)
+
+// Order Amount is the total: Subtotal, the exclusive taxes in TaxTotal, the
+// ServiceCharge and the cash Rounding. Inclusive taxes are part of Subtotal.
+// Every amount of the order is in Currency.
 type Order struct {
-	Id            uuid.UUID `json:"id" validate:"required"`
-	UserId        uuid.UUID `json:"user_id" validate:"required"`
-	Status        string    `json:"status" validate:"required,oneof=pending processing success failed"`
-	PaymentMethod *string   `json:"payment_method,omitempty"`
-	PaymentStatus string    `json:"payment_status" validate:"required,oneof=paid unpaid"`
-	Amount        float64   `json:"amount" validate:"required"`
-	CreatedAt     time.Time `json:"created_at" validate:"required"`
-	UpdatedAt     time.Time `json:"updated_at" validate:"required"`
+	Id                   uuid.UUID      `json:"id" validate:"required"`
+	UserId               uuid.UUID      `json:"user_id" validate:"required"`
+	OrderType            string         `json:"order_type" validate:"required,oneof=dine_in takeaway"`
+	Status               string         `json:"status" validate:"required,oneof=pending accepted preparing ready served picked_up completed cancelled refunded"`
+	PaymentMethod        *string        `json:"payment_method,omitempty"`
+	PaymentStatus        string         `json:"payment_status" validate:"required,oneof=paid unpaid partially_paid"`
+	Subtotal             Money          `json:"subtotal"`
+	TaxTotal             Money          `json:"tax_total"`
+	ServiceChargePercent float64        `json:"service_charge_percent"`
+	ServiceCharge        Money          `json:"service_charge"`
+	Rounding             Money          `json:"rounding"`
+	Amount               Money          `json:"amount" validate:"required"`
+	Currency             string         `json:"currency" validate:"required,len=3"`
+	PaidAmount           Money          `json:"paid_amount"`
+	RefundedAmount       Money          `json:"refunded_amount"`
+	Balance              Money          `json:"balance"`
+	InventoryDeducted    bool           `json:"inventory_deducted"`
+	CreatedAt            time.Time      `json:"created_at" validate:"required"`
+	UpdatedAt            time.Time      `json:"updated_at" validate:"required"`
+	Items                []OrderMenu    `json:"items,omitempty"`
+	TaxLines             []OrderTaxLine `json:"tax_lines,omitempty"`
+}
+
+type OrderFilter struct {
+	UserId        *uuid.UUID
+	Status        string
+	PaymentStatus string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	SortBy        string
+	SortOrder     string
+	Limit         int
+	Offset        int
+}
+
+type OrderList struct {
+	Orders     []Order    `json:"orders"`
+	Pagination Pagination `json:"pagination"`
 }
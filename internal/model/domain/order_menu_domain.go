@@ -3,7 +3,11 @@ package domain
 import "github.com/google/uuid"
 
 type OrderMenu struct {
-	OrderId uuid.UUID `json:"order_id" validate:"required"`
-	MenuId  uuid.UUID `json:"menu_id" validate:"required"`
-	Quantity int       `json:"quantity" validate:"required"`
+	Id        uuid.UUID `json:"id" validate:"required"`
+	OrderId   uuid.UUID `json:"order_id" validate:"required"`
+	MenuId    uuid.UUID `json:"menu_id" validate:"required"`
+	MenuName  string    `json:"menu_name" validate:"required"`
+	Quantity  int       `json:"quantity" validate:"required"`
+	UnitPrice Money     `json:"unit_price" validate:"required"`
+	Subtotal  Money     `json:"subtotal"`
 }
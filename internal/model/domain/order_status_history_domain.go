@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type OrderStatusHistory struct {
+	Id         uuid.UUID `json:"id" validate:"required"`
+	OrderId    uuid.UUID `json:"order_id" validate:"required"`
+	FromStatus *string   `json:"from_status"`
+	ToStatus   string    `json:"to_status" validate:"required"`
+	ChangedBy  uuid.UUID `json:"changed_by" validate:"required"`
+	Note       *string   `json:"note,omitempty"`
+	CreatedAt  time.Time `json:"created_at" validate:"required"`
+}
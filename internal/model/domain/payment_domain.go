@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PaymentStatusPending   = "pending"
+	PaymentStatusCapturing = "capturing"
+	PaymentStatusCaptured  = "captured"
+	PaymentStatusFailed    = "failed"
+	PaymentStatusRefunded  = "refunded"
+)
+
+const (
+	PaymentRefundStatusPending   = "pending"
+	PaymentRefundStatusCompleted = "completed"
+)
+
+// Payment is one attempt to pay an order through a payment provider,
+// ClientSecret is only returned when the attempt is created. A payment covers
+// either an amount, an even share of the order when SplitParts is set or the
+// order lines in Allocations. Rounding is what cash rounding added to the
+// balance a cash payment settled. A payment is capturing while the provider
+// is asked for the money and stays so when the answer never arrived.
+type Payment struct {
+	Id                uuid.UUID           `json:"id" validate:"required"`
+	OrderId           uuid.UUID           `json:"order_id" validate:"required"`
+	Provider          string              `json:"provider" validate:"required"`
+	ProviderReference string              `json:"provider_reference" validate:"required"`
+	Method            string              `json:"method" validate:"required"`
+	Amount            Money               `json:"amount" validate:"required"`
+	Rounding          Money               `json:"rounding"`
+	SplitParts        *int                `json:"split_parts,omitempty"`
+	RefundedAmount    Money               `json:"refunded_amount"`
+	Currency          string              `json:"currency" validate:"required,len=3"`
+	Status            string              `json:"status" validate:"required,oneof=pending capturing captured failed refunded"`
+	FailureReason     *string             `json:"failure_reason,omitempty"`
+	CreatedBy         uuid.UUID           `json:"created_by" validate:"required"`
+	CapturedAt        *time.Time          `json:"captured_at,omitempty"`
+	ClientSecret      string              `json:"client_secret,omitempty"`
+	CreatedAt         time.Time           `json:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at"`
+	Allocations       []PaymentAllocation `json:"allocations,omitempty"`
+}
+
+type PaymentAllocation struct {
+	Id          uuid.UUID `json:"id" validate:"required"`
+	PaymentId   uuid.UUID `json:"payment_id" validate:"required"`
+	OrderMenuId uuid.UUID `json:"order_menu_id" validate:"required"`
+	MenuName    string    `json:"menu_name"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+	Amount      Money     `json:"amount"`
+}
+
+// PaymentRefund is the share of a refund given back through one payment, it
+// is pending until the provider confirmed the refund
+type PaymentRefund struct {
+	Id                uuid.UUID `json:"id" validate:"required"`
+	RefundId          uuid.UUID `json:"refund_id" validate:"required"`
+	PaymentId         uuid.UUID `json:"payment_id" validate:"required"`
+	Amount            Money     `json:"amount" validate:"required,gt=0"`
+	Status            string    `json:"status" validate:"required,oneof=pending completed"`
+	ProviderReference *string   `json:"provider_reference,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
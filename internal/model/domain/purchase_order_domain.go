@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	PurchaseOrderStatusDraft             = "draft"
+	PurchaseOrderStatusSent              = "sent"
+	PurchaseOrderStatusPartiallyReceived = "partially_received"
+	PurchaseOrderStatusReceived          = "received"
+	PurchaseOrderStatusCancelled         = "cancelled"
+)
+
+type PurchaseOrder struct {
+	Id           uuid.UUID           `json:"id" validate:"required"`
+	SupplierId   uuid.UUID           `json:"supplier_id" validate:"required"`
+	SupplierName string              `json:"supplier_name"`
+	Status       string              `json:"status" validate:"required,oneof=draft sent partially_received received cancelled"`
+	Notes        *string             `json:"notes,omitempty"`
+	ExpectedAt   *time.Time          `json:"expected_at,omitempty"`
+	CreatedBy    *uuid.UUID          `json:"created_by,omitempty"`
+	SentAt       *time.Time          `json:"sent_at,omitempty"`
+	ReceivedAt   *time.Time          `json:"received_at,omitempty"`
+	Total        float64             `json:"total"`
+	Items        []PurchaseOrderItem `json:"items,omitempty"`
+	CreatedAt    time.Time           `json:"created_at" validate:"required"`
+	UpdatedAt    time.Time           `json:"updated_at" validate:"required"`
+}
+
+// PurchaseOrderItem quantities are in the base unit of the ingredient
+type PurchaseOrderItem struct {
+	Id               uuid.UUID `json:"id" validate:"required"`
+	PurchaseOrderId  uuid.UUID `json:"purchase_order_id" validate:"required"`
+	IngredientId     uuid.UUID `json:"ingredient_id" validate:"required"`
+	Name             string    `json:"name"`
+	Unit             string    `json:"unit"`
+	Quantity         float64   `json:"quantity" validate:"required,gt=0"`
+	ReceivedQuantity float64   `json:"received_quantity"`
+	UnitCost         float64   `json:"unit_cost" validate:"gte=0"`
+}
+
+type PurchaseOrderFilter struct {
+	Status     string
+	SupplierId *uuid.UUID
+}
+
+// GeneratedPurchaseOrders is the outcome of generating purchase orders from the
+// low-stock list, Unassigned items have no supplier that delivers them
+type GeneratedPurchaseOrders struct {
+	PurchaseOrders []PurchaseOrder `json:"purchase_orders"`
+	Unassigned     []LowStockItem  `json:"unassigned"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import "github.com/google/uuid"
+
+// RecipeComponent uses Quantity Unit of another recipe, the unit converts to
+// the yield unit of the component recipe
+type RecipeComponent struct {
+	Id                uuid.UUID `json:"id" validate:"required"`
+	RecipeId          uuid.UUID `json:"recipe_id" validate:"required"`
+	RecipeVersionId   uuid.UUID `json:"recipe_version_id" validate:"required"`
+	ComponentRecipeId uuid.UUID `json:"component_recipe_id" validate:"required"`
+	Quantity          float64   `json:"quantity" validate:"required"`
+	Unit              string    `json:"unit" validate:"required"`
+}
+
+// SimpleRecipeComponent is a sub-recipe line as entered together with what
+// one batch of the version of the sub-recipe in use yields
+type SimpleRecipeComponent struct {
+	RecipeId      uuid.UUID `json:"recipe_id"`
+	Name          string    `json:"name"`
+	Quantity      float64   `json:"quantity"`
+	Unit          string    `json:"unit"`
+	YieldQuantity float64   `json:"yield_quantity"`
+	YieldUnit     string    `json:"yield_unit"`
+}
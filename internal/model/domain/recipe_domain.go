@@ -6,21 +6,53 @@ import (
 	"github.com/google/uuid"
 )
 
+// Recipe without a MenuId is a prep item, e.g. a sauce or a dough, that is
+// only used as a component of other recipes. The version in use now makes
+// YieldQuantity YieldUnit of it.
 type Recipe struct {
-	Id          uuid.UUID `json:"id" validate:"required"`
-	Name        string    `json:"name" validate:"required"`
-	Description string    `json:"description" validate:"required"`
-	MenuId      uuid.UUID `json:"menu_id" validate:"required"`
-	CreatedAt   time.Time `json:"created_at" validate:"required"`
-	UpdatedAt   time.Time `json:"updated_at" validate:"required"`
+	Id            uuid.UUID  `json:"id" validate:"required"`
+	Name          string     `json:"name" validate:"required"`
+	Description   string     `json:"description" validate:"required"`
+	MenuId        *uuid.UUID `json:"menu_id"`
+	VersionId     *uuid.UUID `json:"version_id,omitempty"`
+	Version       int        `json:"version"`
+	YieldQuantity float64    `json:"yield_quantity"`
+	YieldUnit     string     `json:"yield_unit"`
+	CreatedAt     time.Time  `json:"created_at" validate:"required"`
+	UpdatedAt     time.Time  `json:"updated_at" validate:"required"`
 }
 
 type RecipeAndIngredients struct {
-	Id          uuid.UUID                `json:"id" validate:"required"`
-	Name        string                   `json:"name" validate:"required"`
-	Description string                   `json:"description" validate:"required"`
-	MenuId      uuid.UUID                `json:"menu_id" validate:"required"`
-	Ingredients []SimpleRecipeIngredient `json:"ingredients"`
-	CreatedAt   time.Time                `json:"created_at" validate:"required"`
-	UpdatedAt   time.Time                `json:"updated_at" validate:"required"`
+	Id            uuid.UUID                `json:"id" validate:"required"`
+	Name          string                   `json:"name" validate:"required"`
+	Description   string                   `json:"description" validate:"required"`
+	MenuId        *uuid.UUID               `json:"menu_id"`
+	VersionId     *uuid.UUID               `json:"version_id,omitempty"`
+	Version       int                      `json:"version"`
+	YieldQuantity float64                  `json:"yield_quantity"`
+	YieldUnit     string                   `json:"yield_unit"`
+	Ingredients   []SimpleRecipeIngredient `json:"ingredients"`
+	Components    []SimpleRecipeComponent  `json:"components"`
+	Steps         []RecipeStep             `json:"steps"`
+	CreatedAt     time.Time                `json:"created_at" validate:"required"`
+	UpdatedAt     time.Time                `json:"updated_at" validate:"required"`
+}
+
+// RecipeBatchSheet is a version of a recipe scaled to Quantity of its yield
+// unit for the kitchen, Batches is how many times the recipe as written that
+// is. Ingredients and components are scaled, steps are as written.
+type RecipeBatchSheet struct {
+	RecipeId             uuid.UUID                `json:"recipe_id"`
+	Name                 string                   `json:"name"`
+	MenuId               *uuid.UUID               `json:"menu_id"`
+	VersionId            uuid.UUID                `json:"version_id"`
+	Version              int                      `json:"version"`
+	YieldQuantity        float64                  `json:"yield_quantity"`
+	YieldUnit            string                   `json:"yield_unit"`
+	Quantity             float64                  `json:"quantity"`
+	Batches              float64                  `json:"batches"`
+	Ingredients          []SimpleRecipeIngredient `json:"ingredients"`
+	Components           []SimpleRecipeComponent  `json:"components"`
+	Steps                []RecipeStep             `json:"steps"`
+	TotalDurationMinutes int                      `json:"total_duration_minutes"`
 }
@@ -3,14 +3,23 @@ package domain
 import "github.com/google/uuid"
 
 type RecipeIngredient struct {
-	Id           uuid.UUID `json:"id" validate:"required"`
-	RecipeId     uuid.UUID `json:"recipe_id" validate:"required"`
-	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
-	Quantity     float64   `json:"quantity" validate:"required"`
+	Id              uuid.UUID `json:"id" validate:"required"`
+	RecipeId        uuid.UUID `json:"recipe_id" validate:"required"`
+	RecipeVersionId uuid.UUID `json:"recipe_version_id" validate:"required"`
+	IngredientId    uuid.UUID `json:"ingredient_id" validate:"required"`
+	Quantity        float64   `json:"quantity" validate:"required"`
+	Unit            string    `json:"unit" validate:"required"`
 }
 
+// SimpleRecipeIngredient is a recipe line as entered, BaseQuantity is the
+// same quantity expressed in the base unit of the ingredient and CostPerUnit
+// the cost of one base unit
 type SimpleRecipeIngredient struct {
 	IngredientId uuid.UUID `json:"ingredient_id"`
 	Quantity     float64   `json:"quantity"`
+	Unit         string    `json:"unit"`
 	Name         string    `json:"name"`
+	BaseUnit     string    `json:"base_unit"`
+	BaseQuantity float64   `json:"base_quantity"`
+	CostPerUnit  float64   `json:"cost_per_unit"`
 }
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecipeStep is one preparation step of a recipe version, steps are done in
+// Position order and Station is where in the kitchen the step is done
+type RecipeStep struct {
+	Id              uuid.UUID `json:"id" validate:"required"`
+	RecipeVersionId uuid.UUID `json:"recipe_version_id" validate:"required"`
+	Position        int       `json:"position" validate:"required"`
+	Instruction     string    `json:"instruction" validate:"required"`
+	DurationMinutes int       `json:"duration_minutes"`
+	Station         *string   `json:"station,omitempty"`
+	CreatedAt       time.Time `json:"created_at" validate:"required"`
+	UpdatedAt       time.Time `json:"updated_at" validate:"required"`
+}
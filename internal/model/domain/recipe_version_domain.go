@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RecipeVersionStatusDraft     = "draft"
+	RecipeVersionStatusPublished = "published"
+)
+
+// RecipeVersion holds the lines and yield of a recipe. A published version
+// never changes, the one with the latest EffectiveFrom not after a moment is
+// the recipe in use at that moment. Drafts have no EffectiveFrom yet.
+type RecipeVersion struct {
+	Id            uuid.UUID                `json:"id" validate:"required"`
+	RecipeId      uuid.UUID                `json:"recipe_id" validate:"required"`
+	Version       int                      `json:"version" validate:"required"`
+	Status        string                   `json:"status" validate:"required,oneof=draft published"`
+	YieldQuantity float64                  `json:"yield_quantity"`
+	YieldUnit     string                   `json:"yield_unit"`
+	Notes         *string                  `json:"notes,omitempty"`
+	EffectiveFrom *time.Time               `json:"effective_from,omitempty"`
+	CreatedBy     *uuid.UUID               `json:"created_by,omitempty"`
+	PublishedBy   *uuid.UUID               `json:"published_by,omitempty"`
+	PublishedAt   *time.Time               `json:"published_at,omitempty"`
+	Ingredients   []SimpleRecipeIngredient `json:"ingredients,omitempty"`
+	Components    []SimpleRecipeComponent  `json:"components,omitempty"`
+	Steps         []RecipeStep             `json:"steps,omitempty"`
+	CreatedAt     time.Time                `json:"created_at" validate:"required"`
+	UpdatedAt     time.Time                `json:"updated_at" validate:"required"`
+}
@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	RefundReasonCustomerComplaint = "customer_complaint"
+	RefundReasonWrongItem         = "wrong_item"
+	RefundReasonQualityIssue      = "quality_issue"
+	RefundReasonOvercharge        = "overcharge"
+	RefundReasonDuplicateCharge   = "duplicate_charge"
+	RefundReasonOther             = "other"
+)
+
+const (
+	VoidReasonCustomerRequest = "customer_request"
+	VoidReasonEnteredInError  = "entered_in_error"
+	VoidReasonOutOfStock      = "out_of_stock"
+	VoidReasonQualityIssue    = "quality_issue"
+	VoidReasonOther           = "other"
+)
+
+// Refund gives back money of a paid order, either an amount or the price of
+// the order lines in Items. ApprovedBy is set when the amount needed a
+// manager.
+type Refund struct {
+	Id         uuid.UUID    `json:"id" validate:"required"`
+	OrderId    uuid.UUID    `json:"order_id" validate:"required"`
+	Amount     Money        `json:"amount" validate:"required,gt=0"`
+	ReasonCode string       `json:"reason_code" validate:"required,oneof=customer_complaint wrong_item quality_issue overcharge duplicate_charge other"`
+	Note       *string      `json:"note,omitempty"`
+	Restocked  bool         `json:"restocked"`
+	CreatedBy  *uuid.UUID   `json:"created_by,omitempty"`
+	ApprovedBy *uuid.UUID   `json:"approved_by,omitempty"`
+	Items      []RefundItem `json:"items,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+}
+
+type RefundItem struct {
+	Id          uuid.UUID `json:"id" validate:"required"`
+	RefundId    uuid.UUID `json:"refund_id" validate:"required"`
+	OrderMenuId uuid.UUID `json:"order_menu_id" validate:"required"`
+	MenuName    string    `json:"menu_name"`
+	Quantity    int       `json:"quantity" validate:"required,min=1"`
+	Amount      Money     `json:"amount"`
+}
+
+// OrderVoid takes units of an order line off the bill before they are paid
+type OrderVoid struct {
+	Id          uuid.UUID  `json:"id" validate:"required"`
+	OrderId     uuid.UUID  `json:"order_id" validate:"required"`
+	OrderMenuId uuid.UUID  `json:"order_menu_id" validate:"required"`
+	MenuName    string     `json:"menu_name"`
+	Quantity    int        `json:"quantity" validate:"required,min=1"`
+	Amount      Money      `json:"amount"`
+	ReasonCode  string     `json:"reason_code" validate:"required,oneof=customer_request entered_in_error out_of_stock quality_issue other"`
+	Note        *string    `json:"note,omitempty"`
+	Restocked   bool       `json:"restocked"`
+	CreatedBy   *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
@@ -0,0 +1,38 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StockAlertLowStock  = "low_stock"
+	StockAlertRestocked = "restocked"
+)
+
+// StockAlert is raised when the stock of an ingredient over all locations
+// crosses its reorder point, downwards (low_stock) or back upwards (restocked)
+type StockAlert struct {
+	Type             string    `json:"type"`
+	IngredientId     uuid.UUID `json:"ingredient_id"`
+	InventoryId      uuid.UUID `json:"inventory_id"`
+	Name             string    `json:"name"`
+	Unit             string    `json:"unit"`
+	PreviousQuantity float64   `json:"previous_quantity"`
+	Quantity         float64   `json:"quantity"`
+	ReorderPoint     float64   `json:"reorder_point"`
+	ReorderQuantity  float64   `json:"reorder_quantity"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// LowStockItem is an ingredient whose stock across all locations is at or
+// below its reorder point
+type LowStockItem struct {
+	IngredientId    uuid.UUID `json:"ingredient_id"`
+	Name            string    `json:"name"`
+	Unit            string    `json:"unit"`
+	Quantity        float64   `json:"quantity"`
+	ReorderPoint    float64   `json:"reorder_point"`
+	ReorderQuantity float64   `json:"reorder_quantity"`
+}
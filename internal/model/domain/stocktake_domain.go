@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	StocktakeStatusOpen      = "open"
+	StocktakeStatusClosed    = "closed"
+	StocktakeStatusCancelled = "cancelled"
+)
+
+// Stocktake is one physical count session. The cost totals are filled in
+// with the lines: ShrinkageCost sums missing stock, SurplusCost sums stock
+// found over the expected quantity.
+type Stocktake struct {
+	Id              uuid.UUID       `json:"id" validate:"required"`
+	Status          string          `json:"status" validate:"required,oneof=open closed cancelled"`
+	Notes           *string         `json:"notes,omitempty"`
+	OpenedBy        *uuid.UUID      `json:"opened_by,omitempty"`
+	ClosedBy        *uuid.UUID      `json:"closed_by,omitempty"`
+	ClosedAt        *time.Time      `json:"closed_at,omitempty"`
+	Lines           []StocktakeLine `json:"lines,omitempty"`
+	ShrinkageCost   float64         `json:"shrinkage_cost"`
+	SurplusCost     float64         `json:"surplus_cost"`
+	NetVarianceCost float64         `json:"net_variance_cost"`
+	CreatedAt       time.Time       `json:"created_at" validate:"required"`
+	UpdatedAt       time.Time       `json:"updated_at" validate:"required"`
+}
+
+// StocktakeLine is the count of one ingredient at one location in the base
+// unit. ExpectedQuantity and UnitCost are the stock and cost when the count
+// was taken, the variance against them is what is posted at close.
+type StocktakeLine struct {
+	Id               uuid.UUID  `json:"id" validate:"required"`
+	StocktakeId      uuid.UUID  `json:"stocktake_id" validate:"required"`
+	IngredientId     uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Name             string     `json:"name"`
+	Unit             string     `json:"unit"`
+	LocationId       uuid.UUID  `json:"location_id" validate:"required"`
+	LocationName     string     `json:"location_name"`
+	CountedQuantity  float64    `json:"counted_quantity" validate:"gte=0"`
+	ExpectedQuantity float64    `json:"expected_quantity"`
+	Variance         float64    `json:"variance"`
+	UnitCost         float64    `json:"unit_cost"`
+	VarianceCost     float64    `json:"variance_cost"`
+	CountedBy        *uuid.UUID `json:"counted_by,omitempty"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
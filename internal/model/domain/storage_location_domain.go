@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// StorageLocation is a place stock is kept. Only locations that feed the
+// kitchen count towards menu availability and are drawn down by orders.
+type StorageLocation struct {
+	Id           uuid.UUID `json:"id" validate:"required"`
+	Name         string    `json:"name" validate:"required"`
+	FeedsKitchen bool      `json:"feeds_kitchen"`
+	IsDefault    bool      `json:"is_default"`
+	CreatedAt    time.Time `json:"created_at" validate:"required"`
+	UpdatedAt    time.Time `json:"updated_at" validate:"required"`
+}
+
+// StockTransfer moves Quantity of an ingredient, in its base unit, from one
+// location to another
+type StockTransfer struct {
+	Id               uuid.UUID  `json:"id" validate:"required"`
+	IngredientId     uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Name             string     `json:"name"`
+	Unit             string     `json:"unit"`
+	FromLocationId   uuid.UUID  `json:"from_location_id" validate:"required"`
+	FromLocationName string     `json:"from_location_name"`
+	ToLocationId     uuid.UUID  `json:"to_location_id" validate:"required"`
+	ToLocationName   string     `json:"to_location_name"`
+	Quantity         float64    `json:"quantity" validate:"required,gt=0"`
+	Reason           *string    `json:"reason,omitempty"`
+	ActorId          *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt        time.Time  `json:"created_at"`
+}
+
+type StockTransferFilter struct {
+	IngredientId *uuid.UUID
+	LocationId   *uuid.UUID
+}
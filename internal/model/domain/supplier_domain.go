@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Supplier struct {
+	Id          uuid.UUID            `json:"id" validate:"required"`
+	Name        string               `json:"name" validate:"required"`
+	ContactName *string              `json:"contact_name,omitempty"`
+	Email       *string              `json:"email,omitempty"`
+	Phone       *string              `json:"phone,omitempty"`
+	Address     *string              `json:"address,omitempty"`
+	Ingredients []SupplierIngredient `json:"ingredients,omitempty"`
+	CreatedAt   time.Time            `json:"created_at" validate:"required"`
+	UpdatedAt   time.Time            `json:"updated_at" validate:"required"`
+}
+
+// SupplierIngredient is an ingredient a supplier delivers, UnitCost is the
+// price of one base unit of the ingredient
+type SupplierIngredient struct {
+	Id           uuid.UUID `json:"id" validate:"required"`
+	SupplierId   uuid.UUID `json:"supplier_id" validate:"required"`
+	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	Name         string    `json:"name"`
+	Unit         string    `json:"unit"`
+	UnitCost     float64   `json:"unit_cost" validate:"required,gte=0"`
+	LeadTimeDays int       `json:"lead_time_days" validate:"gte=0"`
+	CreatedAt    time.Time `json:"created_at" validate:"required"`
+	UpdatedAt    time.Time `json:"updated_at" validate:"required"`
+}
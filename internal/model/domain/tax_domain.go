@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaxRate is charged on the menus of Category, a rate without a category
+// applies to every category that has no rates of its own. An inclusive rate
+// is already part of the menu price.
+type TaxRate struct {
+	Id          uuid.UUID `json:"id" validate:"required"`
+	Name        string    `json:"name" validate:"required"`
+	RatePercent float64   `json:"rate_percent" validate:"required,gt=0"`
+	Inclusive   bool      `json:"inclusive"`
+	Category    *string   `json:"category,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// OrderItemTax is a rate an order line was charged, copied when the order
+// was placed so later changes to the rate don't rewrite the order
+type OrderItemTax struct {
+	Id          uuid.UUID  `json:"id" validate:"required"`
+	OrderId     uuid.UUID  `json:"order_id" validate:"required"`
+	OrderMenuId uuid.UUID  `json:"order_menu_id" validate:"required"`
+	TaxRateId   *uuid.UUID `json:"tax_rate_id,omitempty"`
+	Name        string     `json:"name" validate:"required"`
+	RatePercent float64    `json:"rate_percent"`
+	Inclusive   bool       `json:"inclusive"`
+}
+
+// OrderTaxLine is the tax of one rate over the whole order, TaxableAmount is
+// the price of the lines charged without any tax
+type OrderTaxLine struct {
+	Id            uuid.UUID  `json:"id" validate:"required"`
+	OrderId       uuid.UUID  `json:"order_id" validate:"required"`
+	TaxRateId     *uuid.UUID `json:"tax_rate_id,omitempty"`
+	Name          string     `json:"name" validate:"required"`
+	RatePercent   float64    `json:"rate_percent"`
+	Inclusive     bool       `json:"inclusive"`
+	TaxableAmount Money      `json:"taxable_amount"`
+	Amount        Money      `json:"amount"`
+}
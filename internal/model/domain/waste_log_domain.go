@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	WasteReasonSpoiled        = "spoiled"
+	WasteReasonExpired        = "expired"
+	WasteReasonDropped        = "dropped"
+	WasteReasonOverproduction = "overproduction"
+	WasteReasonComped         = "comped"
+	WasteReasonOther          = "other"
+)
+
+// WasteLog is one wasted ingredient quantity or a number of portions of a
+// menu item, Cost is the food cost when the waste was logged
+type WasteLog struct {
+	Id           uuid.UUID  `json:"id" validate:"required"`
+	IngredientId *uuid.UUID `json:"ingredient_id,omitempty"`
+	MenuId       *uuid.UUID `json:"menu_id,omitempty"`
+	Name         string     `json:"name"`
+	Quantity     float64    `json:"quantity" validate:"required,gt=0"`
+	Unit         string     `json:"unit" validate:"required"`
+	Reason       string     `json:"reason" validate:"required,oneof=spoiled expired dropped overproduction comped other"`
+	Notes        *string    `json:"notes,omitempty"`
+	Cost         float64    `json:"cost"`
+	ActorId      *uuid.UUID `json:"actor_id,omitempty"`
+	CreatedAt    time.Time  `json:"created_at" validate:"required"`
+}
+
+type WasteLogFilter struct {
+	Reason       string
+	IngredientId *uuid.UUID
+	MenuId       *uuid.UUID
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Limit        int
+	Offset       int
+}
+
+type WasteLogList struct {
+	WasteLogs  []WasteLog `json:"waste_logs"`
+	Pagination Pagination `json:"pagination"`
+}
+
+type WasteSummary struct {
+	Key     string  `json:"key"`
+	Entries int     `json:"entries"`
+	Cost    float64 `json:"cost"`
+}
+
+// WasteReport sums the cost of waste by reason and by period, From and To
+// are the requested range with To exclusive
+type WasteReport struct {
+	From      *time.Time     `json:"from,omitempty"`
+	To        *time.Time     `json:"to,omitempty"`
+	Period    string         `json:"period"`
+	Entries   int            `json:"entries"`
+	TotalCost float64        `json:"total_cost"`
+	ByReason  []WasteSummary `json:"by_reason"`
+	ByPeriod  []WasteSummary `json:"by_period"`
+}
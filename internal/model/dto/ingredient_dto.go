@@ -4,9 +4,43 @@ type CreateIngredientRequest struct {
 	Name        string  `json:"name" validate:"required"`
 	Description string  `json:"description" validate:"required"`
 	Quantity    float64 `json:"quantity" validate:"required"`
+	Unit        string  `json:"unit,omitempty" validate:"omitempty,max=20"`
 }
 
+// UpdateIngredientRequest reorder levels are in the base unit of the
+// ingredient, a reorder point of 0 turns stock alerts off. Allergens and
+// Diets given, even an empty list, replace the ones of the ingredient, giving
+// Allergens marks them as reviewed.
 type UpdateIngredientRequest struct {
-	Name        string `json:"name,omitempty" validate:"omitempty,required"`
-	Description string `json:"description,omitempty" validate:"omitempty,required"`
+	Name            string   `json:"name,omitempty" validate:"omitempty,required"`
+	Description     string   `json:"description,omitempty" validate:"omitempty,required"`
+	ReorderPoint    *float64 `json:"reorder_point,omitempty" validate:"omitempty,gte=0"`
+	ReorderQuantity *float64 `json:"reorder_quantity,omitempty" validate:"omitempty,gte=0"`
+	Allergens       []string `json:"allergens,omitempty" validate:"omitempty,dive,oneof=celery crustaceans eggs fish gluten lupin milk molluscs mustard peanuts sesame soy sulphites tree_nuts"`
+	Diets           []string `json:"diets,omitempty" validate:"omitempty,dive,oneof=vegan vegetarian halal"`
+}
+
+type CreateIngredientUnitConversionRequest struct {
+	Unit   string  `json:"unit" validate:"required,max=20"`
+	Factor float64 `json:"factor" validate:"required,gt=0"`
+}
+
+// UpdateIngredientCostRequest CostPerUnit is the price of one Unit, it is
+// stored per base unit. Unit defaults to the base unit of the ingredient.
+type UpdateIngredientCostRequest struct {
+	CostPerUnit *float64 `json:"cost_per_unit" validate:"required,gte=0"`
+	Unit        string   `json:"unit,omitempty" validate:"omitempty,max=20"`
+}
+
+// UpdateIngredientNutritionRequest the values are for Quantity Unit of the
+// ingredient, e.g. per 100 g, and are stored per base unit. Quantity defaults
+// to 1 and Unit to the base unit of the ingredient.
+type UpdateIngredientNutritionRequest struct {
+	Kcal     *float64 `json:"kcal" validate:"required,gte=0"`
+	Protein  *float64 `json:"protein_g" validate:"required,gte=0"`
+	Fat      *float64 `json:"fat_g" validate:"required,gte=0"`
+	Carbs    *float64 `json:"carbs_g" validate:"required,gte=0"`
+	Sodium   *float64 `json:"sodium_mg" validate:"required,gte=0"`
+	Quantity float64  `json:"quantity,omitempty" validate:"omitempty,gt=0"`
+	Unit     string   `json:"unit,omitempty" validate:"omitempty,max=20"`
 }
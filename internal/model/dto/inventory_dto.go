@@ -1,12 +1,60 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// CreateInventoryRequest the initial quantity becomes the first lot, without
+// ExpiresAt it does not expire. LocationId defaults to the default location.
 type CreateInventoryRequest struct {
-	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
-	Quantity     float64   `json:"quantity" validate:"required"`
+	IngredientId uuid.UUID  `json:"ingredient_id" validate:"required"`
+	LocationId   *uuid.UUID `json:"location_id,omitempty"`
+	Quantity     float64    `json:"quantity" validate:"required,gt=0"`
+	Unit         string     `json:"unit,omitempty" validate:"omitempty,max=20"`
+	LotNumber    string     `json:"lot_number,omitempty" validate:"omitempty,max=100"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
 }
 
 type UpdateInventoryRequest struct {
 	Quantity float64 `json:"quantity" validate:"required"`
+	Unit     string  `json:"unit,omitempty" validate:"omitempty,max=20"`
+	Reason   string  `json:"reason,omitempty" validate:"omitempty,max=255"`
+}
+
+// CreateInventoryAdjustmentRequest records a stock movement. Quantity is the
+// amount received or wasted, for adjustments it is the signed difference.
+// Unit defaults to the base unit of the ingredient. LotNumber and ExpiresAt
+// describe the lot added by a positive movement.
+type CreateInventoryAdjustmentRequest struct {
+	Type        string     `json:"type" validate:"required,oneof=receipt waste adjustment"`
+	Quantity    float64    `json:"quantity" validate:"required"`
+	Unit        string     `json:"unit,omitempty" validate:"omitempty,max=20"`
+	Reason      string     `json:"reason" validate:"required,max=255"`
+	ReferenceId *uuid.UUID `json:"reference_id,omitempty"`
+	LotNumber   string     `json:"lot_number,omitempty" validate:"omitempty,max=100"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+}
+
+// GetExpiringLotsRequest Days is how far ahead to look, expired lots that
+// are not written off yet are always included
+type GetExpiringLotsRequest struct {
+	Days string `json:"days,omitempty" validate:"omitempty,numeric"`
+}
+
+// CreateStockTransferRequest Quantity is in Unit, which defaults to the base
+// unit of the ingredient
+type CreateStockTransferRequest struct {
+	IngredientId   uuid.UUID `json:"ingredient_id" validate:"required"`
+	FromLocationId uuid.UUID `json:"from_location_id" validate:"required"`
+	ToLocationId   uuid.UUID `json:"to_location_id" validate:"required"`
+	Quantity       float64   `json:"quantity" validate:"required,gt=0"`
+	Unit           string    `json:"unit,omitempty" validate:"omitempty,max=20"`
+	Reason         string    `json:"reason,omitempty" validate:"omitempty,max=255"`
+}
+
+type GetStockTransfersRequest struct {
+	IngredientId string `json:"ingredient_id,omitempty" validate:"omitempty,uuid"`
+	LocationId   string `json:"location_id,omitempty" validate:"omitempty,uuid"`
 }
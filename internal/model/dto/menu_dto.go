@@ -1,18 +1,29 @@
 package dto
 
-import "mime/multipart"
+import (
+	"mime/multipart"
+
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
 
 type CreateMenuRequest struct {
 	Name        string                `form:"name" validate:"required,min=3,max=100"`
-	Price       float64               `form:"price" validate:"required,gt=0"`
+	Price       domain.Money          `form:"price" validate:"required,gt=0"`
 	Description string                `form:"description" validate:"required,min=3,max=1000"`
 	Category    string                `form:"category" validate:"required,oneof=main appetizer dessert drink snack vegetarian kids local special combo breakfast healthy international seafood spicy"`
 	Image       *multipart.FileHeader `form:"image" validate:"required"`
 }
 type UpdateMenuRequest struct {
 	Name        string                `form:"name,omitempty" validate:"omitempty,min=3,max=100"`
-	Price       float64               `form:"price,omitempty" validate:"omitempty,gt=0"`
+	Price       domain.Money          `form:"price,omitempty" validate:"omitempty,gt=0"`
 	Description string                `form:"description,omitempty" validate:"omitempty,min=3,max=1000"`
 	Category    string                `form:"category, omitempty" validate:"omitempty,oneof=main appetizer dessert drink snack vegetarian kids local special combo breakfast healthy international seafood spicy"`
 	Image       *multipart.FileHeader `form:"image,omitempty" validate:"omitempty,required"`
 }
+
+// GetMenuRequest ExcludeAllergens and Diets are comma separated, an item is
+// listed when it contains none of the allergens and fits every diet
+type GetMenuRequest struct {
+	ExcludeAllergens string `json:"exclude_allergens,omitempty" validate:"omitempty,max=255"`
+	Diets            string `json:"diets,omitempty" validate:"omitempty,max=255"`
+}
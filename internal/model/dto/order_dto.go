@@ -1,18 +1,72 @@
 package dto
 
+import "github.com/ryvasa/go-restaurant/internal/model/domain"
+
 type OrderMenuDto struct {
 	MenuId   string `json:"menu_id" validate:"required"`
-	Quantity int    `json:"quantity" validate:"required"`
+	Quantity int    `json:"quantity" validate:"required,min=1"`
 }
 
+// CreateOrderDto OrderType defaults to takeaway, only dine in orders pay the
+// service charge
 type CreateOrderDto struct {
-	Menu []OrderMenuDto `json:"menu" validate:"required"`
+	OrderType string         `json:"order_type,omitempty" validate:"omitempty,oneof=dine_in takeaway"`
+	Menu      []OrderMenuDto `json:"menu" validate:"required,min=1,dive"`
 }
 
 type UpdateOrderStatusDto struct {
-	Status string `json:"status,omitempty" validate:"omitempty,oneof=pending processing success failed"`
+	Status string `json:"status,omitempty" validate:"omitempty,oneof=pending accepted preparing ready served picked_up completed cancelled"`
+	Note   string `json:"note,omitempty" validate:"omitempty,max=255"`
+}
+
+type CancelOrderDto struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=255"`
 }
 
 type UpdatePaymentDto struct {
-	PaymentMethod *string `json:"payment_method" validate:"required"`
+	PaymentMethod *string `json:"payment_method" validate:"required,max=50"`
+}
+
+// OrderItemRequest points at a number of units of one order line
+type OrderItemRequest struct {
+	OrderMenuId string `json:"order_menu_id" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,min=1"`
+}
+
+// CreatePaymentRequest pays an amount, an even share of the order or a set
+// of order lines, without any of them the outstanding balance is paid
+type CreatePaymentRequest struct {
+	Method     string             `json:"method" validate:"required,max=50"`
+	Amount     domain.Money       `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	SplitParts int                `json:"split_parts,omitempty" validate:"omitempty,min=2,max=50"`
+	Items      []OrderItemRequest `json:"items,omitempty" validate:"omitempty,dive"`
+}
+
+type GetOrdersRequest struct {
+	UserId        string `json:"user_id,omitempty" validate:"omitempty,uuid"`
+	Status        string `json:"status,omitempty" validate:"omitempty,oneof=pending accepted preparing ready served picked_up completed cancelled refunded"`
+	PaymentStatus string `json:"payment_status,omitempty" validate:"omitempty,oneof=paid unpaid partially_paid"`
+	CreatedFrom   string `json:"created_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo     string `json:"created_to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	SortBy        string `json:"sort_by,omitempty" validate:"omitempty,oneof=created_at amount status payment_status"`
+	SortOrder     string `json:"sort_order,omitempty" validate:"omitempty,oneof=asc desc"`
+	Page          int    `json:"page,omitempty" validate:"omitempty,min=1"`
+	Limit         int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// CreateRefundRequest refunds an amount or the price of order lines, without
+// either of them everything paid and not yet refunded is given back
+type CreateRefundRequest struct {
+	Amount     domain.Money       `json:"amount,omitempty" validate:"omitempty,gt=0"`
+	Items      []OrderItemRequest `json:"items,omitempty" validate:"omitempty,dive"`
+	ReasonCode string             `json:"reason_code" validate:"required,oneof=customer_complaint wrong_item quality_issue overcharge duplicate_charge other"`
+	Note       string             `json:"note,omitempty" validate:"omitempty,max=255"`
+	Restock    bool               `json:"restock,omitempty"`
+}
+
+type CreateOrderVoidRequest struct {
+	Items      []OrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	ReasonCode string             `json:"reason_code" validate:"required,oneof=customer_request entered_in_error out_of_stock quality_issue other"`
+	Note       string             `json:"note,omitempty" validate:"omitempty,max=255"`
+	Restock    bool               `json:"restock,omitempty"`
 }
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PurchaseOrderItemRequest UnitCost is the price per Unit and defaults to the
+// supplier's price, Unit defaults to the base unit of the ingredient
+type PurchaseOrderItemRequest struct {
+	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	Quantity     float64   `json:"quantity" validate:"required,gt=0"`
+	Unit         string    `json:"unit,omitempty" validate:"omitempty,max=20"`
+	UnitCost     *float64  `json:"unit_cost,omitempty" validate:"omitempty,gte=0"`
+}
+
+type CreatePurchaseOrderRequest struct {
+	SupplierId uuid.UUID                  `json:"supplier_id" validate:"required"`
+	Notes      string                     `json:"notes,omitempty" validate:"omitempty,max=255"`
+	ExpectedAt *time.Time                 `json:"expected_at,omitempty"`
+	Items      []PurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+}
+
+type GetPurchaseOrdersRequest struct {
+	Status     string `json:"status,omitempty" validate:"omitempty,oneof=draft sent partially_received received cancelled"`
+	SupplierId string `json:"supplier_id,omitempty" validate:"omitempty,uuid"`
+}
+
+type ReceivePurchaseOrderItemRequest struct {
+	ItemId    uuid.UUID  `json:"item_id" validate:"required"`
+	Quantity  float64    `json:"quantity" validate:"required,gt=0"`
+	Unit      string     `json:"unit,omitempty" validate:"omitempty,max=20"`
+	LotNumber string     `json:"lot_number,omitempty" validate:"omitempty,max=100"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ReceivePurchaseOrderRequest LocationId is where the delivery is stored and
+// defaults to the default storage location
+type ReceivePurchaseOrderRequest struct {
+	Items      []ReceivePurchaseOrderItemRequest `json:"items" validate:"required,min=1,dive"`
+	Notes      string                            `json:"notes,omitempty" validate:"omitempty,max=255"`
+	LocationId *uuid.UUID                        `json:"location_id,omitempty"`
+}
@@ -1,16 +1,87 @@
 package dto
 
-import "github.com/google/uuid"
+import (
+	"time"
 
+	"github.com/google/uuid"
+)
+
+// CreateRecipeRequest a recipe without MenuId is a prep item used as a
+// component of other recipes, the yield defaults to one portion. A menu
+// recipe yields YieldQuantity portions per batch.
 type CreateRecipeRequest struct {
-	MenuId      uuid.UUID                 `json:"menu_id" validate:"required"`
-	Name        string                    `json:"name" validate:"required"`
-	Ingredients []CreateIngredientRequest `json:"ingredients" validate:"required"`
-	Description string                    `json:"description" validate:"required"`
+	MenuId        *uuid.UUID                     `json:"menu_id,omitempty"`
+	Name          string                         `json:"name" validate:"required"`
+	Ingredients   []CreateIngredientRequest      `json:"ingredients" validate:"required_without=Components"`
+	Components    []CreateRecipeComponentRequest `json:"components,omitempty" validate:"omitempty,dive"`
+	Description   string                         `json:"description" validate:"required"`
+	YieldQuantity float64                        `json:"yield_quantity,omitempty" validate:"omitempty,gt=0"`
+	YieldUnit     string                         `json:"yield_unit,omitempty" validate:"omitempty,max=20"`
+	Steps         []CreateRecipeStepRequest      `json:"steps,omitempty" validate:"omitempty,dive"`
 }
 
+// UpdateRecipeRequest Steps given replace all steps of the recipe
 type UpdateRecipeRequest struct {
-	Name        string                    `json:"name,omitempty" validate:"omitempty,required"`
-	Ingredients []CreateIngredientRequest `json:"ingredients,omitempty" validate:"omitempty,required"`
-	Description string                    `json:"description,omitempty" validate:"omitempty,required"`
+	Name          string                         `json:"name,omitempty" validate:"omitempty,required"`
+	Ingredients   []CreateIngredientRequest      `json:"ingredients,omitempty" validate:"omitempty,required"`
+	Components    []CreateRecipeComponentRequest `json:"components,omitempty" validate:"omitempty,dive"`
+	Description   string                         `json:"description,omitempty" validate:"omitempty,required"`
+	YieldQuantity float64                        `json:"yield_quantity,omitempty" validate:"omitempty,gt=0"`
+	YieldUnit     string                         `json:"yield_unit,omitempty" validate:"omitempty,max=20"`
+	Steps         []CreateRecipeStepRequest      `json:"steps,omitempty" validate:"omitempty,dive"`
+}
+
+// CreateRecipeComponentRequest uses Quantity of another recipe, Unit defaults
+// to the yield unit of that recipe
+type CreateRecipeComponentRequest struct {
+	RecipeId uuid.UUID `json:"recipe_id" validate:"required"`
+	Quantity float64   `json:"quantity" validate:"required,gt=0"`
+	Unit     string    `json:"unit,omitempty" validate:"omitempty,max=20"`
+}
+
+// CreateRecipeStepRequest steps are numbered in the order they are given
+type CreateRecipeStepRequest struct {
+	Instruction     string `json:"instruction" validate:"required,max=1000"`
+	DurationMinutes int    `json:"duration_minutes,omitempty" validate:"omitempty,min=0"`
+	Station         string `json:"station,omitempty" validate:"omitempty,max=50"`
+}
+
+// GetRecipeCostRequest At prices the recipe as it was made at the end of that
+// date, with the ingredient costs of that date
+type GetRecipeCostRequest struct {
+	At string `json:"at,omitempty" validate:"omitempty,datetime=2006-01-02"`
+}
+
+// CreateRecipeVersionRequest starts a draft from the latest published
+// version of the recipe
+type CreateRecipeVersionRequest struct {
+	Notes string `json:"notes,omitempty" validate:"omitempty,max=255"`
+}
+
+// UpdateRecipeVersionRequest changes a draft, ingredients and components
+// given are added or replace the matching lines, the Remove lists take lines
+// out by ingredient id and component recipe id. Steps given, even an empty
+// list, replace all steps of the draft.
+type UpdateRecipeVersionRequest struct {
+	Notes               string                         `json:"notes,omitempty" validate:"omitempty,max=255"`
+	YieldQuantity       float64                        `json:"yield_quantity,omitempty" validate:"omitempty,gt=0"`
+	YieldUnit           string                         `json:"yield_unit,omitempty" validate:"omitempty,max=20"`
+	Ingredients         []CreateIngredientRequest      `json:"ingredients,omitempty"`
+	Components          []CreateRecipeComponentRequest `json:"components,omitempty" validate:"omitempty,dive"`
+	RemoveIngredientIds []uuid.UUID                    `json:"remove_ingredient_ids,omitempty"`
+	RemoveComponentIds  []uuid.UUID                    `json:"remove_component_ids,omitempty"`
+	Steps               []CreateRecipeStepRequest      `json:"steps,omitempty" validate:"omitempty,dive"`
+}
+
+// PublishRecipeVersionRequest EffectiveFrom defaults to now and cannot be in
+// the past, orders already placed keep the version they were placed with
+type PublishRecipeVersionRequest struct {
+	EffectiveFrom *time.Time `json:"effective_from,omitempty"`
+}
+
+// GetRecipeBatchSheetRequest Quantity is in Unit, which defaults to the yield
+// unit of the recipe, so portions for a menu recipe
+type GetRecipeBatchSheetRequest struct {
+	Quantity string `json:"quantity" validate:"required,numeric"`
+	Unit     string `json:"unit,omitempty" validate:"omitempty,max=20"`
 }
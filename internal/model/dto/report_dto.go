@@ -0,0 +1,24 @@
+package dto
+
+// GetMenuMarginsRequest TargetMargin overrides the configured target margin
+// percentage for one report
+type GetMenuMarginsRequest struct {
+	TargetMargin string `json:"target_margin,omitempty" validate:"omitempty,numeric"`
+}
+
+// GetWasteReportRequest From and To are inclusive dates, Period groups the
+// waste by day, week or month and defaults to day
+type GetWasteReportRequest struct {
+	From   string `json:"from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	To     string `json:"to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Period string `json:"period,omitempty" validate:"omitempty,oneof=day week month"`
+}
+
+// GetIngredientForecastRequest Days is how far ahead to project, HistoryDays
+// how many past days of orders to learn from and Window how many of the most
+// recent days make up the moving average
+type GetIngredientForecastRequest struct {
+	Days        string `json:"days,omitempty" validate:"omitempty,numeric"`
+	HistoryDays string `json:"history_days,omitempty" validate:"omitempty,numeric"`
+	Window      string `json:"window,omitempty" validate:"omitempty,numeric"`
+}
@@ -0,0 +1,20 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateStocktakeRequest struct {
+	Notes string `json:"notes,omitempty" validate:"omitempty,max=255"`
+}
+
+// StocktakeCountRequest Quantity is what was counted in Unit, Unit defaults
+// to the base unit of the ingredient and LocationId to the default location
+type StocktakeCountRequest struct {
+	IngredientId uuid.UUID  `json:"ingredient_id" validate:"required"`
+	Quantity     *float64   `json:"quantity" validate:"required,gte=0"`
+	Unit         string     `json:"unit,omitempty" validate:"omitempty,max=20"`
+	LocationId   *uuid.UUID `json:"location_id,omitempty"`
+}
+
+type SubmitStocktakeCountsRequest struct {
+	Counts []StocktakeCountRequest `json:"counts" validate:"required,min=1,dive"`
+}
@@ -0,0 +1,17 @@
+package dto
+
+// CreateStorageLocationRequest FeedsKitchen defaults to true, a default
+// location replaces the current one
+type CreateStorageLocationRequest struct {
+	Name         string `json:"name" validate:"required,max=100"`
+	FeedsKitchen *bool  `json:"feeds_kitchen,omitempty"`
+	IsDefault    bool   `json:"is_default,omitempty"`
+}
+
+// UpdateStorageLocationRequest IsDefault can only be set, the default moves
+// when another location becomes the default
+type UpdateStorageLocationRequest struct {
+	Name         string `json:"name,omitempty" validate:"omitempty,max=100"`
+	FeedsKitchen *bool  `json:"feeds_kitchen,omitempty"`
+	IsDefault    bool   `json:"is_default,omitempty"`
+}
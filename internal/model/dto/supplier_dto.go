@@ -0,0 +1,28 @@
+package dto
+
+import "github.com/google/uuid"
+
+type CreateSupplierRequest struct {
+	Name        string  `json:"name" validate:"required,max=255"`
+	ContactName *string `json:"contact_name,omitempty" validate:"omitempty,max=255"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Address     *string `json:"address,omitempty"`
+}
+
+type UpdateSupplierRequest struct {
+	Name        string  `json:"name,omitempty" validate:"omitempty,max=255"`
+	ContactName *string `json:"contact_name,omitempty" validate:"omitempty,max=255"`
+	Email       *string `json:"email,omitempty" validate:"omitempty,email"`
+	Phone       *string `json:"phone,omitempty" validate:"omitempty,max=50"`
+	Address     *string `json:"address,omitempty"`
+}
+
+// SetSupplierIngredientRequest UnitCost is the price per Unit, Unit defaults to
+// the base unit of the ingredient
+type SetSupplierIngredientRequest struct {
+	IngredientId uuid.UUID `json:"ingredient_id" validate:"required"`
+	UnitCost     float64   `json:"unit_cost" validate:"gte=0"`
+	Unit         string    `json:"unit,omitempty" validate:"omitempty,max=20"`
+	LeadTimeDays int       `json:"lead_time_days" validate:"gte=0"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+// CreateTaxRateRequest without a category the rate applies to every category
+// that has no rates of its own
+type CreateTaxRateRequest struct {
+	Name        string  `json:"name" validate:"required,max=100"`
+	RatePercent float64 `json:"rate_percent" validate:"required,gt=0,max=100"`
+	Inclusive   bool    `json:"inclusive,omitempty"`
+	Category    string  `json:"category,omitempty" validate:"omitempty,oneof=main appetizer dessert drink snack vegetarian kids local special combo breakfast healthy international seafood spicy"`
+}
+
+// UpdateTaxRateRequest category all makes the rate apply to every category
+// again
+type UpdateTaxRateRequest struct {
+	Name        string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	RatePercent *float64 `json:"rate_percent,omitempty" validate:"omitempty,gt=0,max=100"`
+	Inclusive   *bool    `json:"inclusive,omitempty"`
+	Category    string   `json:"category,omitempty" validate:"omitempty,oneof=main appetizer dessert drink snack vegetarian kids local special combo breakfast healthy international seafood spicy all"`
+}
@@ -11,5 +11,5 @@ type UpdateUserRequest struct {
 	Email    string  `json:"email,omitempty" validate:"omitempty,email"`
 	Password string  `json:"password,omitempty" validate:"omitempty,min=6,max=100"`
 	Phone    *string `json:"phone,omitempty" validate:"omitempty,min=3,max=100"`
-	Role     string  `json:"role,omitempty" validate:"omitempty,oneof=admin customer staff"`
+	Role     string  `json:"role,omitempty" validate:"omitempty,oneof=admin customer staff manager"`
 }
@@ -0,0 +1,26 @@
+package dto
+
+import "github.com/google/uuid"
+
+// CreateWasteLogRequest wastes either Quantity of an ingredient in Unit, Unit
+// defaulting to the base unit, or Quantity whole portions of a menu item.
+// Stock is taken from LocationId, or from the kitchen when it is empty.
+type CreateWasteLogRequest struct {
+	IngredientId *uuid.UUID `json:"ingredient_id,omitempty" validate:"required_without=MenuId,excluded_with=MenuId"`
+	MenuId       *uuid.UUID `json:"menu_id,omitempty" validate:"required_without=IngredientId"`
+	Quantity     float64    `json:"quantity" validate:"required,gt=0"`
+	Unit         string     `json:"unit,omitempty" validate:"omitempty,max=20"`
+	Reason       string     `json:"reason" validate:"required,oneof=spoiled expired dropped overproduction comped other"`
+	Notes        string     `json:"notes,omitempty" validate:"omitempty,max=255"`
+	LocationId   *uuid.UUID `json:"location_id,omitempty"`
+}
+
+type GetWasteLogsRequest struct {
+	Reason       string `json:"reason,omitempty" validate:"omitempty,oneof=spoiled expired dropped overproduction comped other"`
+	IngredientId string `json:"ingredient_id,omitempty" validate:"omitempty,uuid"`
+	MenuId       string `json:"menu_id,omitempty" validate:"omitempty,uuid"`
+	CreatedFrom  string `json:"created_from,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo    string `json:"created_to,omitempty" validate:"omitempty,datetime=2006-01-02"`
+	Page         int    `json:"page,omitempty" validate:"omitempty,min=1"`
+	Limit        int    `json:"limit,omitempty" validate:"omitempty,min=1,max=100"`
+}
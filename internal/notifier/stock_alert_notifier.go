@@ -0,0 +1,13 @@
+package notifier
+
+import (
+	"context"
+
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+// StockAlertNotifier delivers stock alerts outside the application,
+// Notify is only called after the stock change has been committed
+type StockAlertNotifier interface {
+	Notify(ctx context.Context, alert domain.StockAlert) error
+}
@@ -0,0 +1,55 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/config"
+)
+
+// WebhookStockAlertNotifier posts every alert as JSON to the configured url,
+// without a url alerts are only written to the application log
+type WebhookStockAlertNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewStockAlertNotifier(cfg *config.Config) StockAlertNotifier {
+	return &WebhookStockAlertNotifier{
+		url:    cfg.Notification.StockAlertWebhookUrl,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+func (n *WebhookStockAlertNotifier) Notify(ctx context.Context, alert domain.StockAlert) error {
+	if n.url == "" {
+		return nil
+	}
+
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("stock alert webhook responded with status %d", res.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+const FakeProviderName = "fake"
+
+// FakeDeclinedMethod is the payment method the fake provider always declines
+const FakeDeclinedMethod = "fake_declined"
+
+type fakeIntent struct {
+	method   string
+	amount   domain.Money
+	status   string
+	captured domain.Money
+	refunded domain.Money
+}
+
+// FakePaymentProvider keeps payments in memory so orders can be paid in tests
+// and local development without a gateway. Webhooks are signed with the hex
+// HMAC-SHA256 of the body using the configured secret.
+type FakePaymentProvider struct {
+	secret  string
+	mu      sync.Mutex
+	intents map[string]*fakeIntent
+}
+
+func NewFakePaymentProvider(secret string) *FakePaymentProvider {
+	return &FakePaymentProvider{
+		secret:  secret,
+		intents: map[string]*fakeIntent{},
+	}
+}
+
+func (p *FakePaymentProvider) Name() string {
+	return FakeProviderName
+}
+
+func (p *FakePaymentProvider) CreateIntent(ctx context.Context, req IntentRequest) (Intent, error) {
+	if req.Amount <= 0 {
+		return Intent{}, fmt.Errorf("amount must be positive")
+	}
+
+	reference := "fake_" + uuid.NewString()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.intents[reference] = &fakeIntent{
+		method: req.Method,
+		amount: req.Amount,
+		status: StatusPending,
+	}
+	return Intent{
+		Reference:    reference,
+		Status:       StatusPending,
+		ClientSecret: reference + "_secret",
+	}, nil
+}
+
+func (p *FakePaymentProvider) Capture(ctx context.Context, reference string, amount domain.Money) (Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	intent, ok := p.intents[reference]
+	if !ok {
+		return Result{}, fmt.Errorf("unknown payment reference %s", reference)
+	}
+
+	result := Result{Reference: reference, Amount: amount}
+	switch {
+	case intent.status == StatusCaptured:
+		result.Status = StatusCaptured
+		result.Amount = intent.captured
+		return result, nil
+	case intent.status != StatusPending:
+		return Result{}, fmt.Errorf("payment %s is %s", reference, intent.status)
+	case intent.method == FakeDeclinedMethod:
+		intent.status = StatusFailed
+		result.Status = StatusFailed
+		result.FailureReason = "card_declined"
+		return result, nil
+	case amount <= 0 || amount > intent.amount:
+		return Result{}, fmt.Errorf("capture amount %s outside the intent amount %s", amount, intent.amount)
+	}
+
+	intent.status = StatusCaptured
+	intent.captured = amount
+	result.Status = StatusCaptured
+	return result, nil
+}
+
+func (p *FakePaymentProvider) Refund(ctx context.Context, reference string, amount domain.Money) (Result, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	intent, ok := p.intents[reference]
+	if !ok {
+		return Result{}, fmt.Errorf("unknown payment reference %s", reference)
+	}
+	if intent.status != StatusCaptured && intent.status != StatusRefunded {
+		return Result{}, fmt.Errorf("payment %s is %s", reference, intent.status)
+	}
+	if amount <= 0 || intent.refunded+amount > intent.captured {
+		return Result{}, fmt.Errorf("refund amount %s exceeds the %s left to refund", amount, intent.captured-intent.refunded)
+	}
+
+	intent.refunded += amount
+	if intent.refunded >= intent.captured {
+		intent.status = StatusRefunded
+	}
+	return Result{Reference: reference, Status: StatusRefunded, Amount: amount}, nil
+}
+
+func (p *FakePaymentProvider) VerifyWebhook(payload []byte, signature string) (WebhookEvent, error) {
+	expected, err := hex.DecodeString(signature)
+	if err != nil || !hmac.Equal(expected, p.mac(payload)) {
+		return WebhookEvent{}, ErrInvalidSignature
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return WebhookEvent{}, err
+	}
+	return event, nil
+}
+
+// Sign returns the signature the fake provider expects for a webhook body,
+// used to send webhooks by hand during development
+func (p *FakePaymentProvider) Sign(payload []byte) string {
+	return hex.EncodeToString(p.mac(payload))
+}
+
+func (p *FakePaymentProvider) mac(payload []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(p.secret))
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,80 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/config"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+// Statuses a provider reports for a payment
+const (
+	StatusPending  = "pending"
+	StatusCaptured = "captured"
+	StatusFailed   = "failed"
+	StatusRefunded = "refunded"
+)
+
+// Event types a provider sends to the webhook
+const (
+	EventCaptured = "payment.captured"
+	EventFailed   = "payment.failed"
+	EventRefunded = "payment.refunded"
+)
+
+// ErrInvalidSignature is returned when a webhook was not signed by the provider
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+type IntentRequest struct {
+	OrderId  string
+	Amount   domain.Money
+	Currency string
+	Method   string
+}
+
+// Intent is a payment opened with the provider, ClientSecret lets a client
+// finish the payment on the provider side and is never stored
+type Intent struct {
+	Reference    string
+	Status       string
+	ClientSecret string
+}
+
+// Result is the outcome of a capture or refund, a declined payment is a
+// result with StatusFailed and not an error
+type Result struct {
+	Reference     string
+	Status        string
+	Amount        domain.Money
+	FailureReason string
+}
+
+type WebhookEvent struct {
+	Type          string       `json:"type"`
+	Reference     string       `json:"reference"`
+	Amount        domain.Money `json:"amount"`
+	FailureReason string       `json:"failure_reason,omitempty"`
+}
+
+// PaymentProvider moves the money of an order. Errors mean the provider could
+// not be reached or refused the request, they say nothing about the payment.
+type PaymentProvider interface {
+	Name() string
+	CreateIntent(ctx context.Context, req IntentRequest) (Intent, error)
+	Capture(ctx context.Context, reference string, amount domain.Money) (Result, error)
+	Refund(ctx context.Context, reference string, amount domain.Money) (Result, error)
+	VerifyWebhook(payload []byte, signature string) (WebhookEvent, error)
+}
+
+func NewPaymentProvider(cfg *config.Config) (PaymentProvider, error) {
+	switch cfg.Payment.Provider {
+	case FakeProviderName:
+		logger.Log.Warn("Payments use the fake provider, no money is moved")
+		return NewFakePaymentProvider(cfg.Payment.WebhookSecret), nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", cfg.Payment.Provider)
+	}
+}
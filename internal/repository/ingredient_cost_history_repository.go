@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type IngredientCostHistoryRepository interface {
+	Create(ctx context.Context, history domain.IngredientCostHistory) error
+	GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.IngredientCostHistory, error)
+	GetFirstAfter(ctx context.Context, ingredientId uuid.UUID, at time.Time) (domain.IngredientCostHistory, error)
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type IngredientCostHistoryRepositoryImpl struct {
+	db DB
+}
+
+func NewIngredientCostHistoryRepository(db DB) IngredientCostHistoryRepository {
+	return &IngredientCostHistoryRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *IngredientCostHistoryRepositoryImpl) Create(ctx context.Context, history domain.IngredientCostHistory) error {
+	query := `INSERT INTO ingredient_cost_histories (id, ingredient_id, cost_per_unit, previous_cost_per_unit, source, reference_id, actor_id) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, history.Id, history.IngredientId, history.CostPerUnit, history.PreviousCostPerUnit, history.Source, history.ReferenceId, history.ActorId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *IngredientCostHistoryRepositoryImpl) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.IngredientCostHistory, error) {
+	histories := []domain.IngredientCostHistory{}
+	query := `SELECT id, ingredient_id, cost_per_unit, previous_cost_per_unit, source, reference_id, actor_id, created_at FROM ingredient_cost_histories WHERE ingredient_id = ? ORDER BY created_at DESC, id`
+	rows, err := r.db.QueryContext(ctx, query, ingredientId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var history domain.IngredientCostHistory
+		var referenceId, actorId uuid.NullUUID
+		err := rows.Scan(&history.Id, &history.IngredientId, &history.CostPerUnit, &history.PreviousCostPerUnit, &history.Source, &referenceId, &actorId, &history.CreatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		if referenceId.Valid {
+			history.ReferenceId = &referenceId.UUID
+		}
+		if actorId.Valid {
+			history.ActorId = &actorId.UUID
+		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
+
+// GetFirstAfter returns the first cost change after a moment, its previous
+// cost is what the ingredient cost at that moment
+func (r *IngredientCostHistoryRepositoryImpl) GetFirstAfter(ctx context.Context, ingredientId uuid.UUID, at time.Time) (domain.IngredientCostHistory, error) {
+	var history domain.IngredientCostHistory
+	var referenceId, actorId uuid.NullUUID
+	query := `SELECT id, ingredient_id, cost_per_unit, previous_cost_per_unit, source, reference_id, actor_id, created_at FROM ingredient_cost_histories WHERE ingredient_id = ? AND created_at > ? ORDER BY created_at, id LIMIT 1`
+	err := r.db.QueryRowContext(ctx, query, ingredientId, at).Scan(&history.Id, &history.IngredientId, &history.CostPerUnit, &history.PreviousCostPerUnit, &history.Source, &referenceId, &actorId, &history.CreatedAt)
+	if err != nil {
+		return domain.IngredientCostHistory{}, err
+	}
+	if referenceId.Valid {
+		history.ReferenceId = &referenceId.UUID
+	}
+	if actorId.Valid {
+		history.ActorId = &actorId.UUID
+	}
+	return history, nil
+}
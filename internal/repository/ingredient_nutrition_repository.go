@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type IngredientNutritionRepository interface {
+	Upsert(ctx context.Context, nutrition domain.IngredientNutrition) error
+	GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.IngredientNutrition, error)
+	DeleteByIngredientId(ctx context.Context, ingredientId uuid.UUID) error
+}
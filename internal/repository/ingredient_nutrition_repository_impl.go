@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type IngredientNutritionRepositoryImpl struct {
+	db DB
+}
+
+func NewIngredientNutritionRepository(db DB) IngredientNutritionRepository {
+	return &IngredientNutritionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *IngredientNutritionRepositoryImpl) Upsert(ctx context.Context, nutrition domain.IngredientNutrition) error {
+	query := `INSERT INTO ingredient_nutrition (ingredient_id, kcal, protein, fat, carbs, sodium) VALUES (?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE kcal = VALUES(kcal), protein = VALUES(protein), fat = VALUES(fat), carbs = VALUES(carbs), sodium = VALUES(sodium)`
+	_, err := r.db.ExecContext(ctx, query, nutrition.IngredientId, nutrition.Kcal, nutrition.Protein, nutrition.Fat, nutrition.Carbs, nutrition.Sodium)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *IngredientNutritionRepositoryImpl) GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.IngredientNutrition, error) {
+	nutrition := domain.IngredientNutrition{}
+	query := `SELECT ingredient_nutrition.ingredient_id, ingredients.base_unit, kcal, protein, fat, carbs, sodium, ingredient_nutrition.created_at, ingredient_nutrition.updated_at
+		FROM ingredient_nutrition INNER JOIN ingredients ON ingredients.id = ingredient_nutrition.ingredient_id WHERE ingredient_nutrition.ingredient_id = ?`
+	err := r.db.QueryRowContext(ctx, query, ingredientId).Scan(&nutrition.IngredientId, &nutrition.BaseUnit, &nutrition.Kcal, &nutrition.Protein, &nutrition.Fat, &nutrition.Carbs, &nutrition.Sodium,
+		&nutrition.CreatedAt, &nutrition.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.IngredientNutrition{}, err
+	}
+	return nutrition, nil
+}
+
+func (r *IngredientNutritionRepositoryImpl) DeleteByIngredientId(ctx context.Context, ingredientId uuid.UUID) error {
+	query := `DELETE FROM ingredient_nutrition WHERE ingredient_id = ?`
+	res, err := r.db.ExecContext(ctx, query, ingredientId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
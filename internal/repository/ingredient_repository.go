@@ -12,6 +12,7 @@ type IngredientRepository interface {
 	GetOneById(ctx context.Context, id uuid.UUID) (domain.Ingredient, error)
 	GetOneByName(ctx context.Context, name string) (domain.Ingredient, error)
 	Update(ctx context.Context, id uuid.UUID, ingredient domain.Ingredient) error
+	UpdateCost(ctx context.Context, id uuid.UUID, costPerUnit float64) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
 	GetDeletedById(ctx context.Context, id uuid.UUID) (domain.Ingredient, error)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,12 +21,20 @@ func NewIngredientRepository(db DB) IngredientRepository {
 	}
 }
 
+// splitSet reads a SET column, an empty set has no members
+func splitSet(value string) []string {
+	if value == "" {
+		return []string{}
+	}
+	return strings.Split(value, ",")
+}
+
 func (r *IngredientRepositoryImpl) Create(ctx context.Context, ingredient domain.Ingredient) error {
 	query := `
-		INSERT INTO ingredients (id, name, description)
-		VALUES (?, ?, ?)
+		INSERT INTO ingredients (id, name, description, base_unit)
+		VALUES (?, ?, ?, ?)
 	`
-	res, err := r.db.ExecContext(ctx, query, ingredient.Id, ingredient.Name, ingredient.Description)
+	res, err := r.db.ExecContext(ctx, query, ingredient.Id, ingredient.Name, ingredient.Description, ingredient.BaseUnit)
 	if err != nil {
 		logger.Log.Error(err)
 		return err
@@ -40,35 +49,42 @@ func (r *IngredientRepositoryImpl) Create(ctx context.Context, ingredient domain
 
 func (r *IngredientRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Ingredient, error) {
 	recipe := domain.Ingredient{}
+	var allergens, diets string
 	query := `
-		SELECT id, name, description, created_at, updated_at FROM ingredients WHERE id = ? AND deleted = false AND deleted_at IS NULL
+		SELECT id, name, description, base_unit, reorder_point, reorder_quantity, cost_per_unit, allergens, allergens_reviewed, diets, created_at, updated_at FROM ingredients WHERE id = ? AND deleted = false AND deleted_at IS NULL
 	`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&recipe.Id, &recipe.Name, &recipe.Description, &recipe.CreatedAt, &recipe.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&recipe.Id, &recipe.Name, &recipe.Description, &recipe.BaseUnit, &recipe.ReorderPoint, &recipe.ReorderQuantity, &recipe.CostPerUnit, &allergens, &recipe.AllergensReviewed, &diets, &recipe.CreatedAt, &recipe.UpdatedAt)
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Ingredient{}, err
 	}
+	recipe.Allergens = splitSet(allergens)
+	recipe.Diets = splitSet(diets)
 	return recipe, nil
 }
 
 func (r *IngredientRepositoryImpl) GetOneByName(ctx context.Context, name string) (domain.Ingredient, error) {
 	recipe := domain.Ingredient{}
+	var allergens, diets string
 	query := `
-		SELECT id, name, description FROM ingredients WHERE name = ? AND deleted = false AND deleted_at IS NULL
+		SELECT id, name, description, base_unit, reorder_point, reorder_quantity, cost_per_unit, allergens, allergens_reviewed, diets FROM ingredients WHERE name = ? AND deleted = false AND deleted_at IS NULL
 	`
-	err := r.db.QueryRowContext(ctx, query, name).Scan(&recipe.Id, &recipe.Name, &recipe.Description)
+	err := r.db.QueryRowContext(ctx, query, name).Scan(&recipe.Id, &recipe.Name, &recipe.Description, &recipe.BaseUnit, &recipe.ReorderPoint, &recipe.ReorderQuantity, &recipe.CostPerUnit, &allergens, &recipe.AllergensReviewed, &diets)
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Ingredient{}, err
 	}
+	recipe.Allergens = splitSet(allergens)
+	recipe.Diets = splitSet(diets)
 	return recipe, nil
 }
 
 func (r *IngredientRepositoryImpl) Update(ctx context.Context, id uuid.UUID, ingredient domain.Ingredient) error {
 	query := `
-		UPDATE ingredients SET name = ?, description = ? WHERE id = ?
+		UPDATE ingredients SET name = ?, description = ?, reorder_point = ?, reorder_quantity = ?, allergens = ?, allergens_reviewed = ?, diets = ? WHERE id = ?
 	`
-	res, err := r.db.ExecContext(ctx, query, ingredient.Name, ingredient.Description, id)
+	res, err := r.db.ExecContext(ctx, query, ingredient.Name, ingredient.Description, ingredient.ReorderPoint, ingredient.ReorderQuantity,
+		strings.Join(ingredient.Allergens, ","), ingredient.AllergensReviewed, strings.Join(ingredient.Diets, ","), id)
 	if err != nil {
 		logger.Log.Error(err)
 		return err
@@ -81,6 +97,18 @@ func (r *IngredientRepositoryImpl) Update(ctx context.Context, id uuid.UUID, ing
 	return nil
 }
 
+func (r *IngredientRepositoryImpl) UpdateCost(ctx context.Context, id uuid.UUID, costPerUnit float64) error {
+	query := `
+		UPDATE ingredients SET cost_per_unit = ? WHERE id = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, costPerUnit, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
 func (r *IngredientRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `
 		UPDATE ingredients SET deleted = true, deleted_at = ? WHERE id = ?
@@ -117,13 +145,16 @@ func (r *IngredientRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) er
 
 func (r *IngredientRepositoryImpl) GetDeletedById(ctx context.Context, id uuid.UUID) (domain.Ingredient, error) {
 	ingredient := domain.Ingredient{}
+	var allergens, diets string
 	query := `
-		SELECT id, name, description FROM ingredients WHERE id = ? AND deleted = true AND deleted_at IS NOT NULL
+		SELECT id, name, description, base_unit, reorder_point, reorder_quantity, cost_per_unit, allergens, allergens_reviewed, diets FROM ingredients WHERE id = ? AND deleted = true AND deleted_at IS NOT NULL
 	`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&ingredient.Id, &ingredient.Name, &ingredient.Description)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&ingredient.Id, &ingredient.Name, &ingredient.Description, &ingredient.BaseUnit, &ingredient.ReorderPoint, &ingredient.ReorderQuantity, &ingredient.CostPerUnit, &allergens, &ingredient.AllergensReviewed, &diets)
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Ingredient{}, err
 	}
+	ingredient.Allergens = splitSet(allergens)
+	ingredient.Diets = splitSet(diets)
 	return ingredient, nil
 }
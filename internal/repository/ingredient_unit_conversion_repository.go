@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type IngredientUnitConversionRepository interface {
+	Create(ctx context.Context, conversion domain.IngredientUnitConversion) error
+	GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.IngredientUnitConversion, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.IngredientUnitConversion, error)
+	GetOneByIngredientIdAndUnit(ctx context.Context, ingredientId uuid.UUID, unit string) (domain.IngredientUnitConversion, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type IngredientUnitConversionRepositoryImpl struct {
+	db DB
+}
+
+func NewIngredientUnitConversionRepository(db DB) IngredientUnitConversionRepository {
+	return &IngredientUnitConversionRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *IngredientUnitConversionRepositoryImpl) Create(ctx context.Context, conversion domain.IngredientUnitConversion) error {
+	query := `INSERT INTO ingredient_unit_conversions (id, ingredient_id, unit, factor) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, conversion.Id, conversion.IngredientId, conversion.Unit, conversion.Factor)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *IngredientUnitConversionRepositoryImpl) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.IngredientUnitConversion, error) {
+	conversions := []domain.IngredientUnitConversion{}
+	query := `SELECT id, ingredient_id, unit, factor, created_at, updated_at FROM ingredient_unit_conversions WHERE ingredient_id = ? ORDER BY unit`
+	rows, err := r.db.QueryContext(ctx, query, ingredientId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var conversion domain.IngredientUnitConversion
+		err := rows.Scan(&conversion.Id, &conversion.IngredientId, &conversion.Unit, &conversion.Factor, &conversion.CreatedAt, &conversion.UpdatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		conversions = append(conversions, conversion)
+	}
+	return conversions, nil
+}
+
+func (r *IngredientUnitConversionRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.IngredientUnitConversion, error) {
+	conversion := domain.IngredientUnitConversion{}
+	query := `SELECT id, ingredient_id, unit, factor, created_at, updated_at FROM ingredient_unit_conversions WHERE id = ?`
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&conversion.Id, &conversion.IngredientId, &conversion.Unit, &conversion.Factor, &conversion.CreatedAt, &conversion.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.IngredientUnitConversion{}, err
+	}
+	return conversion, nil
+}
+
+func (r *IngredientUnitConversionRepositoryImpl) GetOneByIngredientIdAndUnit(ctx context.Context, ingredientId uuid.UUID, unit string) (domain.IngredientUnitConversion, error) {
+	conversion := domain.IngredientUnitConversion{}
+	query := `SELECT id, ingredient_id, unit, factor, created_at, updated_at FROM ingredient_unit_conversions WHERE ingredient_id = ? AND unit = ?`
+	err := r.db.QueryRowContext(ctx, query, ingredientId, unit).Scan(&conversion.Id, &conversion.IngredientId, &conversion.Unit, &conversion.Factor, &conversion.CreatedAt, &conversion.UpdatedAt)
+	if err != nil {
+		return domain.IngredientUnitConversion{}, err
+	}
+	return conversion, nil
+}
+
+func (r *IngredientUnitConversionRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM ingredient_unit_conversions WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
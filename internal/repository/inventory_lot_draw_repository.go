@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type InventoryLotDrawRepository interface {
+	Create(ctx context.Context, draw domain.InventoryLotDraw) error
+	GetOpenByReferenceId(ctx context.Context, referenceId, inventoryId uuid.UUID) ([]domain.InventoryLotDraw, error)
+	AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type InventoryLotDrawRepositoryImpl struct {
+	db DB
+}
+
+func NewInventoryLotDrawRepository(db DB) InventoryLotDrawRepository {
+	return &InventoryLotDrawRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *InventoryLotDrawRepositoryImpl) Create(ctx context.Context, draw domain.InventoryLotDraw) error {
+	query := `INSERT INTO inventory_lot_draws (id, lot_id, inventory_id, reference_id, quantity) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, draw.Id, draw.LotId, draw.InventoryId, draw.ReferenceId, draw.Quantity)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// GetOpenByReferenceId returns what a reference took from the lots of an
+// inventory and has not put back, latest first
+func (r *InventoryLotDrawRepositoryImpl) GetOpenByReferenceId(ctx context.Context, referenceId, inventoryId uuid.UUID) ([]domain.InventoryLotDraw, error) {
+	draws := []domain.InventoryLotDraw{}
+	query := `SELECT id, lot_id, inventory_id, reference_id, quantity, created_at FROM inventory_lot_draws WHERE reference_id = ? AND inventory_id = ? AND quantity > 0 ORDER BY created_at DESC, id`
+	rows, err := r.db.QueryContext(ctx, query, referenceId, inventoryId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var draw domain.InventoryLotDraw
+		err := rows.Scan(&draw.Id, &draw.LotId, &draw.InventoryId, &draw.ReferenceId, &draw.Quantity, &draw.CreatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		draws = append(draws, draw)
+	}
+	return draws, nil
+}
+
+func (r *InventoryLotDrawRepositoryImpl) AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error {
+	query := `UPDATE inventory_lot_draws SET quantity = quantity + ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, delta, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type InventoryLotRepository interface {
+	Create(ctx context.Context, lot domain.InventoryLot) error
+	GetOpenByInventoryId(ctx context.Context, inventoryId uuid.UUID) ([]domain.InventoryLot, error)
+	GetExpiringBefore(ctx context.Context, before time.Time) ([]domain.InventoryLot, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.InventoryLot, error)
+	AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error
+}
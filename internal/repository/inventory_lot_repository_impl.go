@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type InventoryLotRepositoryImpl struct {
+	db DB
+}
+
+func NewInventoryLotRepository(db DB) InventoryLotRepository {
+	return &InventoryLotRepositoryImpl{
+		db: db,
+	}
+}
+
+const inventoryLotColumns = `inventory_lots.id, inventory_lots.inventory_id, inventory_lots.ingredient_id, ingredients.name, ingredients.base_unit, inventory_lots.lot_number, inventory_lots.quantity, inventory_lots.initial_quantity, inventory_lots.received_at, inventory_lots.expires_at, inventory_lots.reference_id, inventory_lots.created_at, inventory_lots.updated_at`
+
+func (r *InventoryLotRepositoryImpl) Create(ctx context.Context, lot domain.InventoryLot) error {
+	query := `INSERT INTO inventory_lots (id, inventory_id, ingredient_id, lot_number, quantity, initial_quantity, received_at, expires_at, reference_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, lot.Id, lot.InventoryId, lot.IngredientId, lot.LotNumber, lot.Quantity, lot.InitialQuantity, lot.ReceivedAt, lot.ExpiresAt, lot.ReferenceId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// GetOpenByInventoryId returns the lots with stock left in the order they are
+// consumed: earliest expiry first, lots without expiry last, then oldest
+func (r *InventoryLotRepositoryImpl) GetOpenByInventoryId(ctx context.Context, inventoryId uuid.UUID) ([]domain.InventoryLot, error) {
+	query := `SELECT ` + inventoryLotColumns + ` FROM inventory_lots
+		INNER JOIN ingredients ON inventory_lots.ingredient_id = ingredients.id
+		WHERE inventory_lots.inventory_id = ? AND inventory_lots.quantity > 0
+		ORDER BY inventory_lots.expires_at IS NULL, inventory_lots.expires_at, inventory_lots.received_at, inventory_lots.id`
+	return r.queryLots(ctx, query, inventoryId)
+}
+
+// GetExpiringBefore returns the lots with stock left that expire at or
+// before the given time, expired lots included
+func (r *InventoryLotRepositoryImpl) GetExpiringBefore(ctx context.Context, before time.Time) ([]domain.InventoryLot, error) {
+	query := `SELECT ` + inventoryLotColumns + ` FROM inventory_lots
+		INNER JOIN ingredients ON inventory_lots.ingredient_id = ingredients.id
+		INNER JOIN inventory ON inventory_lots.inventory_id = inventory.id
+		WHERE inventory_lots.quantity > 0 AND inventory_lots.expires_at IS NOT NULL AND inventory_lots.expires_at <= ? AND inventory.deleted_at IS NULL
+		ORDER BY inventory_lots.expires_at, ingredients.name`
+	return r.queryLots(ctx, query, before)
+}
+
+// GetOneByIdForUpdate locks the lot until the transaction ends
+func (r *InventoryLotRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.InventoryLot, error) {
+	query := `SELECT ` + inventoryLotColumns + ` FROM inventory_lots
+		INNER JOIN ingredients ON inventory_lots.ingredient_id = ingredients.id
+		WHERE inventory_lots.id = ? FOR UPDATE`
+	lots, err := r.queryLots(ctx, query, id)
+	if err != nil {
+		return domain.InventoryLot{}, err
+	}
+	if len(lots) == 0 {
+		logger.Log.WithField("lot_id", id).Error("Error inventory lot not found")
+		return domain.InventoryLot{}, sql.ErrNoRows
+	}
+	return lots[0], nil
+}
+
+func (r *InventoryLotRepositoryImpl) AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error {
+	query := `UPDATE inventory_lots SET quantity = quantity + ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, delta, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *InventoryLotRepositoryImpl) queryLots(ctx context.Context, query string, args ...interface{}) ([]domain.InventoryLot, error) {
+	lots := []domain.InventoryLot{}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var lot domain.InventoryLot
+		var lotNumber sql.NullString
+		var expiresAt sql.NullTime
+		var referenceId uuid.NullUUID
+		err := rows.Scan(&lot.Id, &lot.InventoryId, &lot.IngredientId, &lot.Name, &lot.Unit, &lotNumber, &lot.Quantity, &lot.InitialQuantity, &lot.ReceivedAt, &expiresAt, &referenceId, &lot.CreatedAt, &lot.UpdatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		if lotNumber.Valid {
+			lot.LotNumber = &lotNumber.String
+		}
+		if expiresAt.Valid {
+			lot.ExpiresAt = &expiresAt.Time
+		}
+		if referenceId.Valid {
+			lot.ReferenceId = &referenceId.UUID
+		}
+		lots = append(lots, lot)
+	}
+	return lots, nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type InventoryMovementRepository interface {
+	Create(ctx context.Context, movement domain.InventoryMovement) error
+	GetAllByInventoryId(ctx context.Context, inventoryId uuid.UUID) ([]domain.InventoryMovement, error)
+	SumByInventoryId(ctx context.Context, inventoryId uuid.UUID) (float64, error)
+	SumByReferenceId(ctx context.Context, referenceId uuid.UUID, movementType string) ([]domain.InventoryMovement, error)
+}
@@ -0,0 +1,104 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type InventoryMovementRepositoryImpl struct {
+	db DB
+}
+
+func NewInventoryMovementRepository(db DB) InventoryMovementRepository {
+	return &InventoryMovementRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *InventoryMovementRepositoryImpl) Create(ctx context.Context, movement domain.InventoryMovement) error {
+	query := `INSERT INTO inventory_movements (id, inventory_id, ingredient_id, type, quantity, reason, reference_id, actor_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, movement.Id, movement.InventoryId, movement.IngredientId, movement.Type, movement.Quantity, movement.Reason, movement.ReferenceId, movement.ActorId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *InventoryMovementRepositoryImpl) GetAllByInventoryId(ctx context.Context, inventoryId uuid.UUID) ([]domain.InventoryMovement, error) {
+	movements := []domain.InventoryMovement{}
+	query := `SELECT id, inventory_id, ingredient_id, type, quantity, reason, reference_id, actor_id, created_at FROM inventory_movements WHERE inventory_id = ? ORDER BY created_at DESC, id`
+	rows, err := r.db.QueryContext(ctx, query, inventoryId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var movement domain.InventoryMovement
+		var reason sql.NullString
+		var referenceId, actorId uuid.NullUUID
+		err := rows.Scan(&movement.Id, &movement.InventoryId, &movement.IngredientId, &movement.Type, &movement.Quantity, &reason, &referenceId, &actorId, &movement.CreatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		if reason.Valid {
+			movement.Reason = &reason.String
+		}
+		if referenceId.Valid {
+			movement.ReferenceId = &referenceId.UUID
+		}
+		if actorId.Valid {
+			movement.ActorId = &actorId.UUID
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
+
+func (r *InventoryMovementRepositoryImpl) SumByInventoryId(ctx context.Context, inventoryId uuid.UUID) (float64, error) {
+	var balance float64
+	query := `SELECT COALESCE(SUM(quantity), 0) FROM inventory_movements WHERE inventory_id = ?`
+	err := r.db.QueryRowContext(ctx, query, inventoryId).Scan(&balance)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return balance, nil
+}
+
+// SumByReferenceId nets the movements of one type booked for a reference,
+// one entry per inventory
+func (r *InventoryMovementRepositoryImpl) SumByReferenceId(ctx context.Context, referenceId uuid.UUID, movementType string) ([]domain.InventoryMovement, error) {
+	movements := []domain.InventoryMovement{}
+	query := `SELECT inventory_id, ingredient_id, SUM(quantity) FROM inventory_movements WHERE reference_id = ? AND type = ? GROUP BY inventory_id, ingredient_id`
+	rows, err := r.db.QueryContext(ctx, query, referenceId, movementType)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		movement := domain.InventoryMovement{Type: movementType, ReferenceId: &referenceId}
+		err := rows.Scan(&movement.InventoryId, &movement.IngredientId, &movement.Quantity)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		movements = append(movements, movement)
+	}
+	return movements, nil
+}
@@ -9,10 +9,20 @@ import (
 
 type InventoryRepository interface {
 	Create(ctx context.Context, inventory domain.Inventory) error
-	GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.Inventory, error)
+	GetOneByIngredientIdAndLocationId(ctx context.Context, ingredientId, locationId uuid.UUID) (domain.Inventory, error)
+	GetOneByIngredientIdAndLocationIdForUpdate(ctx context.Context, ingredientId, locationId uuid.UUID) (domain.Inventory, error)
+	GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error)
+	GetAllByLocationId(ctx context.Context, locationId uuid.UUID) ([]domain.Inventory, error)
+	GetKitchenByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error)
+	GetKitchenByIngredientIdForUpdate(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error)
+	SumQuantityByIngredientId(ctx context.Context, ingredientId uuid.UUID) (float64, error)
+	SumKitchenQuantities(ctx context.Context) (map[uuid.UUID]float64, error)
 	GetOneById(ctx context.Context, id uuid.UUID) (domain.Inventory, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Inventory, error)
 	Update(ctx context.Context, id uuid.UUID, inventory domain.Inventory) error
+	AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) error
 	GetDeletedById(ctx context.Context, id uuid.UUID) (domain.Inventory, error)
+	GetLowStock(ctx context.Context) ([]domain.LowStockItem, error)
 }
@@ -20,9 +20,17 @@ func NewInventoryRepository(db DB) InventoryRepository {
 	}
 }
 
+const inventoryQuery = `
+	SELECT inventory.id, inventory.ingredient_id, inventory.location_id, storage_locations.name, storage_locations.feeds_kitchen,
+		inventory.quantity, ingredients.base_unit, inventory.created_at, inventory.updated_at
+	FROM inventory
+	INNER JOIN ingredients ON inventory.ingredient_id = ingredients.id
+	INNER JOIN storage_locations ON inventory.location_id = storage_locations.id
+`
+
 func (r *InventoryRepositoryImpl) Create(ctx context.Context, inventory domain.Inventory) error {
-	query := `INSERT INTO inventory (id, ingredient_id, quantity) VALUES (?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, inventory.Id, inventory.IngredientId, inventory.Quantity)
+	query := `INSERT INTO inventory (id, ingredient_id, location_id, quantity) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, inventory.Id, inventory.IngredientId, inventory.LocationId, inventory.Quantity)
 	if err != nil {
 		logger.Log.Error(err)
 		return err
@@ -36,10 +44,21 @@ func (r *InventoryRepositoryImpl) Create(ctx context.Context, inventory domain.I
 	return nil
 }
 
-func (r *InventoryRepositoryImpl) GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.Inventory, error) {
-	inventory := domain.Inventory{}
-	query := `SELECT id, ingredient_id, quantity, created_at, updated_at FROM inventory WHERE ingredient_id = ?`
-	err := r.db.QueryRowContext(ctx, query, ingredientId).Scan(&inventory.Id, &inventory.IngredientId, &inventory.Quantity, &inventory.CreatedAt, &inventory.UpdatedAt)
+func (r *InventoryRepositoryImpl) GetOneByIngredientIdAndLocationId(ctx context.Context, ingredientId, locationId uuid.UUID) (domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.ingredient_id = ? AND inventory.location_id = ? AND inventory.deleted_at IS NULL`
+	inventory, err := scanInventory(r.db.QueryRowContext(ctx, query, ingredientId, locationId))
+	if err != nil {
+		logger.Log.Error(err)
+		return inventory, err
+	}
+	return inventory, nil
+}
+
+// GetOneByIngredientIdAndLocationIdForUpdate locks the inventory until the
+// transaction ends, so the stock checked is still there when it is taken
+func (r *InventoryRepositoryImpl) GetOneByIngredientIdAndLocationIdForUpdate(ctx context.Context, ingredientId, locationId uuid.UUID) (domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.ingredient_id = ? AND inventory.location_id = ? AND inventory.deleted_at IS NULL FOR UPDATE`
+	inventory, err := scanInventory(r.db.QueryRowContext(ctx, query, ingredientId, locationId))
 	if err != nil {
 		logger.Log.Error(err)
 		return inventory, err
@@ -47,10 +66,83 @@ func (r *InventoryRepositoryImpl) GetOneByIngredientId(ctx context.Context, ingr
 	return inventory, nil
 }
 
+func (r *InventoryRepositoryImpl) GetAllByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.ingredient_id = ? AND inventory.deleted_at IS NULL ORDER BY storage_locations.is_default DESC, storage_locations.name`
+	return r.queryInventories(ctx, query, ingredientId)
+}
+
+func (r *InventoryRepositoryImpl) GetAllByLocationId(ctx context.Context, locationId uuid.UUID) ([]domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.location_id = ? AND inventory.deleted_at IS NULL ORDER BY ingredients.name`
+	return r.queryInventories(ctx, query, locationId)
+}
+
+// GetKitchenByIngredientId returns the stock of an ingredient at the
+// locations feeding the kitchen, in the order they are drawn down
+func (r *InventoryRepositoryImpl) GetKitchenByIngredientId(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.ingredient_id = ? AND storage_locations.feeds_kitchen = true AND storage_locations.deleted = false AND inventory.deleted_at IS NULL ORDER BY storage_locations.is_default DESC, storage_locations.name`
+	return r.queryInventories(ctx, query, ingredientId)
+}
+
+// GetKitchenByIngredientIdForUpdate is GetKitchenByIngredientId locking the
+// inventories until the transaction ends
+func (r *InventoryRepositoryImpl) GetKitchenByIngredientIdForUpdate(ctx context.Context, ingredientId uuid.UUID) ([]domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.ingredient_id = ? AND storage_locations.feeds_kitchen = true AND storage_locations.deleted = false AND inventory.deleted_at IS NULL ORDER BY storage_locations.is_default DESC, storage_locations.name FOR UPDATE`
+	return r.queryInventories(ctx, query, ingredientId)
+}
+
+func (r *InventoryRepositoryImpl) SumQuantityByIngredientId(ctx context.Context, ingredientId uuid.UUID) (float64, error) {
+	var quantity float64
+	query := `SELECT COALESCE(SUM(quantity), 0) FROM inventory WHERE ingredient_id = ? AND deleted_at IS NULL`
+	err := r.db.QueryRowContext(ctx, query, ingredientId).Scan(&quantity)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return quantity, nil
+}
+
+// SumKitchenQuantities returns per ingredient the stock at the locations
+// feeding the kitchen, ingredients without any are missing
+func (r *InventoryRepositoryImpl) SumKitchenQuantities(ctx context.Context) (map[uuid.UUID]float64, error) {
+	quantities := map[uuid.UUID]float64{}
+	query := `SELECT inventory.ingredient_id, SUM(inventory.quantity)
+		FROM inventory INNER JOIN storage_locations ON inventory.location_id = storage_locations.id
+		WHERE storage_locations.feeds_kitchen = true AND storage_locations.deleted = false AND inventory.deleted_at IS NULL
+		GROUP BY inventory.ingredient_id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ingredientId uuid.UUID
+		var quantity float64
+		if err := rows.Scan(&ingredientId, &quantity); err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		quantities[ingredientId] = quantity
+	}
+	return quantities, nil
+}
+
 func (r *InventoryRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Inventory, error) {
-	inventory := domain.Inventory{}
-	query := `SELECT id, ingredient_id, quantity, created_at, updated_at FROM inventory WHERE id = ?`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&inventory.Id, &inventory.IngredientId, &inventory.Quantity, &inventory.CreatedAt, &inventory.UpdatedAt)
+	query := inventoryQuery + ` WHERE inventory.id = ?`
+	inventory, err := scanInventory(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return inventory, err
+	}
+	return inventory, nil
+}
+
+// GetOneByIdForUpdate is GetOneById locking the inventory until the
+// transaction ends
+func (r *InventoryRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Inventory, error) {
+	query := inventoryQuery + ` WHERE inventory.id = ? FOR UPDATE`
+	inventory, err := scanInventory(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		logger.Log.Error(err)
 		return inventory, err
@@ -73,6 +165,21 @@ func (r *InventoryRepositoryImpl) Update(ctx context.Context, id uuid.UUID, inve
 	return nil
 }
 
+func (r *InventoryRepositoryImpl) AdjustQuantity(ctx context.Context, id uuid.UUID, delta float64) error {
+	query := `UPDATE inventory SET quantity = quantity + ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, delta, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
 func (r *InventoryRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	query := `UPDATE inventory SET deleted_at = ? WHERE id = ?`
 	res, err := r.db.ExecContext(ctx, query, time.Now(), id)
@@ -107,11 +214,74 @@ func (r *InventoryRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) err
 
 func (r *InventoryRepositoryImpl) GetDeletedById(ctx context.Context, id uuid.UUID) (domain.Inventory, error) {
 	inventory := domain.Inventory{}
-	query := `SELECT id, ingredient_id, quantity FROM inventory WHERE id = ? AND deleted = true AND deleted_at IS NOT NULL`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&inventory.Id, &inventory.IngredientId, &inventory.Quantity)
+	query := `SELECT id, ingredient_id, location_id, quantity FROM inventory WHERE id = ? AND deleted = true AND deleted_at IS NOT NULL`
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&inventory.Id, &inventory.IngredientId, &inventory.LocationId, &inventory.Quantity)
 	if err != nil {
 		logger.Log.Error(err)
 		return inventory, err
 	}
 	return inventory, nil
 }
+
+func (r *InventoryRepositoryImpl) GetLowStock(ctx context.Context) ([]domain.LowStockItem, error) {
+	items := []domain.LowStockItem{}
+	query := `
+		SELECT ingredients.id, ingredients.name, ingredients.base_unit, COALESCE(SUM(inventory.quantity), 0) AS quantity, ingredients.reorder_point, ingredients.reorder_quantity
+		FROM ingredients
+		LEFT JOIN inventory ON inventory.ingredient_id = ingredients.id AND inventory.deleted_at IS NULL
+		WHERE ingredients.deleted = false AND ingredients.reorder_point > 0
+		GROUP BY ingredients.id, ingredients.name, ingredients.base_unit, ingredients.reorder_point, ingredients.reorder_quantity
+		HAVING quantity <= ingredients.reorder_point
+		ORDER BY quantity / ingredients.reorder_point, ingredients.name
+	`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.LowStockItem
+		err := rows.Scan(&item.IngredientId, &item.Name, &item.Unit, &item.Quantity, &item.ReorderPoint, &item.ReorderQuantity)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *InventoryRepositoryImpl) queryInventories(ctx context.Context, query string, args ...interface{}) ([]domain.Inventory, error) {
+	inventories := []domain.Inventory{}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		inventory, err := scanInventory(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		inventories = append(inventories, inventory)
+	}
+	return inventories, nil
+}
+
+type inventoryScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanInventory(row inventoryScanner) (domain.Inventory, error) {
+	var inventory domain.Inventory
+	err := row.Scan(&inventory.Id, &inventory.IngredientId, &inventory.LocationId, &inventory.LocationName, &inventory.FeedsKitchen, &inventory.Quantity, &inventory.Unit, &inventory.CreatedAt, &inventory.UpdatedAt)
+	if err != nil {
+		return domain.Inventory{}, err
+	}
+	return inventory, nil
+}
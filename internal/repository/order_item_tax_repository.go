@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderItemTaxRepository interface {
+	Create(ctx context.Context, tax domain.OrderItemTax) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderItemTax, error)
+}
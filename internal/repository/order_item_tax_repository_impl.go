@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderItemTaxRepositoryImpl struct {
+	db DB
+}
+
+func NewOrderItemTaxRepository(db DB) OrderItemTaxRepository {
+	return &OrderItemTaxRepositoryImpl{db}
+}
+
+func (r *OrderItemTaxRepositoryImpl) Create(ctx context.Context, tax domain.OrderItemTax) error {
+	query := `INSERT INTO order_item_taxes (id, order_id, order_menu_id, tax_rate_id, name, rate_percent, inclusive) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, tax.Id, tax.OrderId, tax.OrderMenuId, tax.TaxRateId, tax.Name, tax.RatePercent, tax.Inclusive)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *OrderItemTaxRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderItemTax, error) {
+	taxes := []domain.OrderItemTax{}
+	query := `SELECT id, order_id, order_menu_id, tax_rate_id, name, rate_percent, inclusive FROM order_item_taxes WHERE order_id = ? ORDER BY name, id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tax domain.OrderItemTax
+		var taxRateId uuid.NullUUID
+		err := rows.Scan(&tax.Id, &tax.OrderId, &tax.OrderMenuId, &taxRateId, &tax.Name, &tax.RatePercent, &tax.Inclusive)
+		if err != nil {
+			return nil, err
+		}
+		if taxRateId.Valid {
+			tax.TaxRateId = &taxRateId.UUID
+		}
+		taxes = append(taxes, tax)
+	}
+	return taxes, nil
+}
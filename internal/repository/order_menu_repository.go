@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -10,5 +11,7 @@ import (
 type OrderMenuRepository interface {
 	Create(ctx context.Context, review domain.OrderMenu) error
 	GetOneByOrderIdAndMenuId(ctx context.Context, orderId, menuId uuid.UUID) (domain.OrderMenu, error)
-	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) (domain.OrderMenu, error)
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderMenu, error)
+	UpdateQuantity(ctx context.Context, id uuid.UUID, quantity int) error
+	SumDailyQuantities(ctx context.Context, from, to time.Time) ([]domain.MenuDailySales, error)
 }
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -17,8 +18,8 @@ func NewOrderMenuRepository(db DB) OrderMenuRepository {
 }
 
 func (r *OrderMenuRepositoryImpl) Create(ctx context.Context, orderMenu domain.OrderMenu) error {
-	query := `INSERT INTO order_menu (order_id, menu_id, quantity) VALUES (?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, orderMenu.OrderId, orderMenu.MenuId, orderMenu.Quantity)
+	query := `INSERT INTO order_menu (id, order_id, menu_id, menu_name, quantity, unit_price) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, orderMenu.Id, orderMenu.OrderId, orderMenu.MenuId, orderMenu.MenuName, orderMenu.Quantity, orderMenu.UnitPrice)
 	if err != nil {
 		return err
 	}
@@ -31,23 +32,72 @@ func (r *OrderMenuRepositoryImpl) Create(ctx context.Context, orderMenu domain.O
 
 func (r *OrderMenuRepositoryImpl) GetOneByOrderIdAndMenuId(ctx context.Context, orderId, menuId uuid.UUID) (domain.OrderMenu, error) {
 	orderMenu := domain.OrderMenu{}
-	query := `SELECT  order_id, menu_id, quantity FROM order_menu WHERE order_id = ? AND menu_id = ?`
-	err := r.db.QueryRowContext(ctx, query, orderId, menuId).Scan(&orderMenu.OrderId, &orderMenu.MenuId, &orderMenu.Quantity)
+	query := `SELECT id, order_id, menu_id, menu_name, quantity, unit_price FROM order_menu WHERE order_id = ? AND menu_id = ?`
+	err := r.db.QueryRowContext(ctx, query, orderId, menuId).Scan(&orderMenu.Id, &orderMenu.OrderId, &orderMenu.MenuId, &orderMenu.MenuName, &orderMenu.Quantity, &orderMenu.UnitPrice)
 
 	if err != nil {
 		return domain.OrderMenu{}, err
 	}
+	orderMenu.Subtotal = orderMenu.UnitPrice.Mul(orderMenu.Quantity)
 	return orderMenu, nil
 }
 
-func (r *OrderMenuRepositoryImpl) GetAllByOrderId(ctx context.Context, id uuid.UUID) (domain.OrderMenu, error) {
-	orderMenu := domain.OrderMenu{}
-	query := `SELECT  order_id, menu_id, quantity FROM order_menu WHERE order_id = ? AND deleted = false AND deleted_at IS NULL`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&orderMenu.OrderId, &orderMenu.MenuId, &orderMenu.Quantity)
+func (r *OrderMenuRepositoryImpl) GetAllByOrderId(ctx context.Context, id uuid.UUID) ([]domain.OrderMenu, error) {
+	orderMenus := []domain.OrderMenu{}
+	query := `SELECT id, order_id, menu_id, menu_name, quantity, unit_price FROM order_menu WHERE order_id = ? ORDER BY created_at, menu_name`
+	rows, err := r.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderMenu domain.OrderMenu
+		err := rows.Scan(&orderMenu.Id, &orderMenu.OrderId, &orderMenu.MenuId, &orderMenu.MenuName, &orderMenu.Quantity, &orderMenu.UnitPrice)
+		if err != nil {
+			return nil, err
+		}
+		orderMenu.Subtotal = orderMenu.UnitPrice.Mul(orderMenu.Quantity)
+		orderMenus = append(orderMenus, orderMenu)
+	}
 
+	return orderMenus, nil
+}
+
+func (r *OrderMenuRepositoryImpl) SumDailyQuantities(ctx context.Context, from, to time.Time) ([]domain.MenuDailySales, error) {
+	sales := []domain.MenuDailySales{}
+	query := `SELECT order_menu.menu_id, MAX(order_menu.menu_name), DATE(orders.created_at), SUM(order_menu.quantity)
+		FROM order_menu JOIN orders ON orders.id = order_menu.order_id
+		WHERE orders.status != ? AND orders.deleted = false AND orders.deleted_at IS NULL AND orders.created_at >= ? AND orders.created_at < ?
+		GROUP BY order_menu.menu_id, DATE(orders.created_at)
+		ORDER BY DATE(orders.created_at)`
+	rows, err := r.db.QueryContext(ctx, query, domain.OrderStatusCancelled, from, to)
 	if err != nil {
-		return orderMenu, err
+		return nil, err
 	}
+	defer rows.Close()
 
-	return orderMenu, nil
+	for rows.Next() {
+		var sale domain.MenuDailySales
+		err := rows.Scan(&sale.MenuId, &sale.MenuName, &sale.Date, &sale.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		sales = append(sales, sale)
+	}
+
+	return sales, nil
+}
+
+func (r *OrderMenuRepositoryImpl) UpdateQuantity(ctx context.Context, id uuid.UUID, quantity int) error {
+	query := `UPDATE order_menu SET quantity = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, quantity, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
 }
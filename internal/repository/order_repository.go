@@ -10,6 +10,12 @@ import (
 type OrderRepository interface {
 	Create(ctx context.Context, order domain.Order) error
 	GetOneById(ctx context.Context, id uuid.UUID) (domain.Order, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Order, error)
+	GetAll(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error)
+	Count(ctx context.Context, filter domain.OrderFilter) (int, error)
 	UpdateOrderStatus(ctx context.Context, id uuid.UUID, order domain.Order) error
 	UpdatePayment(ctx context.Context, id uuid.UUID, order domain.Order) error
+	UpdateTotals(ctx context.Context, id uuid.UUID, order domain.Order) error
+	UpdateRefundedAmount(ctx context.Context, id uuid.UUID, amount domain.Money) error
+	UpdateInventoryDeducted(ctx context.Context, id uuid.UUID, deducted bool) error
 }
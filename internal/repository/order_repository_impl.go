@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -17,8 +18,8 @@ func NewOrderRepository(db DB) OrderRepository {
 	return &OrderRepositoryImpl{db}
 }
 func (r *OrderRepositoryImpl) Create(ctx context.Context, order domain.Order) error {
-	query := `INSERT INTO orders (id, user_id, amount) VALUES (?, ?, ?)`
-	res, err := r.db.ExecContext(ctx, query, order.Id, order.UserId, order.Amount)
+	query := `INSERT INTO orders (id, user_id, order_type, subtotal, tax_total, service_charge_percent, service_charge, rounding, amount, currency) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, order.Id, order.UserId, order.OrderType, order.Subtotal, order.TaxTotal, order.ServiceChargePercent, order.ServiceCharge, order.Rounding, order.Amount, order.Currency)
 	if err != nil {
 		return err
 	}
@@ -29,11 +30,22 @@ func (r *OrderRepositoryImpl) Create(ctx context.Context, order domain.Order) er
 	return nil
 }
 
+const orderByIdQuery = `SELECT id, order_type, subtotal, tax_total, service_charge_percent, service_charge, rounding, amount, currency, paid_amount, refunded_amount, payment_method, inventory_deducted, payment_status, status, user_id, created_at, updated_at FROM orders WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+
 func (r *OrderRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Order, error) {
+	return r.getOneById(ctx, orderByIdQuery, id)
+}
+
+// GetOneByIdForUpdate locks the order until the transaction ends, so
+// payments, refunds and voids on the same order run one after another
+func (r *OrderRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Order, error) {
+	return r.getOneById(ctx, orderByIdQuery+` FOR UPDATE`, id)
+}
+
+func (r *OrderRepositoryImpl) getOneById(ctx context.Context, query string, id uuid.UUID) (domain.Order, error) {
 	order := domain.Order{}
 	var paymentMethod sql.NullString
-	query := `SELECT id, amount, payment_method, payment_status, status, user_id, created_at, updated_at FROM orders WHERE id = ? AND deleted = false AND deleted_at IS NULL`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&order.Id, &order.Amount, &paymentMethod, &order.PaymentStatus, &order.Status, &order.UserId, &order.CreatedAt, &order.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&order.Id, &order.OrderType, &order.Subtotal, &order.TaxTotal, &order.ServiceChargePercent, &order.ServiceCharge, &order.Rounding, &order.Amount, &order.Currency, &order.PaidAmount, &order.RefundedAmount, &paymentMethod, &order.InventoryDeducted, &order.PaymentStatus, &order.Status, &order.UserId, &order.CreatedAt, &order.UpdatedAt)
 
 	if err != nil {
 		return domain.Order{}, err
@@ -44,10 +56,96 @@ func (r *OrderRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (dom
 	} else {
 		order.PaymentMethod = nil
 	}
+	order.Balance = order.Amount - order.PaidAmount
 
 	return order, nil
 }
 
+var orderSortColumns = map[string]string{
+	"created_at":     "created_at",
+	"amount":         "amount",
+	"status":         "status",
+	"payment_status": "payment_status",
+}
+
+func buildOrderFilter(filter domain.OrderFilter) (string, []interface{}) {
+	conditions := []string{"deleted = false", "deleted_at IS NULL"}
+	args := []interface{}{}
+
+	if filter.UserId != nil {
+		conditions = append(conditions, "user_id = ?")
+		args = append(args, *filter.UserId)
+	}
+	if filter.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.PaymentStatus != "" {
+		conditions = append(conditions, "payment_status = ?")
+		args = append(args, filter.PaymentStatus)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, *filter.CreatedTo)
+	}
+
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *OrderRepositoryImpl) GetAll(ctx context.Context, filter domain.OrderFilter) ([]domain.Order, error) {
+	orders := []domain.Order{}
+	where, args := buildOrderFilter(filter)
+
+	sortColumn, ok := orderSortColumns[filter.SortBy]
+	if !ok {
+		sortColumn = "created_at"
+	}
+	sortOrder := "DESC"
+	if filter.SortOrder == "asc" {
+		sortOrder = "ASC"
+	}
+
+	query := `SELECT id, order_type, subtotal, tax_total, service_charge_percent, service_charge, rounding, amount, currency, paid_amount, refunded_amount, payment_method, inventory_deducted, payment_status, status, user_id, created_at, updated_at FROM orders` +
+		where + fmt.Sprintf(" ORDER BY %s %s, id %s LIMIT ? OFFSET ?", sortColumn, sortOrder, sortOrder)
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var order domain.Order
+		var paymentMethod sql.NullString
+		err := rows.Scan(&order.Id, &order.OrderType, &order.Subtotal, &order.TaxTotal, &order.ServiceChargePercent, &order.ServiceCharge, &order.Rounding, &order.Amount, &order.Currency, &order.PaidAmount, &order.RefundedAmount, &paymentMethod, &order.InventoryDeducted, &order.PaymentStatus, &order.Status, &order.UserId, &order.CreatedAt, &order.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if paymentMethod.Valid {
+			order.PaymentMethod = &paymentMethod.String
+		}
+		order.Balance = order.Amount - order.PaidAmount
+		orders = append(orders, order)
+	}
+	return orders, nil
+}
+
+func (r *OrderRepositoryImpl) Count(ctx context.Context, filter domain.OrderFilter) (int, error) {
+	var count int
+	where, args := buildOrderFilter(filter)
+	query := `SELECT COUNT(*) FROM orders` + where
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
 func (r *OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, id uuid.UUID, order domain.Order) error {
 	query := `UPDATE orders SET status = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
 	res, err := r.db.ExecContext(ctx, query, order.Status, id)
@@ -62,8 +160,42 @@ func (r *OrderRepositoryImpl) UpdateOrderStatus(ctx context.Context, id uuid.UUI
 }
 
 func (r *OrderRepositoryImpl) UpdatePayment(ctx context.Context, id uuid.UUID, order domain.Order) error {
-	query := `UPDATE orders SET payment_status = ?, payment_method = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
-	res, err := r.db.ExecContext(ctx, query, order.PaymentStatus, order.PaymentMethod, id)
+	query := `UPDATE orders SET payment_status = ?, payment_method = ?, paid_amount = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, order.PaymentStatus, order.PaymentMethod, order.PaidAmount, id)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// UpdateTotals stores the breakdown of an order, amount is its total
+func (r *OrderRepositoryImpl) UpdateTotals(ctx context.Context, id uuid.UUID, order domain.Order) error {
+	query := `UPDATE orders SET subtotal = ?, tax_total = ?, service_charge = ?, rounding = ?, amount = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, order.Subtotal, order.TaxTotal, order.ServiceCharge, order.Rounding, order.Amount, id)
+	return err
+}
+
+func (r *OrderRepositoryImpl) UpdateRefundedAmount(ctx context.Context, id uuid.UUID, amount domain.Money) error {
+	query := `UPDATE orders SET refunded_amount = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, amount, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *OrderRepositoryImpl) UpdateInventoryDeducted(ctx context.Context, id uuid.UUID, deducted bool) error {
+	query := `UPDATE orders SET inventory_deducted = ? WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	res, err := r.db.ExecContext(ctx, query, deducted, id)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderStatusHistoryRepository interface {
+	Create(ctx context.Context, history domain.OrderStatusHistory) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderStatusHistory, error)
+}
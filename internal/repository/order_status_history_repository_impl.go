@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderStatusHistoryRepositoryImpl struct {
+	db DB
+}
+
+func NewOrderStatusHistoryRepository(db DB) OrderStatusHistoryRepository {
+	return &OrderStatusHistoryRepositoryImpl{db}
+}
+
+func (r *OrderStatusHistoryRepositoryImpl) Create(ctx context.Context, history domain.OrderStatusHistory) error {
+	query := `INSERT INTO order_status_history (id, order_id, from_status, to_status, changed_by, note) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, history.Id, history.OrderId, history.FromStatus, history.ToStatus, history.ChangedBy, history.Note)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *OrderStatusHistoryRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderStatusHistory, error) {
+	histories := []domain.OrderStatusHistory{}
+	query := `SELECT id, order_id, from_status, to_status, changed_by, note, created_at FROM order_status_history WHERE order_id = ? ORDER BY created_at, id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var history domain.OrderStatusHistory
+		var fromStatus, note sql.NullString
+		err := rows.Scan(&history.Id, &history.OrderId, &fromStatus, &history.ToStatus, &history.ChangedBy, &note, &history.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if fromStatus.Valid {
+			history.FromStatus = &fromStatus.String
+		}
+		if note.Valid {
+			history.Note = &note.String
+		}
+		histories = append(histories, history)
+	}
+	return histories, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderTaxLineRepository interface {
+	Create(ctx context.Context, line domain.OrderTaxLine) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderTaxLine, error)
+	DeleteByOrderId(ctx context.Context, orderId uuid.UUID) error
+}
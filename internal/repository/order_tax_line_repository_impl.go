@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderTaxLineRepositoryImpl struct {
+	db DB
+}
+
+func NewOrderTaxLineRepository(db DB) OrderTaxLineRepository {
+	return &OrderTaxLineRepositoryImpl{db}
+}
+
+func (r *OrderTaxLineRepositoryImpl) Create(ctx context.Context, line domain.OrderTaxLine) error {
+	query := `INSERT INTO order_tax_lines (id, order_id, tax_rate_id, name, rate_percent, inclusive, taxable_amount, amount) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, line.Id, line.OrderId, line.TaxRateId, line.Name, line.RatePercent, line.Inclusive, line.TaxableAmount, line.Amount)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *OrderTaxLineRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderTaxLine, error) {
+	lines := []domain.OrderTaxLine{}
+	query := `SELECT id, order_id, tax_rate_id, name, rate_percent, inclusive, taxable_amount, amount FROM order_tax_lines WHERE order_id = ? ORDER BY inclusive DESC, name, id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var line domain.OrderTaxLine
+		var taxRateId uuid.NullUUID
+		err := rows.Scan(&line.Id, &line.OrderId, &taxRateId, &line.Name, &line.RatePercent, &line.Inclusive, &line.TaxableAmount, &line.Amount)
+		if err != nil {
+			return nil, err
+		}
+		if taxRateId.Valid {
+			line.TaxRateId = &taxRateId.UUID
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// DeleteByOrderId clears the tax lines of an order before they are worked
+// out again
+func (r *OrderTaxLineRepositoryImpl) DeleteByOrderId(ctx context.Context, orderId uuid.UUID) error {
+	query := `DELETE FROM order_tax_lines WHERE order_id = ?`
+	_, err := r.db.ExecContext(ctx, query, orderId)
+	return err
+}
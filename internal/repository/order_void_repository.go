@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderVoidRepository interface {
+	Create(ctx context.Context, void domain.OrderVoid) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderVoid, error)
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type OrderVoidRepositoryImpl struct {
+	db DB
+}
+
+func NewOrderVoidRepository(db DB) OrderVoidRepository {
+	return &OrderVoidRepositoryImpl{db}
+}
+
+func (r *OrderVoidRepositoryImpl) Create(ctx context.Context, void domain.OrderVoid) error {
+	query := `INSERT INTO order_voids (id, order_id, order_menu_id, quantity, amount, reason_code, note, restocked, created_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, void.Id, void.OrderId, void.OrderMenuId, void.Quantity, void.Amount, void.ReasonCode, void.Note, void.Restocked, void.CreatedBy)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *OrderVoidRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.OrderVoid, error) {
+	voids := []domain.OrderVoid{}
+	query := `SELECT order_voids.id, order_voids.order_id, order_voids.order_menu_id, order_menu.menu_name, order_voids.quantity, order_voids.amount, order_voids.reason_code, order_voids.note, order_voids.restocked, order_voids.created_by, order_voids.created_at
+		FROM order_voids JOIN order_menu ON order_menu.id = order_voids.order_menu_id
+		WHERE order_voids.order_id = ?
+		ORDER BY order_voids.created_at, order_voids.id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var void domain.OrderVoid
+		var note sql.NullString
+		var createdBy uuid.NullUUID
+		err := rows.Scan(&void.Id, &void.OrderId, &void.OrderMenuId, &void.MenuName, &void.Quantity, &void.Amount, &void.ReasonCode, &note, &void.Restocked, &createdBy, &void.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if note.Valid {
+			void.Note = &note.String
+		}
+		if createdBy.Valid {
+			void.CreatedBy = &createdBy.UUID
+		}
+		voids = append(voids, void)
+	}
+	return voids, nil
+}
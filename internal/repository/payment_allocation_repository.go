@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PaymentAllocationRepository interface {
+	Create(ctx context.Context, allocation domain.PaymentAllocation) error
+	GetAllByPaymentId(ctx context.Context, paymentId uuid.UUID) ([]domain.PaymentAllocation, error)
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.PaymentAllocation, error)
+	SumCapturedQuantitiesByOrderId(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PaymentAllocationRepositoryImpl struct {
+	db DB
+}
+
+func NewPaymentAllocationRepository(db DB) PaymentAllocationRepository {
+	return &PaymentAllocationRepositoryImpl{db}
+}
+
+func (r *PaymentAllocationRepositoryImpl) Create(ctx context.Context, allocation domain.PaymentAllocation) error {
+	query := `INSERT INTO payment_allocations (id, payment_id, order_menu_id, quantity, amount) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, allocation.Id, allocation.PaymentId, allocation.OrderMenuId, allocation.Quantity, allocation.Amount)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *PaymentAllocationRepositoryImpl) GetAllByPaymentId(ctx context.Context, paymentId uuid.UUID) ([]domain.PaymentAllocation, error) {
+	query := `SELECT payment_allocations.id, payment_allocations.payment_id, payment_allocations.order_menu_id, order_menu.menu_name, payment_allocations.quantity, payment_allocations.amount
+		FROM payment_allocations JOIN order_menu ON order_menu.id = payment_allocations.order_menu_id
+		WHERE payment_allocations.payment_id = ?
+		ORDER BY order_menu.created_at, order_menu.menu_name`
+	return r.queryAllocations(ctx, query, paymentId)
+}
+
+func (r *PaymentAllocationRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.PaymentAllocation, error) {
+	query := `SELECT payment_allocations.id, payment_allocations.payment_id, payment_allocations.order_menu_id, order_menu.menu_name, payment_allocations.quantity, payment_allocations.amount
+		FROM payment_allocations JOIN order_menu ON order_menu.id = payment_allocations.order_menu_id
+		WHERE order_menu.order_id = ?
+		ORDER BY order_menu.created_at, order_menu.menu_name`
+	return r.queryAllocations(ctx, query, orderId)
+}
+
+func (r *PaymentAllocationRepositoryImpl) queryAllocations(ctx context.Context, query string, args ...interface{}) ([]domain.PaymentAllocation, error) {
+	allocations := []domain.PaymentAllocation{}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var allocation domain.PaymentAllocation
+		err := rows.Scan(&allocation.Id, &allocation.PaymentId, &allocation.OrderMenuId, &allocation.MenuName, &allocation.Quantity, &allocation.Amount)
+		if err != nil {
+			return nil, err
+		}
+		allocations = append(allocations, allocation)
+	}
+	return allocations, nil
+}
+
+// SumCapturedQuantitiesByOrderId returns per order line how many units are
+// already paid by captured payments or held by payments being captured
+func (r *PaymentAllocationRepositoryImpl) SumCapturedQuantitiesByOrderId(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error) {
+	quantities := map[uuid.UUID]int{}
+	query := `SELECT payment_allocations.order_menu_id, SUM(payment_allocations.quantity)
+		FROM payment_allocations JOIN payments ON payments.id = payment_allocations.payment_id
+		WHERE payments.order_id = ? AND payments.status IN (?, ?)
+		GROUP BY payment_allocations.order_menu_id`
+	rows, err := r.db.QueryContext(ctx, query, orderId, domain.PaymentStatusCaptured, domain.PaymentStatusCapturing)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderMenuId uuid.UUID
+		var quantity int
+		if err := rows.Scan(&orderMenuId, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[orderMenuId] = quantity
+	}
+	return quantities, nil
+}
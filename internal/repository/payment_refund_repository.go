@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PaymentRefundRepository interface {
+	Create(ctx context.Context, paymentRefund domain.PaymentRefund) error
+	Complete(ctx context.Context, id uuid.UUID, providerReference string) error
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type PaymentRefundRepositoryImpl struct {
+	db DB
+}
+
+func NewPaymentRefundRepository(db DB) PaymentRefundRepository {
+	return &PaymentRefundRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *PaymentRefundRepositoryImpl) Create(ctx context.Context, paymentRefund domain.PaymentRefund) error {
+	query := `INSERT INTO payment_refunds (id, refund_id, payment_id, amount, status) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, paymentRefund.Id, paymentRefund.RefundId, paymentRefund.PaymentId, paymentRefund.Amount, paymentRefund.Status)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// Complete marks a pending refund as made by the provider
+func (r *PaymentRefundRepositoryImpl) Complete(ctx context.Context, id uuid.UUID, providerReference string) error {
+	query := `UPDATE payment_refunds SET status = ?, provider_reference = ? WHERE id = ? AND status = ?`
+	res, err := r.db.ExecContext(ctx, query, domain.PaymentRefundStatusCompleted, providerReference, id, domain.PaymentRefundStatusPending)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PaymentRepository interface {
+	Create(ctx context.Context, payment domain.Payment) error
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.Payment, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Payment, error)
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.Payment, error)
+	GetOneByProviderReference(ctx context.Context, provider, reference string) (domain.Payment, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, payment domain.Payment) error
+}
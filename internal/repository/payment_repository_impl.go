@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PaymentRepositoryImpl struct {
+	db DB
+}
+
+func NewPaymentRepository(db DB) PaymentRepository {
+	return &PaymentRepositoryImpl{db}
+}
+
+const paymentColumns = `id, order_id, provider, provider_reference, method, amount, rounding, split_parts, refunded_amount, currency, status, failure_reason, created_by, captured_at, created_at, updated_at`
+
+type paymentScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPayment(row paymentScanner) (domain.Payment, error) {
+	var payment domain.Payment
+	var failureReason sql.NullString
+	var capturedAt sql.NullTime
+	var splitParts sql.NullInt64
+	err := row.Scan(&payment.Id, &payment.OrderId, &payment.Provider, &payment.ProviderReference, &payment.Method, &payment.Amount, &payment.Rounding, &splitParts, &payment.RefundedAmount, &payment.Currency, &payment.Status, &failureReason, &payment.CreatedBy, &capturedAt, &payment.CreatedAt, &payment.UpdatedAt)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	if failureReason.Valid {
+		payment.FailureReason = &failureReason.String
+	}
+	if capturedAt.Valid {
+		payment.CapturedAt = &capturedAt.Time
+	}
+	if splitParts.Valid {
+		parts := int(splitParts.Int64)
+		payment.SplitParts = &parts
+	}
+	return payment, nil
+}
+
+func (r *PaymentRepositoryImpl) Create(ctx context.Context, payment domain.Payment) error {
+	query := `INSERT INTO payments (id, order_id, provider, provider_reference, method, amount, rounding, split_parts, refunded_amount, currency, status, failure_reason, created_by, captured_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, payment.Id, payment.OrderId, payment.Provider, payment.ProviderReference, payment.Method, payment.Amount, payment.Rounding, payment.SplitParts, payment.RefundedAmount, payment.Currency, payment.Status, payment.FailureReason, payment.CreatedBy, payment.CapturedAt)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *PaymentRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = ?`
+	return scanPayment(r.db.QueryRowContext(ctx, query, id))
+}
+
+// GetOneByIdForUpdate is GetOneById locking the payment until the
+// transaction ends
+func (r *PaymentRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE id = ? FOR UPDATE`
+	return scanPayment(r.db.QueryRowContext(ctx, query, id))
+}
+
+func (r *PaymentRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.Payment, error) {
+	payments := []domain.Payment{}
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE order_id = ? ORDER BY created_at, id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		payment, err := scanPayment(rows)
+		if err != nil {
+			return nil, err
+		}
+		payments = append(payments, payment)
+	}
+	return payments, nil
+}
+
+func (r *PaymentRepositoryImpl) GetOneByProviderReference(ctx context.Context, provider, reference string) (domain.Payment, error) {
+	query := `SELECT ` + paymentColumns + ` FROM payments WHERE provider = ? AND provider_reference = ?`
+	return scanPayment(r.db.QueryRowContext(ctx, query, provider, reference))
+}
+
+func (r *PaymentRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, payment domain.Payment) error {
+	query := `UPDATE payments SET status = ?, failure_reason = ?, captured_at = ?, refunded_amount = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, payment.Status, payment.FailureReason, payment.CapturedAt, payment.RefundedAmount, id)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PurchaseOrderItemRepository interface {
+	Create(ctx context.Context, item domain.PurchaseOrderItem) error
+	GetAllByPurchaseOrderId(ctx context.Context, purchaseOrderId uuid.UUID) ([]domain.PurchaseOrderItem, error)
+	AddReceivedQuantity(ctx context.Context, id uuid.UUID, quantity float64) error
+	SumOpenQuantityByIngredientId(ctx context.Context, ingredientId uuid.UUID) (float64, error)
+}
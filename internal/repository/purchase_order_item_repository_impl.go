@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type PurchaseOrderItemRepositoryImpl struct {
+	db DB
+}
+
+func NewPurchaseOrderItemRepository(db DB) PurchaseOrderItemRepository {
+	return &PurchaseOrderItemRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *PurchaseOrderItemRepositoryImpl) Create(ctx context.Context, item domain.PurchaseOrderItem) error {
+	query := `INSERT INTO purchase_order_items (id, purchase_order_id, ingredient_id, quantity, unit_cost) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, item.Id, item.PurchaseOrderId, item.IngredientId, item.Quantity, item.UnitCost)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *PurchaseOrderItemRepositoryImpl) GetAllByPurchaseOrderId(ctx context.Context, purchaseOrderId uuid.UUID) ([]domain.PurchaseOrderItem, error) {
+	items := []domain.PurchaseOrderItem{}
+	query := `
+		SELECT purchase_order_items.id, purchase_order_items.purchase_order_id, purchase_order_items.ingredient_id, ingredients.name, ingredients.base_unit,
+			purchase_order_items.quantity, purchase_order_items.received_quantity, purchase_order_items.unit_cost
+		FROM purchase_order_items
+		INNER JOIN ingredients ON purchase_order_items.ingredient_id = ingredients.id
+		WHERE purchase_order_items.purchase_order_id = ?
+		ORDER BY ingredients.name
+	`
+	rows, err := r.db.QueryContext(ctx, query, purchaseOrderId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.PurchaseOrderItem
+		err := rows.Scan(&item.Id, &item.PurchaseOrderId, &item.IngredientId, &item.Name, &item.Unit, &item.Quantity, &item.ReceivedQuantity, &item.UnitCost)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func (r *PurchaseOrderItemRepositoryImpl) AddReceivedQuantity(ctx context.Context, id uuid.UUID, quantity float64) error {
+	query := `UPDATE purchase_order_items SET received_quantity = received_quantity + ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, quantity, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// SumOpenQuantityByIngredientId returns how much of an ingredient is ordered
+// on purchase orders that are not yet fully received or cancelled
+func (r *PurchaseOrderItemRepositoryImpl) SumOpenQuantityByIngredientId(ctx context.Context, ingredientId uuid.UUID) (float64, error) {
+	var quantity float64
+	query := `
+		SELECT COALESCE(SUM(purchase_order_items.quantity - purchase_order_items.received_quantity), 0)
+		FROM purchase_order_items
+		INNER JOIN purchase_orders ON purchase_order_items.purchase_order_id = purchase_orders.id
+		WHERE purchase_order_items.ingredient_id = ? AND purchase_orders.status IN ('draft', 'sent', 'partially_received')
+	`
+	err := r.db.QueryRowContext(ctx, query, ingredientId).Scan(&quantity)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return quantity, nil
+}
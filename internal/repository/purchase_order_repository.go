@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type PurchaseOrderRepository interface {
+	Create(ctx context.Context, purchaseOrder domain.PurchaseOrder) error
+	GetAll(ctx context.Context, filter domain.PurchaseOrderFilter) ([]domain.PurchaseOrder, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string) error
+}
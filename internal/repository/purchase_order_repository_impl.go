@@ -0,0 +1,144 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type PurchaseOrderRepositoryImpl struct {
+	db DB
+}
+
+func NewPurchaseOrderRepository(db DB) PurchaseOrderRepository {
+	return &PurchaseOrderRepositoryImpl{
+		db: db,
+	}
+}
+
+const purchaseOrderSelect = `
+	SELECT purchase_orders.id, purchase_orders.supplier_id, suppliers.name, purchase_orders.status, purchase_orders.notes,
+		purchase_orders.expected_at, purchase_orders.created_by, purchase_orders.sent_at, purchase_orders.received_at,
+		COALESCE((SELECT SUM(quantity * unit_cost) FROM purchase_order_items WHERE purchase_order_id = purchase_orders.id), 0),
+		purchase_orders.created_at, purchase_orders.updated_at
+	FROM purchase_orders
+	INNER JOIN suppliers ON purchase_orders.supplier_id = suppliers.id
+`
+
+type purchaseOrderScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanPurchaseOrder(row purchaseOrderScanner) (domain.PurchaseOrder, error) {
+	var purchaseOrder domain.PurchaseOrder
+	var createdBy uuid.NullUUID
+	err := row.Scan(&purchaseOrder.Id, &purchaseOrder.SupplierId, &purchaseOrder.SupplierName, &purchaseOrder.Status, &purchaseOrder.Notes,
+		&purchaseOrder.ExpectedAt, &createdBy, &purchaseOrder.SentAt, &purchaseOrder.ReceivedAt, &purchaseOrder.Total,
+		&purchaseOrder.CreatedAt, &purchaseOrder.UpdatedAt)
+	if err != nil {
+		return domain.PurchaseOrder{}, err
+	}
+	if createdBy.Valid {
+		purchaseOrder.CreatedBy = &createdBy.UUID
+	}
+	return purchaseOrder, nil
+}
+
+func (r *PurchaseOrderRepositoryImpl) Create(ctx context.Context, purchaseOrder domain.PurchaseOrder) error {
+	query := `INSERT INTO purchase_orders (id, supplier_id, status, notes, expected_at, created_by) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, purchaseOrder.Id, purchaseOrder.SupplierId, purchaseOrder.Status, purchaseOrder.Notes, purchaseOrder.ExpectedAt, purchaseOrder.CreatedBy)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *PurchaseOrderRepositoryImpl) GetAll(ctx context.Context, filter domain.PurchaseOrderFilter) ([]domain.PurchaseOrder, error) {
+	purchaseOrders := []domain.PurchaseOrder{}
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.Status != "" {
+		conditions = append(conditions, "purchase_orders.status = ?")
+		args = append(args, filter.Status)
+	}
+	if filter.SupplierId != nil {
+		conditions = append(conditions, "purchase_orders.supplier_id = ?")
+		args = append(args, *filter.SupplierId)
+	}
+
+	query := purchaseOrderSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY purchase_orders.created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		purchaseOrder, err := scanPurchaseOrder(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		purchaseOrders = append(purchaseOrders, purchaseOrder)
+	}
+	return purchaseOrders, nil
+}
+
+func (r *PurchaseOrderRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error) {
+	query := purchaseOrderSelect + ` WHERE purchase_orders.id = ?`
+	purchaseOrder, err := scanPurchaseOrder(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.PurchaseOrder{}, err
+	}
+	return purchaseOrder, nil
+}
+
+// GetOneByIdForUpdate is GetOneById locking the purchase order until the
+// transaction ends
+func (r *PurchaseOrderRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error) {
+	query := purchaseOrderSelect + ` WHERE purchase_orders.id = ? FOR UPDATE`
+	purchaseOrder, err := scanPurchaseOrder(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.PurchaseOrder{}, err
+	}
+	return purchaseOrder, nil
+}
+
+// UpdateStatus also stamps sent_at and received_at when the order reaches those states
+func (r *PurchaseOrderRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status string) error {
+	query := `
+		UPDATE purchase_orders SET status = ?,
+			sent_at = IF(? = 'sent', CURRENT_TIMESTAMP, sent_at),
+			received_at = IF(? = 'received', CURRENT_TIMESTAMP, received_at)
+		WHERE id = ?
+	`
+	res, err := r.db.ExecContext(ctx, query, status, status, status, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RecipeComponentRepository interface {
+	Create(ctx context.Context, recipeComponent domain.RecipeComponent) error
+	Update(ctx context.Context, id uuid.UUID, recipeComponent domain.RecipeComponent) error
+	DeleteByRecipeVersionIdAndComponentRecipeId(ctx context.Context, recipeVersionId, componentRecipeId uuid.UUID) error
+	GetComponentsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.SimpleRecipeComponent, error)
+	GetOneByRecipeVersionIdAndComponentRecipeId(ctx context.Context, recipeVersionId, componentRecipeId uuid.UUID) (domain.RecipeComponent, error)
+	GetComponentRecipeIdsByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]uuid.UUID, error)
+	GetParentNamesByComponentRecipeId(ctx context.Context, componentRecipeId uuid.UUID) ([]string, error)
+}
@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type RecipeComponentRepositoryImpl struct {
+	db DB
+}
+
+func NewRecipeComponentRepository(db DB) RecipeComponentRepository {
+	return &RecipeComponentRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RecipeComponentRepositoryImpl) Create(ctx context.Context, recipeComponent domain.RecipeComponent) error {
+	query := `INSERT INTO recipe_components (id, recipe_id, recipe_version_id, component_recipe_id, quantity, unit) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query,
+		recipeComponent.Id, recipeComponent.RecipeId, recipeComponent.RecipeVersionId, recipeComponent.ComponentRecipeId, recipeComponent.Quantity, recipeComponent.Unit)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+
+	return nil
+}
+
+func (r *RecipeComponentRepositoryImpl) Update(ctx context.Context, id uuid.UUID, recipeComponent domain.RecipeComponent) error {
+	query := `UPDATE recipe_components SET quantity = ?, unit = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, recipeComponent.Quantity, recipeComponent.Unit, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RecipeComponentRepositoryImpl) DeleteByRecipeVersionIdAndComponentRecipeId(ctx context.Context, recipeVersionId, componentRecipeId uuid.UUID) error {
+	query := `DELETE FROM recipe_components WHERE recipe_version_id = ? AND component_recipe_id = ?`
+	res, err := r.db.ExecContext(ctx, query, recipeVersionId, componentRecipeId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+// GetComponentsByRecipeVersionId keeps components whose recipe has since
+// been deleted so older versions still resolve, the yield of each component
+// depends on its own version and is left to the caller
+func (r *RecipeComponentRepositoryImpl) GetComponentsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.SimpleRecipeComponent, error) {
+	query := `SELECT recipe_components.component_recipe_id, recipes.name, recipe_components.quantity, recipe_components.unit
+		FROM recipe_components INNER JOIN recipes ON recipe_components.component_recipe_id = recipes.id
+		WHERE recipe_components.recipe_version_id = ? AND recipe_components.deleted = false
+		ORDER BY recipes.name`
+	rows, err := r.db.QueryContext(ctx, query, recipeVersionId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	recipeComponents := []domain.SimpleRecipeComponent{}
+	for rows.Next() {
+		var recipeComponent domain.SimpleRecipeComponent
+		err = rows.Scan(&recipeComponent.RecipeId, &recipeComponent.Name, &recipeComponent.Quantity, &recipeComponent.Unit)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		recipeComponents = append(recipeComponents, recipeComponent)
+	}
+
+	return recipeComponents, nil
+}
+
+func (r *RecipeComponentRepositoryImpl) GetOneByRecipeVersionIdAndComponentRecipeId(ctx context.Context, recipeVersionId, componentRecipeId uuid.UUID) (domain.RecipeComponent, error) {
+	recipeComponent := domain.RecipeComponent{}
+	query := `SELECT id, recipe_id, recipe_version_id, component_recipe_id, quantity, unit FROM recipe_components WHERE recipe_version_id = ? AND component_recipe_id = ? AND deleted = false`
+	err := r.db.QueryRowContext(ctx, query, recipeVersionId, componentRecipeId).Scan(&recipeComponent.Id, &recipeComponent.RecipeId, &recipeComponent.RecipeVersionId, &recipeComponent.ComponentRecipeId, &recipeComponent.Quantity, &recipeComponent.Unit)
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.RecipeComponent{}, err
+	}
+	return recipeComponent, nil
+}
+
+// GetComponentRecipeIdsByRecipeId lists the recipes used by any version of a
+// recipe, drafts included
+func (r *RecipeComponentRepositoryImpl) GetComponentRecipeIdsByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]uuid.UUID, error) {
+	query := `SELECT DISTINCT component_recipe_id FROM recipe_components WHERE recipe_id = ? AND deleted = false`
+	rows, err := r.db.QueryContext(ctx, query, recipeId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetParentNamesByComponentRecipeId lists the recipes that use a recipe as a
+// component in their draft, their version in use or a version scheduled for
+// later
+func (r *RecipeComponentRepositoryImpl) GetParentNamesByComponentRecipeId(ctx context.Context, componentRecipeId uuid.UUID) ([]string, error) {
+	query := `SELECT DISTINCT recipes.name FROM recipe_components
+		INNER JOIN recipe_versions ON recipe_components.recipe_version_id = recipe_versions.id
+		INNER JOIN recipes ON recipe_versions.recipe_id = recipes.id
+		WHERE recipe_components.component_recipe_id = ? AND recipe_components.deleted = false AND recipes.deleted = false AND recipes.deleted_at IS NULL
+		AND (recipe_versions.status = ? OR recipe_versions.effective_from > CURRENT_TIMESTAMP OR recipe_versions.id = (
+			SELECT active.id FROM recipe_versions active
+			WHERE active.recipe_id = recipes.id AND active.status = ? AND active.effective_from <= CURRENT_TIMESTAMP
+			ORDER BY active.effective_from DESC, active.version DESC LIMIT 1
+		))
+		ORDER BY recipes.name`
+	rows, err := r.db.QueryContext(ctx, query, componentRecipeId, domain.RecipeVersionStatusDraft, domain.RecipeVersionStatusPublished)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
@@ -10,7 +10,9 @@ import (
 type RecipeIngredientRepository interface {
 	Create(ctx context.Context, recipeIngredient domain.RecipeIngredient) error
 	Update(ctx context.Context, id uuid.UUID, recipeIngredient domain.RecipeIngredient) error
+	DeleteByRecipeVersionIdAndIngredientId(ctx context.Context, recipeVersionId, ingredientId uuid.UUID) error
 
-	GetIngredientsByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]domain.SimpleRecipeIngredient, error)
-	GetIngredientsByRecipeIdAndIngredientId(ctx context.Context, recipeId uuid.UUID, ingredientId uuid.UUID) (domain.RecipeIngredient, error)
+	GetIngredientsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.SimpleRecipeIngredient, error)
+	GetOneByRecipeVersionIdAndIngredientId(ctx context.Context, recipeVersionId uuid.UUID, ingredientId uuid.UUID) (domain.RecipeIngredient, error)
+	CountByIngredientIdAndUnit(ctx context.Context, ingredientId uuid.UUID, unit string) (int, error)
 }
@@ -20,9 +20,9 @@ func NewRecipeIngredientRepository(db DB) RecipeIngredientRepository {
 }
 
 func (r *RecipeIngredientRepositoryImpl) Create(ctx context.Context, recipeIngredient domain.RecipeIngredient) error {
-	query := `INSERT INTO recipes_ingredients (id, recipe_id, ingredient_id, quantity) VALUES (?, ?, ?, ?)`
+	query := `INSERT INTO recipes_ingredients (id, recipe_id, recipe_version_id, ingredient_id, quantity, unit) VALUES (?, ?, ?, ?, ?, ?)`
 	res, err := r.db.ExecContext(ctx, query,
-		recipeIngredient.Id, recipeIngredient.RecipeId, recipeIngredient.IngredientId, recipeIngredient.Quantity)
+		recipeIngredient.Id, recipeIngredient.RecipeId, recipeIngredient.RecipeVersionId, recipeIngredient.IngredientId, recipeIngredient.Quantity, recipeIngredient.Unit)
 	if err != nil {
 		logger.Log.Error(err)
 		return err
@@ -37,8 +37,8 @@ func (r *RecipeIngredientRepositoryImpl) Create(ctx context.Context, recipeIngre
 }
 
 func (r *RecipeIngredientRepositoryImpl) Update(ctx context.Context, id uuid.UUID, recipeIngredient domain.RecipeIngredient) error {
-	query := `UPDATE recipes_ingredients SET  quantity = ? WHERE id = ?`
-	res, err := r.db.ExecContext(ctx, query, recipeIngredient.Quantity, id)
+	query := `UPDATE recipes_ingredients SET  quantity = ?, unit = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, recipeIngredient.Quantity, recipeIngredient.Unit, id)
 	if err != nil {
 		logger.Log.Error(err)
 		return err
@@ -50,9 +50,23 @@ func (r *RecipeIngredientRepositoryImpl) Update(ctx context.Context, id uuid.UUI
 	return nil
 }
 
-func (r *RecipeIngredientRepositoryImpl) GetIngredientsByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]domain.SimpleRecipeIngredient, error) {
-	query := `SELECT ingredient_id, ingredients.name, quantity FROM recipes_ingredients INNER JOIN ingredients ON recipes_ingredients.ingredient_id = ingredients.id WHERE recipe_id = ?`
-	rows, err := r.db.QueryContext(ctx, query, recipeId)
+func (r *RecipeIngredientRepositoryImpl) DeleteByRecipeVersionIdAndIngredientId(ctx context.Context, recipeVersionId, ingredientId uuid.UUID) error {
+	query := `DELETE FROM recipes_ingredients WHERE recipe_version_id = ? AND ingredient_id = ?`
+	res, err := r.db.ExecContext(ctx, query, recipeVersionId, ingredientId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RecipeIngredientRepositoryImpl) GetIngredientsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.SimpleRecipeIngredient, error) {
+	query := `SELECT ingredient_id, ingredients.name, quantity, unit, ingredients.base_unit, ingredients.cost_per_unit FROM recipes_ingredients INNER JOIN ingredients ON recipes_ingredients.ingredient_id = ingredients.id WHERE recipe_version_id = ?`
+	rows, err := r.db.QueryContext(ctx, query, recipeVersionId)
 	if err != nil {
 		logger.Log.Error(err)
 		return nil, err
@@ -62,7 +76,7 @@ func (r *RecipeIngredientRepositoryImpl) GetIngredientsByRecipeId(ctx context.Co
 	var recipeIngredients []domain.SimpleRecipeIngredient
 	for rows.Next() {
 		var recipeIngredient domain.SimpleRecipeIngredient
-		err = rows.Scan(&recipeIngredient.IngredientId, &recipeIngredient.Name, &recipeIngredient.Quantity)
+		err = rows.Scan(&recipeIngredient.IngredientId, &recipeIngredient.Name, &recipeIngredient.Quantity, &recipeIngredient.Unit, &recipeIngredient.BaseUnit, &recipeIngredient.CostPerUnit)
 		if err != nil {
 			logger.Log.Error(err)
 			return nil, err
@@ -73,13 +87,24 @@ func (r *RecipeIngredientRepositoryImpl) GetIngredientsByRecipeId(ctx context.Co
 	return recipeIngredients, nil
 }
 
-func (r *RecipeIngredientRepositoryImpl) GetIngredientsByRecipeIdAndIngredientId(ctx context.Context, recipeId, ingredientId uuid.UUID) (domain.RecipeIngredient, error) {
+func (r *RecipeIngredientRepositoryImpl) GetOneByRecipeVersionIdAndIngredientId(ctx context.Context, recipeVersionId, ingredientId uuid.UUID) (domain.RecipeIngredient, error) {
 	recipeWithIngredients := domain.RecipeIngredient{}
-	query := `SELECT id, recipe_id, ingredient_id, quantity FROM recipes_ingredients WHERE recipe_id = ? AND ingredient_id = ?`
-	err := r.db.QueryRowContext(ctx, query, recipeId, ingredientId).Scan(&recipeWithIngredients.Id, &recipeWithIngredients.RecipeId, &recipeWithIngredients.IngredientId, &recipeWithIngredients.Quantity)
+	query := `SELECT id, recipe_id, recipe_version_id, ingredient_id, quantity, unit FROM recipes_ingredients WHERE recipe_version_id = ? AND ingredient_id = ?`
+	err := r.db.QueryRowContext(ctx, query, recipeVersionId, ingredientId).Scan(&recipeWithIngredients.Id, &recipeWithIngredients.RecipeId, &recipeWithIngredients.RecipeVersionId, &recipeWithIngredients.IngredientId, &recipeWithIngredients.Quantity, &recipeWithIngredients.Unit)
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.RecipeIngredient{}, err
 	}
 	return recipeWithIngredients, nil
 }
+
+func (r *RecipeIngredientRepositoryImpl) CountByIngredientIdAndUnit(ctx context.Context, ingredientId uuid.UUID, unit string) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM recipes_ingredients WHERE ingredient_id = ? AND unit = ? AND deleted = false`
+	err := r.db.QueryRowContext(ctx, query, ingredientId, unit).Scan(&count)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
@@ -18,6 +18,16 @@ func NewRecipeRepository(db DB) RecipeRepository {
 	return &RecipeRepositoryImpl{db: db}
 }
 
+// recipeQuery joins the version in use now, a recipe without one has version
+// 0 and yields one portion
+const recipeQuery = `SELECT recipes.id, recipes.menu_id, recipes.name, recipes.description, recipe_versions.id, COALESCE(recipe_versions.version, 0),
+	COALESCE(recipe_versions.yield_quantity, 1), COALESCE(recipe_versions.yield_unit, 'portion'), recipes.created_at, recipes.updated_at
+	FROM recipes LEFT JOIN recipe_versions ON recipe_versions.id = (
+		SELECT active.id FROM recipe_versions active
+		WHERE active.recipe_id = recipes.id AND active.status = 'published' AND active.effective_from <= CURRENT_TIMESTAMP
+		ORDER BY active.effective_from DESC, active.version DESC LIMIT 1
+	)`
+
 func (r *RecipeRepositoryImpl) Create(ctx context.Context, recipe domain.Recipe) error {
 	query := `INSERT INTO recipes (id, menu_id, name, description) VALUES (?, ?, ?, ?)`
 	res, err := r.db.ExecContext(ctx, query, recipe.Id, recipe.MenuId, recipe.Name, recipe.Description)
@@ -33,9 +43,8 @@ func (r *RecipeRepositoryImpl) Create(ctx context.Context, recipe domain.Recipe)
 }
 
 func (r *RecipeRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Recipe, error) {
-	recipe := domain.Recipe{}
-	query := `SELECT id, menu_id, name, description, created_at, updated_at FROM recipes WHERE id = ? AND deleted = false AND deleted_at IS NULL`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&recipe.Id, &recipe.MenuId, &recipe.Name, &recipe.Description, &recipe.CreatedAt, &recipe.UpdatedAt)
+	query := recipeQuery + ` WHERE recipes.id = ? AND recipes.deleted = false AND recipes.deleted_at IS NULL`
+	recipe, err := scanRecipe(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Recipe{}, err
@@ -45,7 +54,7 @@ func (r *RecipeRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (do
 
 func (r *RecipeRepositoryImpl) GetAll(ctx context.Context) ([]domain.Recipe, error) {
 	recipes := []domain.Recipe{}
-	query := `SELECT id, menu_id, name, description FROM recipes WHERE deleted = false AND deleted_at IS NULL`
+	query := recipeQuery + ` WHERE recipes.deleted = false AND recipes.deleted_at IS NULL`
 	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		logger.Log.Error(err)
@@ -54,8 +63,7 @@ func (r *RecipeRepositoryImpl) GetAll(ctx context.Context) ([]domain.Recipe, err
 	defer rows.Close()
 
 	for rows.Next() {
-		var recipe domain.Recipe
-		err := rows.Scan(&recipe.Id, &recipe.MenuId, &recipe.Name, &recipe.Description)
+		recipe, err := scanRecipe(rows)
 		if err != nil {
 			logger.Log.Error(err)
 			return nil, err
@@ -109,9 +117,8 @@ func (r *RecipeRepositoryImpl) Restore(ctx context.Context, id uuid.UUID) error
 }
 
 func (r *RecipeRepositoryImpl) GetDeletedById(ctx context.Context, id uuid.UUID) (domain.Recipe, error) {
-	recipe := domain.Recipe{}
-	query := `SELECT id, menu_id, name, description FROM recipes WHERE id = ? AND deleted = true AND deleted_at IS NOT NULL`
-	err := r.db.QueryRowContext(ctx, query, id).Scan(&recipe.Id, &recipe.MenuId, &recipe.Name, &recipe.Description)
+	query := recipeQuery + ` WHERE recipes.id = ? AND recipes.deleted = true AND recipes.deleted_at IS NOT NULL`
+	recipe, err := scanRecipe(r.db.QueryRowContext(ctx, query, id))
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Recipe{}, err
@@ -120,12 +127,31 @@ func (r *RecipeRepositoryImpl) GetDeletedById(ctx context.Context, id uuid.UUID)
 }
 
 func (r *RecipeRepositoryImpl) GetOneByMenuId(ctx context.Context, menuId uuid.UUID) (domain.Recipe, error) {
-	recipe := domain.Recipe{}
-	query := `SELECT id, menu_id, name, description, created_at, updated_at FROM recipes WHERE menu_id = ? AND deleted = false AND deleted_at IS NULL`
-	err := r.db.QueryRowContext(ctx, query, menuId).Scan(&recipe.Id, &recipe.MenuId, &recipe.Name, &recipe.Description, &recipe.CreatedAt, &recipe.UpdatedAt)
+	query := recipeQuery + ` WHERE recipes.menu_id = ? AND recipes.deleted = false AND recipes.deleted_at IS NULL`
+	recipe, err := scanRecipe(r.db.QueryRowContext(ctx, query, menuId))
 	if err != nil {
 		logger.Log.Error(err)
 		return domain.Recipe{}, err
 	}
 	return recipe, nil
 }
+
+type recipeScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecipe(row recipeScanner) (domain.Recipe, error) {
+	var recipe domain.Recipe
+	var menuId, versionId uuid.NullUUID
+	err := row.Scan(&recipe.Id, &menuId, &recipe.Name, &recipe.Description, &versionId, &recipe.Version, &recipe.YieldQuantity, &recipe.YieldUnit, &recipe.CreatedAt, &recipe.UpdatedAt)
+	if err != nil {
+		return domain.Recipe{}, err
+	}
+	if menuId.Valid {
+		recipe.MenuId = &menuId.UUID
+	}
+	if versionId.Valid {
+		recipe.VersionId = &versionId.UUID
+	}
+	return recipe, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RecipeStepRepository interface {
+	Create(ctx context.Context, step domain.RecipeStep) error
+	GetStepsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.RecipeStep, error)
+	DeleteByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) error
+}
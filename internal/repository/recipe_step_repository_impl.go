@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type RecipeStepRepositoryImpl struct {
+	db DB
+}
+
+func NewRecipeStepRepository(db DB) RecipeStepRepository {
+	return &RecipeStepRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *RecipeStepRepositoryImpl) Create(ctx context.Context, step domain.RecipeStep) error {
+	query := `INSERT INTO recipe_steps (id, recipe_version_id, position, instruction, duration_minutes, station) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, step.Id, step.RecipeVersionId, step.Position, step.Instruction, step.DurationMinutes, step.Station)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RecipeStepRepositoryImpl) GetStepsByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) ([]domain.RecipeStep, error) {
+	steps := []domain.RecipeStep{}
+	query := `SELECT id, recipe_version_id, position, instruction, duration_minutes, station, created_at, updated_at FROM recipe_steps WHERE recipe_version_id = ? ORDER BY position`
+	rows, err := r.db.QueryContext(ctx, query, recipeVersionId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var step domain.RecipeStep
+		err = rows.Scan(&step.Id, &step.RecipeVersionId, &step.Position, &step.Instruction, &step.DurationMinutes, &step.Station, &step.CreatedAt, &step.UpdatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		steps = append(steps, step)
+	}
+	return steps, nil
+}
+
+// DeleteByRecipeVersionId removes the steps of a version, a version may have
+// no steps so no rows affected is not an error
+func (r *RecipeStepRepositoryImpl) DeleteByRecipeVersionId(ctx context.Context, recipeVersionId uuid.UUID) error {
+	query := `DELETE FROM recipe_steps WHERE recipe_version_id = ?`
+	_, err := r.db.ExecContext(ctx, query, recipeVersionId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
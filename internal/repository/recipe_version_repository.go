@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RecipeVersionRepository interface {
+	Create(ctx context.Context, version domain.RecipeVersion) error
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.RecipeVersion, error)
+	GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]domain.RecipeVersion, error)
+	GetActiveByRecipeId(ctx context.Context, recipeId uuid.UUID, at time.Time) (domain.RecipeVersion, error)
+	GetDraftByRecipeId(ctx context.Context, recipeId uuid.UUID) (domain.RecipeVersion, error)
+	GetMaxVersionByRecipeId(ctx context.Context, recipeId uuid.UUID) (int, error)
+	UpdateDraft(ctx context.Context, id uuid.UUID, version domain.RecipeVersion) error
+	Publish(ctx context.Context, id uuid.UUID, effectiveFrom time.Time, publishedBy *uuid.UUID) error
+	DeleteDraft(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,163 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type RecipeVersionRepositoryImpl struct {
+	db DB
+}
+
+func NewRecipeVersionRepository(db DB) RecipeVersionRepository {
+	return &RecipeVersionRepositoryImpl{
+		db: db,
+	}
+}
+
+const recipeVersionColumns = `id, recipe_id, version, status, yield_quantity, yield_unit, notes, effective_from, created_by, published_by, published_at, created_at, updated_at`
+
+type recipeVersionScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanRecipeVersion(row recipeVersionScanner) (domain.RecipeVersion, error) {
+	var version domain.RecipeVersion
+	var createdBy, publishedBy uuid.NullUUID
+	err := row.Scan(&version.Id, &version.RecipeId, &version.Version, &version.Status, &version.YieldQuantity, &version.YieldUnit, &version.Notes,
+		&version.EffectiveFrom, &createdBy, &publishedBy, &version.PublishedAt, &version.CreatedAt, &version.UpdatedAt)
+	if err != nil {
+		return domain.RecipeVersion{}, err
+	}
+	if createdBy.Valid {
+		version.CreatedBy = &createdBy.UUID
+	}
+	if publishedBy.Valid {
+		version.PublishedBy = &publishedBy.UUID
+	}
+	return version, nil
+}
+
+func (r *RecipeVersionRepositoryImpl) Create(ctx context.Context, version domain.RecipeVersion) error {
+	query := `INSERT INTO recipe_versions (id, recipe_id, version, status, yield_quantity, yield_unit, notes, created_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, version.Id, version.RecipeId, version.Version, version.Status, version.YieldQuantity, version.YieldUnit, version.Notes, version.CreatedBy)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RecipeVersionRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.RecipeVersion, error) {
+	query := `SELECT ` + recipeVersionColumns + ` FROM recipe_versions WHERE id = ?`
+	version, err := scanRecipeVersion(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.RecipeVersion{}, err
+	}
+	return version, nil
+}
+
+func (r *RecipeVersionRepositoryImpl) GetAllByRecipeId(ctx context.Context, recipeId uuid.UUID) ([]domain.RecipeVersion, error) {
+	versions := []domain.RecipeVersion{}
+	query := `SELECT ` + recipeVersionColumns + ` FROM recipe_versions WHERE recipe_id = ? ORDER BY version DESC`
+	rows, err := r.db.QueryContext(ctx, query, recipeId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		version, err := scanRecipeVersion(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+// GetActiveByRecipeId returns the published version in use at the given
+// moment, a later version number wins when two take effect together
+func (r *RecipeVersionRepositoryImpl) GetActiveByRecipeId(ctx context.Context, recipeId uuid.UUID, at time.Time) (domain.RecipeVersion, error) {
+	query := `SELECT ` + recipeVersionColumns + ` FROM recipe_versions WHERE recipe_id = ? AND status = ? AND effective_from <= ? ORDER BY effective_from DESC, version DESC LIMIT 1`
+	version, err := scanRecipeVersion(r.db.QueryRowContext(ctx, query, recipeId, domain.RecipeVersionStatusPublished, at))
+	if err != nil {
+		return domain.RecipeVersion{}, err
+	}
+	return version, nil
+}
+
+func (r *RecipeVersionRepositoryImpl) GetDraftByRecipeId(ctx context.Context, recipeId uuid.UUID) (domain.RecipeVersion, error) {
+	query := `SELECT ` + recipeVersionColumns + ` FROM recipe_versions WHERE recipe_id = ? AND status = ? ORDER BY version DESC LIMIT 1`
+	version, err := scanRecipeVersion(r.db.QueryRowContext(ctx, query, recipeId, domain.RecipeVersionStatusDraft))
+	if err != nil {
+		return domain.RecipeVersion{}, err
+	}
+	return version, nil
+}
+
+func (r *RecipeVersionRepositoryImpl) GetMaxVersionByRecipeId(ctx context.Context, recipeId uuid.UUID) (int, error) {
+	var version int
+	query := `SELECT COALESCE(MAX(version), 0) FROM recipe_versions WHERE recipe_id = ?`
+	err := r.db.QueryRowContext(ctx, query, recipeId).Scan(&version)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return version, nil
+}
+
+// UpdateDraft may leave the row untouched when nothing changed, so it does
+// not check the affected rows
+func (r *RecipeVersionRepositoryImpl) UpdateDraft(ctx context.Context, id uuid.UUID, version domain.RecipeVersion) error {
+	query := `UPDATE recipe_versions SET yield_quantity = ?, yield_unit = ?, notes = ? WHERE id = ? AND status = ?`
+	_, err := r.db.ExecContext(ctx, query, version.YieldQuantity, version.YieldUnit, version.Notes, id, domain.RecipeVersionStatusDraft)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *RecipeVersionRepositoryImpl) Publish(ctx context.Context, id uuid.UUID, effectiveFrom time.Time, publishedBy *uuid.UUID) error {
+	query := `UPDATE recipe_versions SET status = ?, effective_from = ?, published_by = ?, published_at = ? WHERE id = ? AND status = ?`
+	res, err := r.db.ExecContext(ctx, query, domain.RecipeVersionStatusPublished, effectiveFrom, publishedBy, time.Now(), id, domain.RecipeVersionStatusDraft)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RecipeVersionRepositoryImpl) DeleteDraft(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM recipe_versions WHERE id = ? AND status = ?`
+	res, err := r.db.ExecContext(ctx, query, id, domain.RecipeVersionStatusDraft)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RefundItemRepository interface {
+	Create(ctx context.Context, item domain.RefundItem) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.RefundItem, error)
+	SumQuantitiesByOrderId(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error)
+}
@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RefundItemRepositoryImpl struct {
+	db DB
+}
+
+func NewRefundItemRepository(db DB) RefundItemRepository {
+	return &RefundItemRepositoryImpl{db}
+}
+
+func (r *RefundItemRepositoryImpl) Create(ctx context.Context, item domain.RefundItem) error {
+	query := `INSERT INTO refund_items (id, refund_id, order_menu_id, quantity, amount) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, item.Id, item.RefundId, item.OrderMenuId, item.Quantity, item.Amount)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RefundItemRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.RefundItem, error) {
+	items := []domain.RefundItem{}
+	query := `SELECT refund_items.id, refund_items.refund_id, refund_items.order_menu_id, order_menu.menu_name, refund_items.quantity, refund_items.amount
+		FROM refund_items JOIN order_menu ON order_menu.id = refund_items.order_menu_id
+		WHERE order_menu.order_id = ?
+		ORDER BY order_menu.created_at, order_menu.menu_name`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var item domain.RefundItem
+		err := rows.Scan(&item.Id, &item.RefundId, &item.OrderMenuId, &item.MenuName, &item.Quantity, &item.Amount)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// SumQuantitiesByOrderId returns per order line how many units were refunded
+func (r *RefundItemRepositoryImpl) SumQuantitiesByOrderId(ctx context.Context, orderId uuid.UUID) (map[uuid.UUID]int, error) {
+	quantities := map[uuid.UUID]int{}
+	query := `SELECT refund_items.order_menu_id, SUM(refund_items.quantity)
+		FROM refund_items JOIN order_menu ON order_menu.id = refund_items.order_menu_id
+		WHERE order_menu.order_id = ?
+		GROUP BY refund_items.order_menu_id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var orderMenuId uuid.UUID
+		var quantity int
+		if err := rows.Scan(&orderMenuId, &quantity); err != nil {
+			return nil, err
+		}
+		quantities[orderMenuId] = quantity
+	}
+	return quantities, nil
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RefundRepository interface {
+	Create(ctx context.Context, refund domain.Refund) error
+	GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.Refund, error)
+}
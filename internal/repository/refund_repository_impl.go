@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type RefundRepositoryImpl struct {
+	db DB
+}
+
+func NewRefundRepository(db DB) RefundRepository {
+	return &RefundRepositoryImpl{db}
+}
+
+func (r *RefundRepositoryImpl) Create(ctx context.Context, refund domain.Refund) error {
+	query := `INSERT INTO refunds (id, order_id, amount, reason_code, note, restocked, created_by, approved_by) VALUES (?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, refund.Id, refund.OrderId, refund.Amount, refund.ReasonCode, refund.Note, refund.Restocked, refund.CreatedBy, refund.ApprovedBy)
+	if err != nil {
+		return err
+	}
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *RefundRepositoryImpl) GetAllByOrderId(ctx context.Context, orderId uuid.UUID) ([]domain.Refund, error) {
+	refunds := []domain.Refund{}
+	query := `SELECT id, order_id, amount, reason_code, note, restocked, created_by, approved_by, created_at FROM refunds WHERE order_id = ? ORDER BY created_at, id`
+	rows, err := r.db.QueryContext(ctx, query, orderId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var refund domain.Refund
+		var note sql.NullString
+		var createdBy, approvedBy uuid.NullUUID
+		err := rows.Scan(&refund.Id, &refund.OrderId, &refund.Amount, &refund.ReasonCode, &note, &refund.Restocked, &createdBy, &approvedBy, &refund.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		if note.Valid {
+			refund.Note = &note.String
+		}
+		if createdBy.Valid {
+			refund.CreatedBy = &createdBy.UUID
+		}
+		if approvedBy.Valid {
+			refund.ApprovedBy = &approvedBy.UUID
+		}
+		refunds = append(refunds, refund)
+	}
+	return refunds, nil
+}
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type StockTransferRepository interface {
+	Create(ctx context.Context, transfer domain.StockTransfer) error
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.StockTransfer, error)
+	GetAll(ctx context.Context, filter domain.StockTransferFilter) ([]domain.StockTransfer, error)
+}
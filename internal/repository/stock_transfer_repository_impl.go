@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type StockTransferRepositoryImpl struct {
+	db DB
+}
+
+func NewStockTransferRepository(db DB) StockTransferRepository {
+	return &StockTransferRepositoryImpl{
+		db: db,
+	}
+}
+
+const stockTransferQuery = `
+	SELECT stock_transfers.id, stock_transfers.ingredient_id, ingredients.name, ingredients.base_unit,
+		stock_transfers.from_location_id, from_locations.name, stock_transfers.to_location_id, to_locations.name,
+		stock_transfers.quantity, stock_transfers.reason, stock_transfers.actor_id, stock_transfers.created_at
+	FROM stock_transfers
+	INNER JOIN ingredients ON stock_transfers.ingredient_id = ingredients.id
+	INNER JOIN storage_locations AS from_locations ON stock_transfers.from_location_id = from_locations.id
+	INNER JOIN storage_locations AS to_locations ON stock_transfers.to_location_id = to_locations.id
+`
+
+func (r *StockTransferRepositoryImpl) Create(ctx context.Context, transfer domain.StockTransfer) error {
+	query := `INSERT INTO stock_transfers (id, ingredient_id, from_location_id, to_location_id, quantity, reason, actor_id) VALUES (?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, transfer.Id, transfer.IngredientId, transfer.FromLocationId, transfer.ToLocationId, transfer.Quantity, transfer.Reason, transfer.ActorId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *StockTransferRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.StockTransfer, error) {
+	query := stockTransferQuery + ` WHERE stock_transfers.id = ?`
+	transfer, err := scanStockTransfer(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.StockTransfer{}, err
+	}
+	return transfer, nil
+}
+
+func (r *StockTransferRepositoryImpl) GetAll(ctx context.Context, filter domain.StockTransferFilter) ([]domain.StockTransfer, error) {
+	transfers := []domain.StockTransfer{}
+	conditions := []string{}
+	args := []interface{}{}
+	if filter.IngredientId != nil {
+		conditions = append(conditions, "stock_transfers.ingredient_id = ?")
+		args = append(args, *filter.IngredientId)
+	}
+	if filter.LocationId != nil {
+		conditions = append(conditions, "(stock_transfers.from_location_id = ? OR stock_transfers.to_location_id = ?)")
+		args = append(args, *filter.LocationId, *filter.LocationId)
+	}
+
+	query := stockTransferQuery
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY stock_transfers.created_at DESC"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		transfer, err := scanStockTransfer(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+type stockTransferScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStockTransfer(row stockTransferScanner) (domain.StockTransfer, error) {
+	var transfer domain.StockTransfer
+	var reason sql.NullString
+	var actorId uuid.NullUUID
+	err := row.Scan(&transfer.Id, &transfer.IngredientId, &transfer.Name, &transfer.Unit, &transfer.FromLocationId, &transfer.FromLocationName, &transfer.ToLocationId, &transfer.ToLocationName, &transfer.Quantity, &reason, &actorId, &transfer.CreatedAt)
+	if err != nil {
+		return domain.StockTransfer{}, err
+	}
+	if reason.Valid {
+		transfer.Reason = &reason.String
+	}
+	if actorId.Valid {
+		transfer.ActorId = &actorId.UUID
+	}
+	return transfer, nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type StocktakeLineRepository interface {
+	Create(ctx context.Context, line domain.StocktakeLine) error
+	UpdateCount(ctx context.Context, id uuid.UUID, line domain.StocktakeLine) error
+	UpdateExpected(ctx context.Context, id uuid.UUID, expectedQuantity, unitCost float64) error
+	GetOneByStocktakeIdAndIngredientIdAndLocationId(ctx context.Context, stocktakeId, ingredientId, locationId uuid.UUID) (domain.StocktakeLine, error)
+	GetAllByStocktakeId(ctx context.Context, stocktakeId uuid.UUID) ([]domain.StocktakeLine, error)
+}
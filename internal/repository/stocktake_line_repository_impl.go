@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type StocktakeLineRepositoryImpl struct {
+	db DB
+}
+
+func NewStocktakeLineRepository(db DB) StocktakeLineRepository {
+	return &StocktakeLineRepositoryImpl{
+		db: db,
+	}
+}
+
+// lines keep the stock and cost at the time of their count
+const stocktakeLineQuery = `
+	SELECT stocktake_lines.id, stocktake_lines.stocktake_id, stocktake_lines.ingredient_id, ingredients.name, ingredients.base_unit,
+		stocktake_lines.location_id, storage_locations.name, stocktake_lines.counted_quantity,
+		stocktake_lines.expected_quantity, stocktake_lines.unit_cost,
+		stocktake_lines.counted_by, stocktake_lines.updated_at
+	FROM stocktake_lines
+	INNER JOIN ingredients ON stocktake_lines.ingredient_id = ingredients.id
+	INNER JOIN storage_locations ON stocktake_lines.location_id = storage_locations.id
+`
+
+func (r *StocktakeLineRepositoryImpl) Create(ctx context.Context, line domain.StocktakeLine) error {
+	query := `INSERT INTO stocktake_lines (id, stocktake_id, ingredient_id, location_id, counted_quantity, counted_by) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, line.Id, line.StocktakeId, line.IngredientId, line.LocationId, line.CountedQuantity, line.CountedBy)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *StocktakeLineRepositoryImpl) UpdateCount(ctx context.Context, id uuid.UUID, line domain.StocktakeLine) error {
+	query := `UPDATE stocktake_lines SET counted_quantity = ?, counted_by = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, line.CountedQuantity, line.CountedBy, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *StocktakeLineRepositoryImpl) UpdateExpected(ctx context.Context, id uuid.UUID, expectedQuantity, unitCost float64) error {
+	query := `UPDATE stocktake_lines SET expected_quantity = ?, unit_cost = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, expectedQuantity, unitCost, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *StocktakeLineRepositoryImpl) GetOneByStocktakeIdAndIngredientIdAndLocationId(ctx context.Context, stocktakeId, ingredientId, locationId uuid.UUID) (domain.StocktakeLine, error) {
+	query := stocktakeLineQuery + ` WHERE stocktake_lines.stocktake_id = ? AND stocktake_lines.ingredient_id = ? AND stocktake_lines.location_id = ?`
+	line, err := scanStocktakeLine(r.db.QueryRowContext(ctx, query, stocktakeId, ingredientId, locationId))
+	if err != nil {
+		return domain.StocktakeLine{}, err
+	}
+	return line, nil
+}
+
+func (r *StocktakeLineRepositoryImpl) GetAllByStocktakeId(ctx context.Context, stocktakeId uuid.UUID) ([]domain.StocktakeLine, error) {
+	lines := []domain.StocktakeLine{}
+	query := stocktakeLineQuery + ` WHERE stocktake_lines.stocktake_id = ? ORDER BY storage_locations.name, ingredients.name`
+	rows, err := r.db.QueryContext(ctx, query, stocktakeId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		line, err := scanStocktakeLine(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+type stocktakeLineScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStocktakeLine(row stocktakeLineScanner) (domain.StocktakeLine, error) {
+	var line domain.StocktakeLine
+	var countedBy uuid.NullUUID
+	err := row.Scan(&line.Id, &line.StocktakeId, &line.IngredientId, &line.Name, &line.Unit, &line.LocationId, &line.LocationName, &line.CountedQuantity, &line.ExpectedQuantity, &line.UnitCost, &countedBy, &line.UpdatedAt)
+	if err != nil {
+		return domain.StocktakeLine{}, err
+	}
+	if countedBy.Valid {
+		line.CountedBy = &countedBy.UUID
+	}
+	line.Variance = line.CountedQuantity - line.ExpectedQuantity
+	line.VarianceCost = line.Variance * line.UnitCost
+	return line, nil
+}
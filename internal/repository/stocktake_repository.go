@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type StocktakeRepository interface {
+	Create(ctx context.Context, stocktake domain.Stocktake) error
+	GetAll(ctx context.Context) ([]domain.Stocktake, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.Stocktake, error)
+	GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Stocktake, error)
+	GetOpen(ctx context.Context) (domain.Stocktake, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status string, actorId uuid.UUID) error
+}
@@ -0,0 +1,125 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type StocktakeRepositoryImpl struct {
+	db DB
+}
+
+func NewStocktakeRepository(db DB) StocktakeRepository {
+	return &StocktakeRepositoryImpl{
+		db: db,
+	}
+}
+
+const stocktakeColumns = `id, status, notes, opened_by, closed_by, closed_at, created_at, updated_at`
+
+type stocktakeScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStocktake(row stocktakeScanner) (domain.Stocktake, error) {
+	var stocktake domain.Stocktake
+	var openedBy, closedBy uuid.NullUUID
+	err := row.Scan(&stocktake.Id, &stocktake.Status, &stocktake.Notes, &openedBy, &closedBy, &stocktake.ClosedAt, &stocktake.CreatedAt, &stocktake.UpdatedAt)
+	if err != nil {
+		return domain.Stocktake{}, err
+	}
+	if openedBy.Valid {
+		stocktake.OpenedBy = &openedBy.UUID
+	}
+	if closedBy.Valid {
+		stocktake.ClosedBy = &closedBy.UUID
+	}
+	return stocktake, nil
+}
+
+func (r *StocktakeRepositoryImpl) Create(ctx context.Context, stocktake domain.Stocktake) error {
+	query := `INSERT INTO stocktakes (id, status, notes, opened_by) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, stocktake.Id, stocktake.Status, stocktake.Notes, stocktake.OpenedBy)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *StocktakeRepositoryImpl) GetAll(ctx context.Context) ([]domain.Stocktake, error) {
+	stocktakes := []domain.Stocktake{}
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes ORDER BY created_at DESC, id`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		stocktake, err := scanStocktake(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		stocktakes = append(stocktakes, stocktake)
+	}
+	return stocktakes, nil
+}
+
+func (r *StocktakeRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Stocktake, error) {
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes WHERE id = ?`
+	stocktake, err := scanStocktake(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.Stocktake{}, err
+	}
+	return stocktake, nil
+}
+
+// GetOneByIdForUpdate is GetOneById locking the stocktake until the
+// transaction ends
+func (r *StocktakeRepositoryImpl) GetOneByIdForUpdate(ctx context.Context, id uuid.UUID) (domain.Stocktake, error) {
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes WHERE id = ? FOR UPDATE`
+	stocktake, err := scanStocktake(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.Stocktake{}, err
+	}
+	return stocktake, nil
+}
+
+func (r *StocktakeRepositoryImpl) GetOpen(ctx context.Context) (domain.Stocktake, error) {
+	query := `SELECT ` + stocktakeColumns + ` FROM stocktakes WHERE status = 'open' LIMIT 1`
+	stocktake, err := scanStocktake(r.db.QueryRowContext(ctx, query))
+	if err != nil {
+		return domain.Stocktake{}, err
+	}
+	return stocktake, nil
+}
+
+// UpdateStatus closes or cancels a stocktake, actorId is kept as closed_by
+func (r *StocktakeRepositoryImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status string, actorId uuid.UUID) error {
+	query := `UPDATE stocktakes SET status = ?, closed_by = ?, closed_at = CURRENT_TIMESTAMP WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, status, actorId, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
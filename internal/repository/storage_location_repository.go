@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type StorageLocationRepository interface {
+	Create(ctx context.Context, location domain.StorageLocation) error
+	GetAll(ctx context.Context) ([]domain.StorageLocation, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.StorageLocation, error)
+	GetDefault(ctx context.Context) (domain.StorageLocation, error)
+	GetKitchen(ctx context.Context) ([]domain.StorageLocation, error)
+	Update(ctx context.Context, id uuid.UUID, location domain.StorageLocation) error
+	SetDefault(ctx context.Context, id uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
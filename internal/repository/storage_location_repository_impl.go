@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type StorageLocationRepositoryImpl struct {
+	db DB
+}
+
+func NewStorageLocationRepository(db DB) StorageLocationRepository {
+	return &StorageLocationRepositoryImpl{
+		db: db,
+	}
+}
+
+const storageLocationColumns = `id, name, feeds_kitchen, is_default, created_at, updated_at`
+
+func (r *StorageLocationRepositoryImpl) Create(ctx context.Context, location domain.StorageLocation) error {
+	query := `INSERT INTO storage_locations (id, name, feeds_kitchen, is_default) VALUES (?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, location.Id, location.Name, location.FeedsKitchen, location.IsDefault)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *StorageLocationRepositoryImpl) GetAll(ctx context.Context) ([]domain.StorageLocation, error) {
+	query := `SELECT ` + storageLocationColumns + ` FROM storage_locations WHERE deleted = false AND deleted_at IS NULL ORDER BY is_default DESC, name`
+	return r.queryLocations(ctx, query)
+}
+
+func (r *StorageLocationRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.StorageLocation, error) {
+	query := `SELECT ` + storageLocationColumns + ` FROM storage_locations WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	location, err := scanStorageLocation(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.StorageLocation{}, err
+	}
+	return location, nil
+}
+
+func (r *StorageLocationRepositoryImpl) GetDefault(ctx context.Context) (domain.StorageLocation, error) {
+	query := `SELECT ` + storageLocationColumns + ` FROM storage_locations WHERE is_default = true AND deleted = false AND deleted_at IS NULL LIMIT 1`
+	location, err := scanStorageLocation(r.db.QueryRowContext(ctx, query))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.StorageLocation{}, err
+	}
+	return location, nil
+}
+
+// GetKitchen returns the locations feeding the kitchen in the order they are
+// drawn down, the default location first
+func (r *StorageLocationRepositoryImpl) GetKitchen(ctx context.Context) ([]domain.StorageLocation, error) {
+	query := `SELECT ` + storageLocationColumns + ` FROM storage_locations WHERE feeds_kitchen = true AND deleted = false AND deleted_at IS NULL ORDER BY is_default DESC, name`
+	return r.queryLocations(ctx, query)
+}
+
+func (r *StorageLocationRepositoryImpl) Update(ctx context.Context, id uuid.UUID, location domain.StorageLocation) error {
+	query := `UPDATE storage_locations SET name = ?, feeds_kitchen = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, location.Name, location.FeedsKitchen, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// SetDefault makes id the only default location
+func (r *StorageLocationRepositoryImpl) SetDefault(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE storage_locations SET is_default = (id = ?) WHERE deleted = false AND deleted_at IS NULL`
+	_, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *StorageLocationRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE storage_locations SET deleted = true, deleted_at = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *StorageLocationRepositoryImpl) queryLocations(ctx context.Context, query string, args ...interface{}) ([]domain.StorageLocation, error) {
+	locations := []domain.StorageLocation{}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		location, err := scanStorageLocation(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		locations = append(locations, location)
+	}
+	return locations, nil
+}
+
+type storageLocationScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanStorageLocation(row storageLocationScanner) (domain.StorageLocation, error) {
+	var location domain.StorageLocation
+	err := row.Scan(&location.Id, &location.Name, &location.FeedsKitchen, &location.IsDefault, &location.CreatedAt, &location.UpdatedAt)
+	if err != nil {
+		return domain.StorageLocation{}, err
+	}
+	return location, nil
+}
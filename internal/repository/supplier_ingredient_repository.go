@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type SupplierIngredientRepository interface {
+	Create(ctx context.Context, supplierIngredient domain.SupplierIngredient) error
+	Update(ctx context.Context, id uuid.UUID, supplierIngredient domain.SupplierIngredient) error
+	GetAllBySupplierId(ctx context.Context, supplierId uuid.UUID) ([]domain.SupplierIngredient, error)
+	GetOneBySupplierIdAndIngredientId(ctx context.Context, supplierId, ingredientId uuid.UUID) (domain.SupplierIngredient, error)
+	GetPreferredByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.SupplierIngredient, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,114 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type SupplierIngredientRepositoryImpl struct {
+	db DB
+}
+
+func NewSupplierIngredientRepository(db DB) SupplierIngredientRepository {
+	return &SupplierIngredientRepositoryImpl{
+		db: db,
+	}
+}
+
+const supplierIngredientColumns = `supplier_ingredients.id, supplier_ingredients.supplier_id, supplier_ingredients.ingredient_id, ingredients.name, ingredients.base_unit, supplier_ingredients.unit_cost, supplier_ingredients.lead_time_days, supplier_ingredients.created_at, supplier_ingredients.updated_at`
+
+func (r *SupplierIngredientRepositoryImpl) Create(ctx context.Context, supplierIngredient domain.SupplierIngredient) error {
+	query := `INSERT INTO supplier_ingredients (id, supplier_id, ingredient_id, unit_cost, lead_time_days) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, supplierIngredient.Id, supplierIngredient.SupplierId, supplierIngredient.IngredientId, supplierIngredient.UnitCost, supplierIngredient.LeadTimeDays)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *SupplierIngredientRepositoryImpl) Update(ctx context.Context, id uuid.UUID, supplierIngredient domain.SupplierIngredient) error {
+	query := `UPDATE supplier_ingredients SET unit_cost = ?, lead_time_days = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, supplierIngredient.UnitCost, supplierIngredient.LeadTimeDays, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *SupplierIngredientRepositoryImpl) GetAllBySupplierId(ctx context.Context, supplierId uuid.UUID) ([]domain.SupplierIngredient, error) {
+	supplierIngredients := []domain.SupplierIngredient{}
+	query := `SELECT ` + supplierIngredientColumns + ` FROM supplier_ingredients INNER JOIN ingredients ON supplier_ingredients.ingredient_id = ingredients.id WHERE supplier_ingredients.supplier_id = ? ORDER BY ingredients.name`
+	rows, err := r.db.QueryContext(ctx, query, supplierId)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var supplierIngredient domain.SupplierIngredient
+		err := rows.Scan(&supplierIngredient.Id, &supplierIngredient.SupplierId, &supplierIngredient.IngredientId, &supplierIngredient.Name, &supplierIngredient.Unit, &supplierIngredient.UnitCost, &supplierIngredient.LeadTimeDays, &supplierIngredient.CreatedAt, &supplierIngredient.UpdatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		supplierIngredients = append(supplierIngredients, supplierIngredient)
+	}
+	return supplierIngredients, nil
+}
+
+func (r *SupplierIngredientRepositoryImpl) GetOneBySupplierIdAndIngredientId(ctx context.Context, supplierId, ingredientId uuid.UUID) (domain.SupplierIngredient, error) {
+	supplierIngredient := domain.SupplierIngredient{}
+	query := `SELECT ` + supplierIngredientColumns + ` FROM supplier_ingredients INNER JOIN ingredients ON supplier_ingredients.ingredient_id = ingredients.id WHERE supplier_ingredients.supplier_id = ? AND supplier_ingredients.ingredient_id = ?`
+	err := r.db.QueryRowContext(ctx, query, supplierId, ingredientId).Scan(&supplierIngredient.Id, &supplierIngredient.SupplierId, &supplierIngredient.IngredientId, &supplierIngredient.Name, &supplierIngredient.Unit, &supplierIngredient.UnitCost, &supplierIngredient.LeadTimeDays, &supplierIngredient.CreatedAt, &supplierIngredient.UpdatedAt)
+	if err != nil {
+		return domain.SupplierIngredient{}, err
+	}
+	return supplierIngredient, nil
+}
+
+// GetPreferredByIngredientId returns the cheapest active supplier of an
+// ingredient, the shorter lead time wins a tie
+func (r *SupplierIngredientRepositoryImpl) GetPreferredByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.SupplierIngredient, error) {
+	supplierIngredient := domain.SupplierIngredient{}
+	query := `
+		SELECT ` + supplierIngredientColumns + `
+		FROM supplier_ingredients
+		INNER JOIN ingredients ON supplier_ingredients.ingredient_id = ingredients.id
+		INNER JOIN suppliers ON supplier_ingredients.supplier_id = suppliers.id
+		WHERE supplier_ingredients.ingredient_id = ? AND suppliers.deleted = false AND suppliers.deleted_at IS NULL
+		ORDER BY supplier_ingredients.unit_cost, supplier_ingredients.lead_time_days
+		LIMIT 1
+	`
+	err := r.db.QueryRowContext(ctx, query, ingredientId).Scan(&supplierIngredient.Id, &supplierIngredient.SupplierId, &supplierIngredient.IngredientId, &supplierIngredient.Name, &supplierIngredient.Unit, &supplierIngredient.UnitCost, &supplierIngredient.LeadTimeDays, &supplierIngredient.CreatedAt, &supplierIngredient.UpdatedAt)
+	if err != nil {
+		return domain.SupplierIngredient{}, err
+	}
+	return supplierIngredient, nil
+}
+
+func (r *SupplierIngredientRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM supplier_ingredients WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type SupplierRepository interface {
+	Create(ctx context.Context, supplier domain.Supplier) error
+	GetAll(ctx context.Context) ([]domain.Supplier, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.Supplier, error)
+	Update(ctx context.Context, id uuid.UUID, supplier domain.Supplier) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type SupplierRepositoryImpl struct {
+	db DB
+}
+
+func NewSupplierRepository(db DB) SupplierRepository {
+	return &SupplierRepositoryImpl{
+		db: db,
+	}
+}
+
+func (r *SupplierRepositoryImpl) Create(ctx context.Context, supplier domain.Supplier) error {
+	query := `INSERT INTO suppliers (id, name, contact_name, email, phone, address) VALUES (?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, supplier.Id, supplier.Name, supplier.ContactName, supplier.Email, supplier.Phone, supplier.Address)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *SupplierRepositoryImpl) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+	suppliers := []domain.Supplier{}
+	query := `SELECT id, name, contact_name, email, phone, address, created_at, updated_at FROM suppliers WHERE deleted = false AND deleted_at IS NULL ORDER BY name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var supplier domain.Supplier
+		err := rows.Scan(&supplier.Id, &supplier.Name, &supplier.ContactName, &supplier.Email, &supplier.Phone, &supplier.Address, &supplier.CreatedAt, &supplier.UpdatedAt)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		suppliers = append(suppliers, supplier)
+	}
+	return suppliers, nil
+}
+
+func (r *SupplierRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Supplier, error) {
+	supplier := domain.Supplier{}
+	query := `SELECT id, name, contact_name, email, phone, address, created_at, updated_at FROM suppliers WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	err := r.db.QueryRowContext(ctx, query, id).Scan(&supplier.Id, &supplier.Name, &supplier.ContactName, &supplier.Email, &supplier.Phone, &supplier.Address, &supplier.CreatedAt, &supplier.UpdatedAt)
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.Supplier{}, err
+	}
+	return supplier, nil
+}
+
+func (r *SupplierRepositoryImpl) Update(ctx context.Context, id uuid.UUID, supplier domain.Supplier) error {
+	query := `UPDATE suppliers SET name = ?, contact_name = ?, email = ?, phone = ?, address = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, supplier.Name, supplier.ContactName, supplier.Email, supplier.Phone, supplier.Address, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *SupplierRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE suppliers SET deleted = true, deleted_at = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
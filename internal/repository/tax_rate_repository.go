@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type TaxRateRepository interface {
+	Create(ctx context.Context, rate domain.TaxRate) error
+	GetAll(ctx context.Context) ([]domain.TaxRate, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.TaxRate, error)
+	Update(ctx context.Context, id uuid.UUID, rate domain.TaxRate) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type TaxRateRepositoryImpl struct {
+	db DB
+}
+
+func NewTaxRateRepository(db DB) TaxRateRepository {
+	return &TaxRateRepositoryImpl{
+		db: db,
+	}
+}
+
+const taxRateColumns = `id, name, rate_percent, inclusive, category, created_at, updated_at`
+
+func (r *TaxRateRepositoryImpl) Create(ctx context.Context, rate domain.TaxRate) error {
+	query := `INSERT INTO tax_rates (id, name, rate_percent, inclusive, category) VALUES (?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, rate.Id, rate.Name, rate.RatePercent, rate.Inclusive, rate.Category)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *TaxRateRepositoryImpl) GetAll(ctx context.Context) ([]domain.TaxRate, error) {
+	rates := []domain.TaxRate{}
+	query := `SELECT ` + taxRateColumns + ` FROM tax_rates WHERE deleted = false AND deleted_at IS NULL ORDER BY category, name`
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rate, err := scanTaxRate(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func (r *TaxRateRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.TaxRate, error) {
+	query := `SELECT ` + taxRateColumns + ` FROM tax_rates WHERE id = ? AND deleted = false AND deleted_at IS NULL`
+	rate, err := scanTaxRate(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.TaxRate{}, err
+	}
+	return rate, nil
+}
+
+func (r *TaxRateRepositoryImpl) Update(ctx context.Context, id uuid.UUID, rate domain.TaxRate) error {
+	query := `UPDATE tax_rates SET name = ?, rate_percent = ?, inclusive = ?, category = ? WHERE id = ?`
+	_, err := r.db.ExecContext(ctx, query, rate.Name, rate.RatePercent, rate.Inclusive, rate.Category, id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+func (r *TaxRateRepositoryImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE tax_rates SET deleted = true, deleted_at = ? WHERE id = ?`
+	res, err := r.db.ExecContext(ctx, query, time.Now(), id)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+type taxRateScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanTaxRate(row taxRateScanner) (domain.TaxRate, error) {
+	var rate domain.TaxRate
+	var category sql.NullString
+	err := row.Scan(&rate.Id, &rate.Name, &rate.RatePercent, &rate.Inclusive, &category, &rate.CreatedAt, &rate.UpdatedAt)
+	if err != nil {
+		return domain.TaxRate{}, err
+	}
+	if category.Valid {
+		rate.Category = &category.String
+	}
+	return rate, nil
+}
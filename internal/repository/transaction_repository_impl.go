@@ -25,33 +25,89 @@ func NewTransactionRepository(db *sql.DB) TransactionRepository {
 }
 
 type Adapters struct {
-	UserRepository             UserRepository
-	MenuRepository             MenuRepository
-	TableRepository            TableRepository
-	ReservationRepository      ReservationRepository
-	OrderRepository            OrderRepository
-	OrderMenuRepository        OrderMenuRepository
-	ReviewRepository           ReviewRepository
-	RecipeRepository           RecipeRepository
-	IngredientRepository       IngredientRepository
-	RecipeIngredientRepository RecipeIngredientRepository
-	InventoryRepository        InventoryRepository
+	UserRepository                     UserRepository
+	MenuRepository                     MenuRepository
+	TableRepository                    TableRepository
+	ReservationRepository              ReservationRepository
+	OrderRepository                    OrderRepository
+	OrderMenuRepository                OrderMenuRepository
+	OrderStatusHistoryRepository       OrderStatusHistoryRepository
+	PaymentRepository                  PaymentRepository
+	PaymentAllocationRepository        PaymentAllocationRepository
+	PaymentRefundRepository            PaymentRefundRepository
+	RefundRepository                   RefundRepository
+	RefundItemRepository               RefundItemRepository
+	OrderVoidRepository                OrderVoidRepository
+	OrderItemTaxRepository             OrderItemTaxRepository
+	OrderTaxLineRepository             OrderTaxLineRepository
+	TaxRateRepository                  TaxRateRepository
+	ReviewRepository                   ReviewRepository
+	RecipeRepository                   RecipeRepository
+	IngredientRepository               IngredientRepository
+	IngredientUnitConversionRepository IngredientUnitConversionRepository
+	IngredientCostHistoryRepository    IngredientCostHistoryRepository
+	RecipeIngredientRepository         RecipeIngredientRepository
+	RecipeComponentRepository          RecipeComponentRepository
+	RecipeVersionRepository            RecipeVersionRepository
+	IngredientNutritionRepository      IngredientNutritionRepository
+	RecipeStepRepository               RecipeStepRepository
+	InventoryRepository                InventoryRepository
+	InventoryMovementRepository        InventoryMovementRepository
+	InventoryLotRepository             InventoryLotRepository
+	InventoryLotDrawRepository         InventoryLotDrawRepository
+	SupplierRepository                 SupplierRepository
+	SupplierIngredientRepository       SupplierIngredientRepository
+	PurchaseOrderRepository            PurchaseOrderRepository
+	PurchaseOrderItemRepository        PurchaseOrderItemRepository
+	WasteLogRepository                 WasteLogRepository
+	StocktakeRepository                StocktakeRepository
+	StocktakeLineRepository            StocktakeLineRepository
+	StorageLocationRepository          StorageLocationRepository
+	StockTransferRepository            StockTransferRepository
 }
 
 func (p *TransactionRepositoryImpl) Transact(txFunc func(adapters Adapters) error) error {
 	return runInTx(p.db, func(tx *sql.Tx) error {
 		adapters := Adapters{
-			UserRepository:             NewUserRepository(tx),
-			MenuRepository:             NewMenuRepository(tx),
-			TableRepository:            NewTableRepository(tx),
-			ReservationRepository:      NewReservationRepository(tx),
-			OrderRepository:            NewOrderRepository(tx),
-			OrderMenuRepository:        NewOrderMenuRepository(tx),
-			ReviewRepository:           NewReviewRepository(tx),
-			RecipeRepository:           NewRecipeRepository(tx),
-			IngredientRepository:       NewIngredientRepository(tx),
-			RecipeIngredientRepository: NewRecipeIngredientRepository(tx),
-			InventoryRepository:        NewInventoryRepository(tx),
+			UserRepository:                     NewUserRepository(tx),
+			MenuRepository:                     NewMenuRepository(tx),
+			TableRepository:                    NewTableRepository(tx),
+			ReservationRepository:              NewReservationRepository(tx),
+			OrderRepository:                    NewOrderRepository(tx),
+			OrderMenuRepository:                NewOrderMenuRepository(tx),
+			OrderStatusHistoryRepository:       NewOrderStatusHistoryRepository(tx),
+			PaymentRepository:                  NewPaymentRepository(tx),
+			PaymentAllocationRepository:        NewPaymentAllocationRepository(tx),
+			PaymentRefundRepository:            NewPaymentRefundRepository(tx),
+			RefundRepository:                   NewRefundRepository(tx),
+			RefundItemRepository:               NewRefundItemRepository(tx),
+			OrderVoidRepository:                NewOrderVoidRepository(tx),
+			OrderItemTaxRepository:             NewOrderItemTaxRepository(tx),
+			OrderTaxLineRepository:             NewOrderTaxLineRepository(tx),
+			TaxRateRepository:                  NewTaxRateRepository(tx),
+			ReviewRepository:                   NewReviewRepository(tx),
+			RecipeRepository:                   NewRecipeRepository(tx),
+			IngredientRepository:               NewIngredientRepository(tx),
+			IngredientUnitConversionRepository: NewIngredientUnitConversionRepository(tx),
+			IngredientCostHistoryRepository:    NewIngredientCostHistoryRepository(tx),
+			RecipeIngredientRepository:         NewRecipeIngredientRepository(tx),
+			RecipeComponentRepository:          NewRecipeComponentRepository(tx),
+			RecipeVersionRepository:            NewRecipeVersionRepository(tx),
+			IngredientNutritionRepository:      NewIngredientNutritionRepository(tx),
+			RecipeStepRepository:               NewRecipeStepRepository(tx),
+			InventoryRepository:                NewInventoryRepository(tx),
+			InventoryMovementRepository:        NewInventoryMovementRepository(tx),
+			InventoryLotRepository:             NewInventoryLotRepository(tx),
+			InventoryLotDrawRepository:         NewInventoryLotDrawRepository(tx),
+			SupplierRepository:                 NewSupplierRepository(tx),
+			SupplierIngredientRepository:       NewSupplierIngredientRepository(tx),
+			PurchaseOrderRepository:            NewPurchaseOrderRepository(tx),
+			PurchaseOrderItemRepository:        NewPurchaseOrderItemRepository(tx),
+			WasteLogRepository:                 NewWasteLogRepository(tx),
+			StocktakeRepository:                NewStocktakeRepository(tx),
+			StocktakeLineRepository:            NewStocktakeLineRepository(tx),
+			StorageLocationRepository:          NewStorageLocationRepository(tx),
+			StockTransferRepository:            NewStockTransferRepository(tx),
 		}
 
 		return txFunc(adapters)
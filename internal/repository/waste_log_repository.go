@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+type WasteLogRepository interface {
+	Create(ctx context.Context, wasteLog domain.WasteLog) error
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.WasteLog, error)
+	GetAll(ctx context.Context, filter domain.WasteLogFilter) ([]domain.WasteLog, error)
+	Count(ctx context.Context, filter domain.WasteLogFilter) (int, error)
+	SumByReason(ctx context.Context, filter domain.WasteLogFilter) ([]domain.WasteSummary, error)
+	SumByPeriod(ctx context.Context, filter domain.WasteLogFilter, period string) ([]domain.WasteSummary, error)
+}
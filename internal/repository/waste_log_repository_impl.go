@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type WasteLogRepositoryImpl struct {
+	db DB
+}
+
+func NewWasteLogRepository(db DB) WasteLogRepository {
+	return &WasteLogRepositoryImpl{
+		db: db,
+	}
+}
+
+// wastePeriodFormats maps a report period to the MySQL format that groups it
+var wastePeriodFormats = map[string]string{
+	"day":   "%Y-%m-%d",
+	"week":  "%x-W%v",
+	"month": "%Y-%m",
+}
+
+const wasteLogColumns = `waste_logs.id, waste_logs.ingredient_id, waste_logs.menu_id, COALESCE(ingredients.name, menu.name, ''), waste_logs.quantity, waste_logs.unit, waste_logs.reason, waste_logs.notes, waste_logs.cost, waste_logs.actor_id, waste_logs.created_at`
+
+const wasteLogJoins = ` LEFT JOIN ingredients ON waste_logs.ingredient_id = ingredients.id LEFT JOIN menu ON waste_logs.menu_id = menu.id`
+
+func buildWasteLogFilter(filter domain.WasteLogFilter) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+
+	if filter.Reason != "" {
+		conditions = append(conditions, "waste_logs.reason = ?")
+		args = append(args, filter.Reason)
+	}
+	if filter.IngredientId != nil {
+		conditions = append(conditions, "waste_logs.ingredient_id = ?")
+		args = append(args, *filter.IngredientId)
+	}
+	if filter.MenuId != nil {
+		conditions = append(conditions, "waste_logs.menu_id = ?")
+		args = append(args, *filter.MenuId)
+	}
+	if filter.CreatedFrom != nil {
+		conditions = append(conditions, "waste_logs.created_at >= ?")
+		args = append(args, *filter.CreatedFrom)
+	}
+	if filter.CreatedTo != nil {
+		conditions = append(conditions, "waste_logs.created_at < ?")
+		args = append(args, *filter.CreatedTo)
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " WHERE " + strings.Join(conditions, " AND "), args
+}
+
+func (r *WasteLogRepositoryImpl) Create(ctx context.Context, wasteLog domain.WasteLog) error {
+	query := `INSERT INTO waste_logs (id, ingredient_id, menu_id, quantity, unit, reason, notes, cost, actor_id) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`
+	res, err := r.db.ExecContext(ctx, query, wasteLog.Id, wasteLog.IngredientId, wasteLog.MenuId, wasteLog.Quantity, wasteLog.Unit, wasteLog.Reason, wasteLog.Notes, wasteLog.Cost, wasteLog.ActorId)
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+
+	rowsAffected, _ := res.RowsAffected()
+	if rowsAffected == 0 {
+		return fmt.Errorf("No rows affected")
+	}
+	return nil
+}
+
+func (r *WasteLogRepositoryImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.WasteLog, error) {
+	query := `SELECT ` + wasteLogColumns + ` FROM waste_logs` + wasteLogJoins + ` WHERE waste_logs.id = ?`
+	wasteLog, err := scanWasteLog(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		logger.Log.Error(err)
+		return domain.WasteLog{}, err
+	}
+	return wasteLog, nil
+}
+
+func (r *WasteLogRepositoryImpl) GetAll(ctx context.Context, filter domain.WasteLogFilter) ([]domain.WasteLog, error) {
+	wasteLogs := []domain.WasteLog{}
+	where, args := buildWasteLogFilter(filter)
+	query := `SELECT ` + wasteLogColumns + ` FROM waste_logs` + wasteLogJoins + where + ` ORDER BY waste_logs.created_at DESC, waste_logs.id LIMIT ? OFFSET ?`
+	args = append(args, filter.Limit, filter.Offset)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		wasteLog, err := scanWasteLog(rows)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		wasteLogs = append(wasteLogs, wasteLog)
+	}
+	return wasteLogs, nil
+}
+
+func (r *WasteLogRepositoryImpl) Count(ctx context.Context, filter domain.WasteLogFilter) (int, error) {
+	var count int
+	where, args := buildWasteLogFilter(filter)
+	query := `SELECT COUNT(*) FROM waste_logs` + where
+	err := r.db.QueryRowContext(ctx, query, args...).Scan(&count)
+	if err != nil {
+		logger.Log.Error(err)
+		return 0, err
+	}
+	return count, nil
+}
+
+func (r *WasteLogRepositoryImpl) SumByReason(ctx context.Context, filter domain.WasteLogFilter) ([]domain.WasteSummary, error) {
+	where, args := buildWasteLogFilter(filter)
+	query := `SELECT waste_logs.reason, COUNT(*), COALESCE(SUM(waste_logs.cost), 0) FROM waste_logs` + where + ` GROUP BY waste_logs.reason ORDER BY SUM(waste_logs.cost) DESC`
+	return r.querySummaries(ctx, query, args...)
+}
+
+func (r *WasteLogRepositoryImpl) SumByPeriod(ctx context.Context, filter domain.WasteLogFilter, period string) ([]domain.WasteSummary, error) {
+	format, ok := wastePeriodFormats[period]
+	if !ok {
+		return nil, fmt.Errorf("unknown waste period %s", period)
+	}
+	where, args := buildWasteLogFilter(filter)
+	bucket := fmt.Sprintf("DATE_FORMAT(waste_logs.created_at, '%s')", format)
+	query := `SELECT ` + bucket + `, COUNT(*), COALESCE(SUM(waste_logs.cost), 0) FROM waste_logs` + where + ` GROUP BY ` + bucket + ` ORDER BY ` + bucket
+	return r.querySummaries(ctx, query, args...)
+}
+
+func (r *WasteLogRepositoryImpl) querySummaries(ctx context.Context, query string, args ...interface{}) ([]domain.WasteSummary, error) {
+	summaries := []domain.WasteSummary{}
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		logger.Log.Error(err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var summary domain.WasteSummary
+		err := rows.Scan(&summary.Key, &summary.Entries, &summary.Cost)
+		if err != nil {
+			logger.Log.Error(err)
+			return nil, err
+		}
+		summaries = append(summaries, summary)
+	}
+	return summaries, nil
+}
+
+type wasteLogScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanWasteLog(row wasteLogScanner) (domain.WasteLog, error) {
+	var wasteLog domain.WasteLog
+	var ingredientId, menuId, actorId uuid.NullUUID
+	var notes sql.NullString
+	err := row.Scan(&wasteLog.Id, &ingredientId, &menuId, &wasteLog.Name, &wasteLog.Quantity, &wasteLog.Unit, &wasteLog.Reason, &notes, &wasteLog.Cost, &actorId, &wasteLog.CreatedAt)
+	if err != nil {
+		return domain.WasteLog{}, err
+	}
+	if ingredientId.Valid {
+		wasteLog.IngredientId = &ingredientId.UUID
+	}
+	if menuId.Valid {
+		wasteLog.MenuId = &menuId.UUID
+	}
+	if notes.Valid {
+		wasteLog.Notes = &notes.String
+	}
+	if actorId.Valid {
+		wasteLog.ActorId = &actorId.UUID
+	}
+	return wasteLog, nil
+}
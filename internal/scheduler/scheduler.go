@@ -0,0 +1,8 @@
+package scheduler
+
+import "context"
+
+// Scheduler runs the background jobs of the application until ctx is done
+type Scheduler interface {
+	Start(ctx context.Context)
+}
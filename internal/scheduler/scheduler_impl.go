@@ -0,0 +1,53 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ryvasa/go-restaurant/internal/usecase"
+	"github.com/ryvasa/go-restaurant/pkg/config"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+)
+
+type SchedulerImpl struct {
+	inventoryUsecase   usecase.InventoryUsecase
+	expiredLotInterval time.Duration
+}
+
+func NewScheduler(cfg *config.Config, inventoryUsecase usecase.InventoryUsecase) Scheduler {
+	return &SchedulerImpl{
+		inventoryUsecase:   inventoryUsecase,
+		expiredLotInterval: cfg.Scheduler.ExpiredLotInterval,
+	}
+}
+
+func (s *SchedulerImpl) Start(ctx context.Context) {
+	go s.every(ctx, s.expiredLotInterval, s.wasteExpiredLots)
+}
+
+// every runs job right away and then at each interval
+func (s *SchedulerImpl) every(ctx context.Context, interval time.Duration, job func(ctx context.Context)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	job(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			job(ctx)
+		}
+	}
+}
+
+func (s *SchedulerImpl) wasteExpiredLots(ctx context.Context) {
+	lots, err := s.inventoryUsecase.WasteExpiredLots(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to waste expired lots")
+		return
+	}
+	if len(lots) > 0 {
+		logger.Log.WithField("lots", len(lots)).Info("Expired lots moved to waste")
+	}
+}
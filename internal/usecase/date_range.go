@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// parseDateRange parses an inclusive range of 2006-01-02 dates, either end
+// may be empty. The returned end is the start of the day after to.
+func parseDateRange(fromField, from, toField, to string) (*time.Time, *time.Time, error) {
+	var start, end *time.Time
+	if from != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", from, time.Local)
+		if err != nil {
+			logger.Log.WithError(err).Errorf("Error invalid %s format", fromField)
+			return nil, nil, utils.NewValidationError(utils.FieldError(fromField, "Invalid date format"))
+		}
+		start = &parsed
+	}
+	if to != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", to, time.Local)
+		if err != nil {
+			logger.Log.WithError(err).Errorf("Error invalid %s format", toField)
+			return nil, nil, utils.NewValidationError(utils.FieldError(toField, "Invalid date format"))
+		}
+		parsed = parsed.AddDate(0, 0, 1)
+		end = &parsed
+	}
+	if start != nil && end != nil && !start.Before(*end) {
+		logger.Log.WithField(fromField, from).WithField(toField, to).Error("Error invalid date range")
+		return nil, nil, utils.NewBadRequestError(fromField + " must not be after " + toField)
+	}
+	return start, end, nil
+}
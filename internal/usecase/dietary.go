@@ -0,0 +1,170 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// orderedSet drops duplicates and puts values in the order of known, values
+// must already be members of known
+func orderedSet(values, known []string) []string {
+	present := map[string]bool{}
+	for _, value := range values {
+		present[value] = true
+	}
+	result := []string{}
+	for _, value := range known {
+		if present[value] {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// normalizeIngredientDiets adds vegetarian to a vegan ingredient
+func normalizeIngredientDiets(diets []string) []string {
+	for _, diet := range diets {
+		if diet == domain.DietVegan {
+			diets = append(diets, domain.DietVegetarian)
+			break
+		}
+	}
+	return orderedSet(diets, domain.IngredientDiets)
+}
+
+// parseDietaryList splits a comma separated query value and rejects values
+// that are not in known
+func parseDietaryList(field, value string, known []string) ([]string, error) {
+	if value == "" {
+		return []string{}, nil
+	}
+	valid := map[string]bool{}
+	for _, member := range known {
+		valid[member] = true
+	}
+
+	result := []string{}
+	for _, member := range strings.Split(value, ",") {
+		member = strings.ToLower(strings.TrimSpace(member))
+		if member == "" {
+			continue
+		}
+		if !valid[member] {
+			logger.Log.WithField(field, member).Error("Error unknown dietary filter")
+			return nil, utils.NewValidationError(utils.FieldError(field, fmt.Sprintf("%s is not one of %s", member, strings.Join(known, ", "))))
+		}
+		result = append(result, member)
+	}
+	return result, nil
+}
+
+// calculateMenuDetail derives the allergens, diets and nutrition of a menu
+// item from the raw ingredients of one portion of its recipe, as returned by
+// explodeMenuPortion: it contains the allergens of any ingredient and fits a
+// diet when every ingredient does. The item is only free from an allergen,
+// and only tracked, when the allergens of every ingredient have been
+// reviewed. Ingredients and their nutrition are cached across menu items.
+func calculateMenuDetail(ctx context.Context, adapters repository.Adapters, menu domain.Menu, recipeIngredients []domain.SimpleRecipeIngredient, ingredientCache map[uuid.UUID]domain.Ingredient, nutritionCache map[uuid.UUID]*domain.IngredientNutrition) (domain.MenuDetail, error) {
+	result := domain.MenuDetail{Menu: menu, Allergens: []string{}, Diets: []string{}}
+	if len(recipeIngredients) == 0 {
+		return result, nil
+	}
+
+	allergens := []string{}
+	dietCount := map[string]int{}
+	reviewed := true
+	for _, recipeIngredient := range recipeIngredients {
+		ingredient, ok := ingredientCache[recipeIngredient.IngredientId]
+		if !ok {
+			var err error
+			ingredient, err = adapters.IngredientRepository.GetOneById(ctx, recipeIngredient.IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting ingredient")
+				return result, utils.NewInternalError("Failed to get ingredient")
+			}
+			ingredientCache[ingredient.Id] = ingredient
+		}
+		if !ingredient.AllergensReviewed {
+			reviewed = false
+		}
+		allergens = append(allergens, ingredient.Allergens...)
+		for _, diet := range ingredient.Diets {
+			dietCount[diet]++
+		}
+	}
+	result.Allergens = orderedSet(allergens, domain.Allergens)
+
+	diets := []string{}
+	for diet, count := range dietCount {
+		if count == len(recipeIngredients) {
+			diets = append(diets, diet)
+		}
+	}
+	if reviewed {
+		contains := map[string]bool{}
+		for _, allergen := range result.Allergens {
+			contains[allergen] = true
+		}
+		if !contains[domain.AllergenGluten] {
+			diets = append(diets, domain.DietGlutenFree)
+		}
+		if !contains[domain.AllergenMilk] {
+			diets = append(diets, domain.DietDairyFree)
+		}
+		if !contains[domain.AllergenPeanuts] && !contains[domain.AllergenTreeNuts] {
+			diets = append(diets, domain.DietNutFree)
+		}
+	}
+	result.Diets = orderedSet(diets, domain.MenuDiets)
+	result.DietaryTracked = reviewed
+
+	nutrition, missing, err := sumIngredientNutrition(ctx, adapters, recipeIngredients, nutritionCache)
+	if err != nil {
+		return result, err
+	}
+	result.Nutrition = &domain.PortionNutrition{
+		Nutrition: nutrition,
+		Complete:  len(missing) == 0,
+		Missing:   missing,
+	}
+	return result, nil
+}
+
+// menuMatchesDietary reports whether a menu item contains none of the
+// excluded allergens and fits every diet, untracked items never match a
+// filter
+func menuMatchesDietary(menu domain.MenuDetail, excludeAllergens, diets []string) bool {
+	if len(excludeAllergens) == 0 && len(diets) == 0 {
+		return true
+	}
+	if !menu.DietaryTracked {
+		return false
+	}
+	for _, allergen := range excludeAllergens {
+		for _, contained := range menu.Allergens {
+			if allergen == contained {
+				return false
+			}
+		}
+	}
+	for _, diet := range diets {
+		fits := false
+		for _, menuDiet := range menu.Diets {
+			if diet == menuDiet {
+				fits = true
+				break
+			}
+		}
+		if !fits {
+			return false
+		}
+	}
+	return true
+}
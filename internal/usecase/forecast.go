@@ -0,0 +1,150 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+// The forecast helpers only work on data already read from the repositories
+// so the projection can be checked without a database.
+
+// dailySalesSeries lays the sales of every menu out as one value per day
+// from historyStart, days without sales are zero
+func dailySalesSeries(sales []domain.MenuDailySales, historyStart time.Time, historyDays int) ([]domain.MenuForecast, map[uuid.UUID][]float64) {
+	menus := []domain.MenuForecast{}
+	series := map[uuid.UUID][]float64{}
+	for _, sale := range sales {
+		day := daysBetween(historyStart, sale.Date)
+		if day < 0 || day >= historyDays {
+			continue
+		}
+		if _, ok := series[sale.MenuId]; !ok {
+			series[sale.MenuId] = make([]float64, historyDays)
+			menus = append(menus, domain.MenuForecast{MenuId: sale.MenuId, MenuName: sale.MenuName})
+		}
+		series[sale.MenuId][day] += float64(sale.Quantity)
+	}
+	return menus, series
+}
+
+// daysBetween counts whole calendar days from one date to another, rounding
+// absorbs daylight saving shifts
+func daysBetween(from, to time.Time) int {
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local)
+	return int(math.Round(to.Sub(from).Hours() / 24))
+}
+
+// forecastDailyDemand projects horizon days from start out of a history that
+// ends the day before start. The level is the average of the last window
+// days, each weekday is scaled by how its average compares with the average
+// of the whole history.
+func forecastDailyDemand(history []float64, start time.Time, window, horizon int) []float64 {
+	forecast := make([]float64, horizon)
+	if len(history) == 0 {
+		return forecast
+	}
+	if window <= 0 || window > len(history) {
+		window = len(history)
+	}
+
+	var total, level float64
+	var weekdayTotal [7]float64
+	var weekdayDays [7]int
+	for i, quantity := range history {
+		weekday := start.AddDate(0, 0, i-len(history)).Weekday()
+		weekdayTotal[weekday] += quantity
+		weekdayDays[weekday]++
+		total += quantity
+		if i >= len(history)-window {
+			level += quantity
+		}
+	}
+	mean := total / float64(len(history))
+	level /= float64(window)
+
+	for day := range forecast {
+		weekday := start.AddDate(0, 0, day).Weekday()
+		seasonality := 1.0
+		if mean > 0 && weekdayDays[weekday] > 0 {
+			seasonality = weekdayTotal[weekday] / float64(weekdayDays[weekday]) / mean
+		}
+		forecast[day] = level * seasonality
+	}
+	return forecast
+}
+
+// explodeMenuForecasts turns projected portions into projected ingredient
+// usage per day through the recipes, menus without a recipe use nothing
+func explodeMenuForecasts(menus []domain.MenuForecast, recipes map[uuid.UUID][]domain.SimpleRecipeIngredient, horizon int) ([]domain.IngredientForecast, map[uuid.UUID][]float64) {
+	ingredients := []domain.IngredientForecast{}
+	daily := map[uuid.UUID][]float64{}
+	for _, menu := range menus {
+		for _, recipeIngredient := range recipes[menu.MenuId] {
+			if _, ok := daily[recipeIngredient.IngredientId]; !ok {
+				daily[recipeIngredient.IngredientId] = make([]float64, horizon)
+				ingredients = append(ingredients, domain.IngredientForecast{
+					IngredientId: recipeIngredient.IngredientId,
+					Name:         recipeIngredient.Name,
+					Unit:         recipeIngredient.BaseUnit,
+				})
+			}
+			for day, portions := range menu.Daily {
+				daily[recipeIngredient.IngredientId][day] += portions * recipeIngredient.BaseQuantity
+			}
+		}
+	}
+	for i := range ingredients {
+		for _, quantity := range daily[ingredients[i].IngredientId] {
+			ingredients[i].ProjectedUsage += quantity
+		}
+	}
+	return ingredients, daily
+}
+
+// planIngredientOrder sets the day the stock on hand runs out and the
+// quantity to order so the stock on hand and on order still covers the
+// reorder point once the projected usage is taken out
+func planIngredientOrder(forecast *domain.IngredientForecast, daily []float64, start time.Time) {
+	remaining := forecast.OnHand
+	for day, quantity := range daily {
+		remaining -= quantity
+		if remaining < -stockTolerance {
+			runsOutOn := start.AddDate(0, 0, day)
+			forecast.RunsOutOn = &runsOutOn
+			break
+		}
+	}
+
+	shortfall := forecast.ProjectedUsage + forecast.ReorderPoint - forecast.OnHand - forecast.OnOrder
+	if shortfall <= stockTolerance {
+		forecast.SuggestedOrder = 0
+		return
+	}
+	if forecast.ReorderQuantity > 0 {
+		shortfall = math.Ceil(shortfall/forecast.ReorderQuantity) * forecast.ReorderQuantity
+	}
+	forecast.SuggestedOrder = shortfall
+}
+
+// sortIngredientForecasts puts the ingredients that run out first on top,
+// then the ones with the largest suggested order
+func sortIngredientForecasts(ingredients []domain.IngredientForecast) {
+	sort.SliceStable(ingredients, func(i, j int) bool {
+		a, b := ingredients[i], ingredients[j]
+		if (a.RunsOutOn == nil) != (b.RunsOutOn == nil) {
+			return a.RunsOutOn != nil
+		}
+		if a.RunsOutOn != nil && !a.RunsOutOn.Equal(*b.RunsOutOn) {
+			return a.RunsOutOn.Before(*b.RunsOutOn)
+		}
+		if a.SuggestedOrder != b.SuggestedOrder {
+			return a.SuggestedOrder > b.SuggestedOrder
+		}
+		return a.Name < b.Name
+	})
+}
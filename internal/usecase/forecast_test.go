@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"math"
+	"testing"
+	"time"
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+)
+
+func assertQuantities(t *testing.T, got, want []float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("got %d days, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if math.Abs(got[i]-want[i]) > 1e-9 {
+			t.Errorf("day %d: got %g, want %g", i, got[i], want[i])
+		}
+	}
+}
+
+func TestForecastDailyDemandEmptyHistory(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	assertQuantities(t, forecastDailyDemand(nil, start, 7, 3), []float64{0, 0, 0})
+}
+
+func TestForecastDailyDemandWeekdaySeasonality(t *testing.T) {
+	// two weeks from Monday 1 January, weekends sell twice as much
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	history := []float64{10, 10, 10, 10, 10, 20, 20, 10, 10, 10, 10, 10, 20, 20}
+
+	forecast := forecastDailyDemand(history, start, 14, 7)
+	assertQuantities(t, forecast, []float64{10, 10, 10, 10, 10, 20, 20})
+}
+
+func TestForecastDailyDemandClampsWindowToHistory(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	history := []float64{4, 4, 4}
+
+	for _, window := range []int{0, 3, 30} {
+		forecast := forecastDailyDemand(history, start, window, 2)
+		assertQuantities(t, forecast, []float64{4, 4})
+	}
+}
+
+func TestDailySalesSeriesSkipsDaysOutsideHistory(t *testing.T) {
+	historyStart := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)
+	menuId, otherMenuId := uuid.New(), uuid.New()
+	sales := []domain.MenuDailySales{
+		{MenuId: otherMenuId, MenuName: "Before", Date: historyStart.AddDate(0, 0, -1), Quantity: 5},
+		{MenuId: menuId, MenuName: "Soup", Date: historyStart.AddDate(0, 0, 2), Quantity: 2},
+		{MenuId: menuId, MenuName: "Soup", Date: historyStart.AddDate(0, 0, 2), Quantity: 1},
+		{MenuId: menuId, MenuName: "Soup", Date: historyStart.AddDate(0, 0, 7), Quantity: 9},
+		{MenuId: otherMenuId, MenuName: "After", Date: historyStart.AddDate(0, 0, 8), Quantity: 5},
+	}
+
+	menus, series := dailySalesSeries(sales, historyStart, 7)
+	if len(menus) != 1 || menus[0].MenuId != menuId {
+		t.Fatalf("got menus %+v, want only %s", menus, menuId)
+	}
+	if _, ok := series[otherMenuId]; ok {
+		t.Errorf("menu sold only outside the history has a series")
+	}
+	assertQuantities(t, series[menuId], []float64{0, 0, 3, 0, 0, 0, 0})
+}
+
+func TestPlanIngredientOrder(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	forecast := domain.IngredientForecast{
+		ProjectedUsage:  12,
+		OnHand:          10,
+		ReorderPoint:    5,
+		ReorderQuantity: 5,
+	}
+
+	planIngredientOrder(&forecast, []float64{4, 4, 4}, start)
+	if forecast.RunsOutOn == nil || !forecast.RunsOutOn.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("got runs out on %v, want %v", forecast.RunsOutOn, start.AddDate(0, 0, 2))
+	}
+	// 7 short, rounded up to whole reorder quantities
+	if forecast.SuggestedOrder != 10 {
+		t.Errorf("got suggested order %g, want 10", forecast.SuggestedOrder)
+	}
+}
+
+func TestPlanIngredientOrderCoveredStock(t *testing.T) {
+	start := time.Date(2024, 1, 15, 0, 0, 0, 0, time.Local)
+	forecast := domain.IngredientForecast{
+		ProjectedUsage:  12,
+		OnHand:          10,
+		OnOrder:         10,
+		ReorderPoint:    5,
+		ReorderQuantity: 5,
+	}
+
+	planIngredientOrder(&forecast, []float64{4, 4, 4}, start)
+	if forecast.SuggestedOrder != 0 {
+		t.Errorf("got suggested order %g, want 0", forecast.SuggestedOrder)
+	}
+}
+
+func TestDaysBetweenAcrossDaylightSaving(t *testing.T) {
+	location, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := time.Local
+	time.Local = location
+	t.Cleanup(func() { time.Local = local })
+
+	tests := []struct {
+		from, to time.Time
+		want     int
+	}{
+		// 10 March 2024 is 23 hours long, 3 November 2024 is 25 hours long
+		{time.Date(2024, 3, 9, 0, 0, 0, 0, location), time.Date(2024, 3, 11, 0, 0, 0, 0, location), 2},
+		{time.Date(2024, 11, 2, 0, 0, 0, 0, location), time.Date(2024, 11, 4, 0, 0, 0, 0, location), 2},
+		{time.Date(2024, 3, 11, 0, 0, 0, 0, location), time.Date(2024, 3, 9, 0, 0, 0, 0, location), -2},
+	}
+	for _, test := range tests {
+		if got := daysBetween(test.from, test.to); got != test.want {
+			t.Errorf("daysBetween(%v, %v) = %d, want %d", test.from, test.to, got, test.want)
+		}
+	}
+}
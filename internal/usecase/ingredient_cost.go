@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// changeIngredientCost sets the cost of one base unit of an ingredient and
+// keeps the previous cost in the history. An unchanged cost is not recorded.
+func changeIngredientCost(ctx context.Context, adapters repository.Adapters, ingredient domain.Ingredient, costPerUnit float64, source string, referenceId, actorId *uuid.UUID) error {
+	if costPerUnit == ingredient.CostPerUnit {
+		return nil
+	}
+
+	err := adapters.IngredientRepository.UpdateCost(ctx, ingredient.Id, costPerUnit)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update ingredient cost")
+		return utils.NewInternalError("Failed to update ingredient cost")
+	}
+
+	history := domain.IngredientCostHistory{
+		Id:                  uuid.New(),
+		IngredientId:        ingredient.Id,
+		CostPerUnit:         costPerUnit,
+		PreviousCostPerUnit: ingredient.CostPerUnit,
+		Source:              source,
+		ReferenceId:         referenceId,
+		ActorId:             actorId,
+	}
+	err = adapters.IngredientCostHistoryRepository.Create(ctx, history)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to record ingredient cost")
+		return utils.NewInternalError("Failed to record ingredient cost")
+	}
+	return nil
+}
+
+// calculateRecipeCost prices one batch of a recipe, sub-recipes are priced
+// through their own ingredients. Without at the version in use now is priced
+// at the current costs, with at the version and costs of that moment.
+func calculateRecipeCost(ctx context.Context, adapters repository.Adapters, recipe domain.Recipe, at *time.Time) (domain.RecipeCost, error) {
+	recipeCost := domain.RecipeCost{
+		RecipeId:    recipe.Id,
+		MenuId:      recipe.MenuId,
+		Name:        recipe.Name,
+		At:          at,
+		Ingredients: []domain.RecipeIngredientCost{},
+		Uncosted:    []string{},
+	}
+
+	pricedAt := time.Now()
+	if at != nil {
+		pricedAt = *at
+	}
+	version, found, err := getActiveRecipeVersion(ctx, adapters, recipe.Id, pricedAt)
+	if err != nil || !found {
+		return recipeCost, err
+	}
+	recipeCost.VersionId = &version.Id
+	recipeCost.Version = version.Version
+	recipeCost.YieldQuantity = version.YieldQuantity
+	recipeCost.YieldUnit = version.YieldUnit
+
+	recipeIngredients, err := explodeRecipeVersion(ctx, adapters, version, pricedAt)
+	if err != nil {
+		return domain.RecipeCost{}, err
+	}
+
+	for _, recipeIngredient := range recipeIngredients {
+		if at != nil {
+			recipeIngredient.CostPerUnit, err = ingredientCostAt(ctx, adapters, recipeIngredient.IngredientId, recipeIngredient.CostPerUnit, *at)
+			if err != nil {
+				return domain.RecipeCost{}, err
+			}
+		}
+		cost := recipeIngredient.BaseQuantity * recipeIngredient.CostPerUnit
+		if recipeIngredient.CostPerUnit == 0 {
+			recipeCost.Uncosted = append(recipeCost.Uncosted, recipeIngredient.Name)
+		}
+		recipeCost.Ingredients = append(recipeCost.Ingredients, domain.RecipeIngredientCost{
+			IngredientId: recipeIngredient.IngredientId,
+			Name:         recipeIngredient.Name,
+			BaseQuantity: recipeIngredient.BaseQuantity,
+			BaseUnit:     recipeIngredient.BaseUnit,
+			UnitCost:     recipeIngredient.CostPerUnit,
+			Cost:         cost,
+		})
+		recipeCost.Total += cost
+	}
+	if recipeCost.YieldQuantity > 0 {
+		recipeCost.UnitCost = recipeCost.Total / recipeCost.YieldQuantity
+	}
+	return recipeCost, nil
+}
+
+// ingredientCostAt returns what one base unit of an ingredient cost at a
+// moment, the cost before the first change after it or the current cost
+func ingredientCostAt(ctx context.Context, adapters repository.Adapters, ingredientId uuid.UUID, currentCost float64, at time.Time) (float64, error) {
+	history, err := adapters.IngredientCostHistoryRepository.GetFirstAfter(ctx, ingredientId, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return currentCost, nil
+	}
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting ingredient cost history")
+		return 0, utils.NewInternalError("Failed to get ingredient cost history")
+	}
+	return history.PreviousCostPerUnit, nil
+}
+
+// calculateMenuMargin compares the food cost of a menu item with its price.
+// Menu items without a recipe have no tracked cost and are reported as is.
+func calculateMenuMargin(ctx context.Context, adapters repository.Adapters, menu domain.Menu, targetMarginPercent float64) (domain.MenuMargin, error) {
+	margin := domain.MenuMargin{
+		MenuId:   menu.Id,
+		Name:     menu.Name,
+		Category: menu.Category,
+		Price:    menu.Price.Float64(),
+		Uncosted: []string{},
+	}
+
+	recipe, err := adapters.RecipeRepository.GetOneByMenuId(ctx, menu.Id)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logger.Log.WithError(err).Error("Error getting Recipe")
+		return domain.MenuMargin{}, utils.NewInternalError("Failed to get recipe")
+	}
+	if err == nil {
+		recipeCost, err := calculateRecipeCost(ctx, adapters, recipe, nil)
+		if err != nil {
+			return domain.MenuMargin{}, err
+		}
+		margin.RecipeId = &recipe.Id
+		margin.FoodCost = recipeCost.UnitCost
+		margin.Uncosted = recipeCost.Uncosted
+	}
+
+	margin.GrossMargin = margin.Price - margin.FoodCost
+	if margin.Price > 0 {
+		margin.MarginPercent = margin.GrossMargin / margin.Price * 100
+		margin.FoodCostPercent = margin.FoodCost / margin.Price * 100
+	}
+	margin.BelowTarget = margin.MarginPercent < targetMarginPercent
+	return margin, nil
+}
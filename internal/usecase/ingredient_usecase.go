@@ -13,4 +13,12 @@ type IngredientUsecase interface {
 	Update(ctx context.Context, id uuid.UUID, req dto.UpdateIngredientRequest) (domain.Ingredient, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) (domain.Ingredient, error)
+	GetConversions(ctx context.Context, id uuid.UUID) ([]domain.IngredientUnitConversion, error)
+	CreateConversion(ctx context.Context, id uuid.UUID, req dto.CreateIngredientUnitConversionRequest) (domain.IngredientUnitConversion, error)
+	DeleteConversion(ctx context.Context, id, conversionId uuid.UUID) error
+	UpdateCost(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateIngredientCostRequest) (domain.Ingredient, error)
+	GetCostHistory(ctx context.Context, id uuid.UUID) ([]domain.IngredientCostHistory, error)
+	GetNutrition(ctx context.Context, id uuid.UUID) (domain.IngredientNutrition, error)
+	UpdateNutrition(ctx context.Context, id uuid.UUID, req dto.UpdateIngredientNutritionRequest) (domain.IngredientNutrition, error)
+	DeleteNutrition(ctx context.Context, id uuid.UUID) error
 }
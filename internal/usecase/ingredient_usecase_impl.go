@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -12,16 +13,20 @@ import (
 )
 
 type IngredientUsecaseImpl struct {
-	ingredientRepo repository.IngredientRepository
-	recipeRepo     repository.RecipeRepository
-	txRepo         repository.TransactionRepository
+	ingredientRepo               repository.IngredientRepository
+	recipeRepo                   repository.RecipeRepository
+	ingredientUnitConversionRepo repository.IngredientUnitConversionRepository
+	ingredientCostHistoryRepo    repository.IngredientCostHistoryRepository
+	txRepo                       repository.TransactionRepository
 }
 
-func NewIngredientUsecase(ingredientRepo repository.IngredientRepository, recipeRepo repository.RecipeRepository, txRepo repository.TransactionRepository) IngredientUsecase {
+func NewIngredientUsecase(ingredientRepo repository.IngredientRepository, recipeRepo repository.RecipeRepository, ingredientUnitConversionRepo repository.IngredientUnitConversionRepository, ingredientCostHistoryRepo repository.IngredientCostHistoryRepository, txRepo repository.TransactionRepository) IngredientUsecase {
 	return &IngredientUsecaseImpl{
-		ingredientRepo: ingredientRepo,
-		recipeRepo:     recipeRepo,
-		txRepo:         txRepo,
+		ingredientRepo:               ingredientRepo,
+		recipeRepo:                   recipeRepo,
+		ingredientUnitConversionRepo: ingredientUnitConversionRepo,
+		ingredientCostHistoryRepo:    ingredientCostHistoryRepo,
+		txRepo:                       txRepo,
 	}
 }
 func (u *IngredientUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Ingredient, error) {
@@ -36,6 +41,10 @@ func (u *IngredientUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (d
 func (u *IngredientUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateIngredientRequest) (domain.Ingredient, error) {
 	result := domain.Ingredient{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
 		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to get ingredient")
@@ -47,6 +56,19 @@ func (u *IngredientUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dt
 		if req.Description != "" {
 			ingredient.Description = req.Description
 		}
+		if req.ReorderPoint != nil {
+			ingredient.ReorderPoint = *req.ReorderPoint
+		}
+		if req.ReorderQuantity != nil {
+			ingredient.ReorderQuantity = *req.ReorderQuantity
+		}
+		if req.Allergens != nil {
+			ingredient.Allergens = orderedSet(req.Allergens, domain.Allergens)
+			ingredient.AllergensReviewed = true
+		}
+		if req.Diets != nil {
+			ingredient.Diets = normalizeIngredientDiets(req.Diets)
+		}
 		err = adapters.IngredientRepository.Update(ctx, id, ingredient)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to update ingredient")
@@ -111,3 +133,257 @@ func (u *IngredientUsecaseImpl) Restore(ctx context.Context, id uuid.UUID) (doma
 	}
 	return result, nil
 }
+
+func (u *IngredientUsecaseImpl) GetConversions(ctx context.Context, id uuid.UUID) ([]domain.IngredientUnitConversion, error) {
+	_, err := u.ingredientRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient")
+		return nil, utils.NewNotFoundError("Ingredient not found")
+	}
+
+	conversions, err := u.ingredientUnitConversionRepo.GetAllByIngredientId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get unit conversions")
+		return nil, utils.NewInternalError("Failed to get unit conversions")
+	}
+	return conversions, nil
+}
+
+func (u *IngredientUsecaseImpl) CreateConversion(ctx context.Context, id uuid.UUID, req dto.CreateIngredientUnitConversionRequest) (domain.IngredientUnitConversion, error) {
+	result := domain.IngredientUnitConversion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		// a unit that already converts to the base unit would be ambiguous
+		unit := utils.NormalizeUnit(req.Unit)
+		if _, ok := utils.ConvertUnit(1, unit, ingredient.BaseUnit); ok {
+			logger.Log.WithField("unit", unit).Error("Error unit already convertible")
+			return utils.NewValidationError(utils.FieldError("unit", fmt.Sprintf("Unit %s already converts to %s", unit, ingredient.BaseUnit)))
+		}
+
+		_, err = adapters.IngredientUnitConversionRepository.GetOneByIngredientIdAndUnit(ctx, id, unit)
+		if err == nil {
+			logger.Log.WithField("unit", unit).Error("Error unit conversion already exists")
+			return utils.NewConflictError("Unit conversion already exists")
+		}
+
+		conversion := domain.IngredientUnitConversion{
+			Id:           uuid.New(),
+			IngredientId: id,
+			Unit:         unit,
+			Factor:       req.Factor,
+		}
+		err = adapters.IngredientUnitConversionRepository.Create(ctx, conversion)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create unit conversion")
+			return utils.NewInternalError("Failed to create unit conversion")
+		}
+
+		createdConversion, err := adapters.IngredientUnitConversionRepository.GetOneById(ctx, conversion.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get unit conversion")
+			return utils.NewInternalError("Failed to get unit conversion")
+		}
+		result = createdConversion
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create unit conversion")
+		return domain.IngredientUnitConversion{}, err
+	}
+	return result, nil
+}
+
+func (u *IngredientUsecaseImpl) DeleteConversion(ctx context.Context, id, conversionId uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		conversion, err := adapters.IngredientUnitConversionRepository.GetOneById(ctx, conversionId)
+		if err != nil || conversion.IngredientId != id {
+			logger.Log.WithError(err).Error("Error failed to get unit conversion")
+			return utils.NewNotFoundError("Unit conversion not found")
+		}
+
+		// recipe lines entered in this unit could no longer be converted
+		count, err := adapters.RecipeIngredientRepository.CountByIngredientIdAndUnit(ctx, id, conversion.Unit)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to count recipe ingredients")
+			return utils.NewInternalError("Failed to check recipe ingredients")
+		}
+		if count > 0 {
+			logger.Log.WithField("unit", conversion.Unit).Error("Error unit conversion in use")
+			return utils.NewConflictError(fmt.Sprintf("Unit %s is used by %d recipe ingredient(s)", conversion.Unit, count))
+		}
+
+		err = adapters.IngredientUnitConversionRepository.Delete(ctx, conversionId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete unit conversion")
+			return utils.NewInternalError("Failed to delete unit conversion")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete unit conversion")
+		return err
+	}
+	return nil
+}
+
+func (u *IngredientUsecaseImpl) UpdateCost(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateIngredientCostRequest) (domain.Ingredient, error) {
+	result := domain.Ingredient{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		costPerUnit, err := toBaseUnitCost(ctx, adapters, ingredient, *req.CostPerUnit, req.Unit)
+		if err != nil {
+			return err
+		}
+		err = changeIngredientCost(ctx, adapters, ingredient, costPerUnit, domain.CostSourceManual, nil, &actorId)
+		if err != nil {
+			return err
+		}
+
+		updatedIngredient, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+		result = updatedIngredient
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update ingredient cost")
+		return domain.Ingredient{}, err
+	}
+	return result, nil
+}
+
+func (u *IngredientUsecaseImpl) GetCostHistory(ctx context.Context, id uuid.UUID) ([]domain.IngredientCostHistory, error) {
+	_, err := u.ingredientRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient")
+		return nil, utils.NewNotFoundError("Ingredient not found")
+	}
+
+	histories, err := u.ingredientCostHistoryRepo.GetAllByIngredientId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient cost history")
+		return nil, utils.NewInternalError("Failed to get ingredient cost history")
+	}
+	return histories, nil
+}
+
+func (u *IngredientUsecaseImpl) GetNutrition(ctx context.Context, id uuid.UUID) (domain.IngredientNutrition, error) {
+	result := domain.IngredientNutrition{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		_, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		nutrition, err := adapters.IngredientNutritionRepository.GetOneByIngredientId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient nutrition")
+			return utils.NewNotFoundError("Ingredient has no nutrition data")
+		}
+		result = nutrition
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get ingredient nutrition")
+		return domain.IngredientNutrition{}, err
+	}
+	return result, nil
+}
+
+// UpdateNutrition stores the nutrition of an ingredient per base unit
+func (u *IngredientUsecaseImpl) UpdateNutrition(ctx context.Context, id uuid.UUID, req dto.UpdateIngredientNutritionRequest) (domain.IngredientNutrition, error) {
+	result := domain.IngredientNutrition{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		quantity := req.Quantity
+		if quantity == 0 {
+			quantity = 1
+		}
+		baseQuantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, quantity, req.Unit)
+		if err != nil {
+			return err
+		}
+
+		nutrition := domain.IngredientNutrition{IngredientId: ingredient.Id}
+		addNutrition(&nutrition.Nutrition, domain.Nutrition{
+			Kcal:    *req.Kcal,
+			Protein: *req.Protein,
+			Fat:     *req.Fat,
+			Carbs:   *req.Carbs,
+			Sodium:  *req.Sodium,
+		}, 1/baseQuantity)
+		err = adapters.IngredientNutritionRepository.Upsert(ctx, nutrition)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update ingredient nutrition")
+			return utils.NewInternalError("Failed to update ingredient nutrition")
+		}
+
+		updatedNutrition, err := adapters.IngredientNutritionRepository.GetOneByIngredientId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient nutrition")
+			return utils.NewInternalError("Failed to get ingredient nutrition")
+		}
+		result = updatedNutrition
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update ingredient nutrition")
+		return domain.IngredientNutrition{}, err
+	}
+	return result, nil
+}
+
+func (u *IngredientUsecaseImpl) DeleteNutrition(ctx context.Context, id uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		_, err := adapters.IngredientRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get ingredient")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		err = adapters.IngredientNutritionRepository.DeleteByIngredientId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete ingredient nutrition")
+			return utils.NewNotFoundError("Ingredient has no nutrition data")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete ingredient nutrition")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,749 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// ingredientUsage is the total quantity of one ingredient needed by a set of
+// order items, expressed in the base unit of the ingredient
+type ingredientUsage struct {
+	IngredientId uuid.UUID
+	Name         string
+	Quantity     float64
+	Unit         string
+}
+
+// calculateOrderIngredientUsage explodes order items through the recipes and
+// sub-recipes in use when the order was placed. Menu items without a recipe are not tracked in stock and are skipped.
+func calculateOrderIngredientUsage(ctx context.Context, adapters repository.Adapters, items []domain.OrderMenu, placedAt time.Time) ([]ingredientUsage, error) {
+	usages := []ingredientUsage{}
+	index := map[uuid.UUID]int{}
+
+	for _, item := range items {
+		recipe, err := adapters.RecipeRepository.GetOneByMenuId(ctx, item.MenuId)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting Recipe")
+			return nil, utils.NewInternalError("Failed to get recipe")
+		}
+
+		recipeIngredients, err := explodeRecipePortion(ctx, adapters, recipe.Id, placedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, recipeIngredient := range recipeIngredients {
+			quantity := recipeIngredient.BaseQuantity * float64(item.Quantity)
+			if i, ok := index[recipeIngredient.IngredientId]; ok {
+				usages[i].Quantity += quantity
+				continue
+			}
+			index[recipeIngredient.IngredientId] = len(usages)
+			usages = append(usages, ingredientUsage{
+				IngredientId: recipeIngredient.IngredientId,
+				Name:         recipeIngredient.Name,
+				Quantity:     quantity,
+				Unit:         recipeIngredient.BaseUnit,
+			})
+		}
+	}
+
+	return usages, nil
+}
+
+// getStorageLocation returns the location stock is booked at, nil means the
+// default location
+func getStorageLocation(ctx context.Context, adapters repository.Adapters, locationId *uuid.UUID) (domain.StorageLocation, error) {
+	if locationId == nil {
+		location, err := adapters.StorageLocationRepository.GetDefault(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting default storage location")
+			return domain.StorageLocation{}, utils.NewInternalError("No default storage location")
+		}
+		return location, nil
+	}
+
+	location, err := adapters.StorageLocationRepository.GetOneById(ctx, *locationId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error storage location not found")
+		return domain.StorageLocation{}, utils.NewNotFoundError("Storage location not found")
+	}
+	return location, nil
+}
+
+// availableStock returns the inventories an ingredient is drawn from and
+// their total quantity. Without a location these are the locations feeding
+// the kitchen. Paths about to take stock pass forUpdate so the inventories
+// stay locked until the transaction ends and concurrent draws can't both
+// pass the check.
+func availableStock(ctx context.Context, adapters repository.Adapters, ingredientId uuid.UUID, locationId *uuid.UUID, forUpdate bool) ([]domain.Inventory, float64, error) {
+	inventories := []domain.Inventory{}
+	if locationId == nil {
+		getKitchen := adapters.InventoryRepository.GetKitchenByIngredientId
+		if forUpdate {
+			getKitchen = adapters.InventoryRepository.GetKitchenByIngredientIdForUpdate
+		}
+		kitchenInventories, err := getKitchen(ctx, ingredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting Inventory")
+			return nil, 0, utils.NewInternalError("Failed to get inventory")
+		}
+		inventories = kitchenInventories
+	} else {
+		getOne := adapters.InventoryRepository.GetOneByIngredientIdAndLocationId
+		if forUpdate {
+			getOne = adapters.InventoryRepository.GetOneByIngredientIdAndLocationIdForUpdate
+		}
+		inventory, err := getOne(ctx, ingredientId, *locationId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			logger.Log.WithError(err).Error("Error getting Inventory")
+			return nil, 0, utils.NewInternalError("Failed to get inventory")
+		}
+		if err == nil {
+			inventories = append(inventories, inventory)
+		}
+	}
+
+	var total float64
+	for _, inventory := range inventories {
+		total += inventory.Quantity
+	}
+	return inventories, total, nil
+}
+
+// checkIngredientStock rejects usages that would push any ingredient below
+// zero at the location, or in the kitchen when locationId is nil. The
+// inventories checked stay locked until the transaction ends, they are locked
+// in ingredient order so two checks can't deadlock on each other.
+func checkIngredientStock(ctx context.Context, adapters repository.Adapters, usages []ingredientUsage, locationId *uuid.UUID) error {
+	sorted := append([]ingredientUsage{}, usages...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].IngredientId.String() < sorted[j].IngredientId.String()
+	})
+
+	missing := []domain.MissingIngredient{}
+	for _, usage := range sorted {
+		_, available, err := availableStock(ctx, adapters, usage.IngredientId, locationId, true)
+		if err != nil {
+			return err
+		}
+
+		if available < usage.Quantity {
+			missing = append(missing, domain.MissingIngredient{
+				IngredientId: usage.IngredientId,
+				Name:         usage.Name,
+				Required:     usage.Quantity,
+				Available:    available,
+				Missing:      usage.Quantity - available,
+				Unit:         usage.Unit,
+			})
+		}
+	}
+
+	if len(missing) > 0 {
+		logger.Log.WithField("missing_ingredients", missing).Error("Error insufficient ingredient stock")
+		return utils.NewConflictErrorWithDetails(fmt.Sprintf("Insufficient stock for %d ingredient(s)", len(missing)), missing)
+	}
+	return nil
+}
+
+// stockAlerts collects reorder point crossings while a transaction runs, they
+// are delivered with notify once the transaction has committed
+type stockAlerts struct {
+	alerts []domain.StockAlert
+}
+
+// check compares the stock before and after a movement with the reorder point
+// of the ingredient
+func (a *stockAlerts) check(ctx context.Context, adapters repository.Adapters, inventory domain.Inventory, quantity float64) error {
+	// transfers do not change the total stock and pass no alerts
+	if a == nil {
+		return nil
+	}
+
+	ingredient, err := adapters.IngredientRepository.GetOneById(ctx, inventory.IngredientId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting ingredient")
+		return utils.NewInternalError("Failed to get ingredient")
+	}
+	if ingredient.ReorderPoint <= 0 {
+		return nil
+	}
+
+	previous, err := adapters.InventoryRepository.SumQuantityByIngredientId(ctx, inventory.IngredientId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting ingredient stock")
+		return utils.NewInternalError("Failed to get ingredient stock")
+	}
+	current := previous + quantity
+	var alertType string
+	switch {
+	case previous > ingredient.ReorderPoint && current <= ingredient.ReorderPoint:
+		alertType = domain.StockAlertLowStock
+	case previous <= ingredient.ReorderPoint && current > ingredient.ReorderPoint:
+		alertType = domain.StockAlertRestocked
+	default:
+		return nil
+	}
+
+	a.alerts = append(a.alerts, domain.StockAlert{
+		Type:             alertType,
+		IngredientId:     ingredient.Id,
+		InventoryId:      inventory.Id,
+		Name:             ingredient.Name,
+		Unit:             ingredient.BaseUnit,
+		PreviousQuantity: previous,
+		Quantity:         current,
+		ReorderPoint:     ingredient.ReorderPoint,
+		ReorderQuantity:  ingredient.ReorderQuantity,
+		CreatedAt:        time.Now(),
+	})
+	return nil
+}
+
+// notify logs every collected alert and hands it to the notifier, delivery
+// failures are logged and never undo the committed stock change
+func (a *stockAlerts) notify(ctx context.Context, stockAlertNotifier notifier.StockAlertNotifier) {
+	for _, alert := range a.alerts {
+		logger.Log.WithField("alert", alert).Warn("Stock alert")
+		if err := stockAlertNotifier.Notify(ctx, alert); err != nil {
+			logger.Log.WithError(err).Error("Error failed to deliver stock alert")
+		}
+	}
+	a.alerts = nil
+}
+
+// stockTolerance absorbs float rounding when drawing down lots
+const stockTolerance = 0.0001
+
+// stockLot describes the lot created by a positive movement, the zero value
+// is a lot without lot number that does not expire
+type stockLot struct {
+	LotNumber string
+	ExpiresAt *time.Time
+}
+
+// recordInventoryMovement changes the stock of an inventory and appends the
+// matching entry to the ledger, quantity is signed. Positive movements add a
+// lot, negative movements draw down the lots that expire first.
+func recordInventoryMovement(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, inventory domain.Inventory, movementType string, quantity float64, lot stockLot, reason string, referenceId, actorId *uuid.UUID) error {
+	var err error
+	switch {
+	case quantity > 0:
+		err = addInventoryLot(ctx, adapters, inventory, quantity, lot, referenceId)
+	case quantity < 0:
+		_, err = consumeInventoryLots(ctx, adapters, inventory, -quantity, referenceId)
+	}
+	if err != nil {
+		return err
+	}
+	return appendInventoryMovement(ctx, adapters, alerts, inventory, movementType, quantity, reason, referenceId, actorId)
+}
+
+// appendInventoryMovement changes the inventory quantity and writes the
+// ledger entry, the lots must already reflect the change
+func appendInventoryMovement(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, inventory domain.Inventory, movementType string, quantity float64, reason string, referenceId, actorId *uuid.UUID) error {
+	if err := alerts.check(ctx, adapters, inventory, quantity); err != nil {
+		return err
+	}
+
+	err := adapters.InventoryRepository.AdjustQuantity(ctx, inventory.Id, quantity)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error updating inventory")
+		return utils.NewInternalError("Failed to update inventory")
+	}
+
+	movement := domain.InventoryMovement{
+		Id:           uuid.New(),
+		InventoryId:  inventory.Id,
+		IngredientId: inventory.IngredientId,
+		Type:         movementType,
+		Quantity:     quantity,
+		ReferenceId:  referenceId,
+		ActorId:      actorId,
+	}
+	if reason != "" {
+		movement.Reason = &reason
+	}
+
+	err = adapters.InventoryMovementRepository.Create(ctx, movement)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error creating inventory movement")
+		return utils.NewInternalError("Failed to create inventory movement")
+	}
+	return nil
+}
+
+func addInventoryLot(ctx context.Context, adapters repository.Adapters, inventory domain.Inventory, quantity float64, lot stockLot, referenceId *uuid.UUID) error {
+	inventoryLot := domain.InventoryLot{
+		Id:              uuid.New(),
+		InventoryId:     inventory.Id,
+		IngredientId:    inventory.IngredientId,
+		Quantity:        quantity,
+		InitialQuantity: quantity,
+		ReceivedAt:      time.Now(),
+		ExpiresAt:       lot.ExpiresAt,
+		ReferenceId:     referenceId,
+	}
+	if lot.LotNumber != "" {
+		inventoryLot.LotNumber = &lot.LotNumber
+	}
+
+	err := adapters.InventoryLotRepository.Create(ctx, inventoryLot)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error creating inventory lot")
+		return utils.NewInternalError("Failed to create inventory lot")
+	}
+	return nil
+}
+
+// consumeInventoryLots takes quantity out of the open lots of an inventory,
+// earliest expiry first, and returns the part taken from each lot. With a
+// reference the draws are kept so returnInventoryStock can put them back.
+func consumeInventoryLots(ctx context.Context, adapters repository.Adapters, inventory domain.Inventory, quantity float64, referenceId *uuid.UUID) ([]domain.InventoryLot, error) {
+	lots, err := adapters.InventoryLotRepository.GetOpenByInventoryId(ctx, inventory.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory lots")
+		return nil, utils.NewInternalError("Failed to get inventory lots")
+	}
+
+	taken := []domain.InventoryLot{}
+	remaining := quantity
+	for _, lot := range lots {
+		if remaining <= stockTolerance {
+			break
+		}
+		// a lot left with a rounding error is emptied instead
+		take := lot.Quantity
+		if remaining < take-stockTolerance {
+			take = remaining
+		}
+		err = adapters.InventoryLotRepository.AdjustQuantity(ctx, lot.Id, -take)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error updating inventory lot")
+			return nil, utils.NewInternalError("Failed to update inventory lot")
+		}
+		if referenceId != nil {
+			err = adapters.InventoryLotDrawRepository.Create(ctx, domain.InventoryLotDraw{
+				Id:          uuid.New(),
+				LotId:       lot.Id,
+				InventoryId: inventory.Id,
+				ReferenceId: *referenceId,
+				Quantity:    take,
+			})
+			if err != nil {
+				logger.Log.WithError(err).Error("Error creating inventory lot draw")
+				return nil, utils.NewInternalError("Failed to update inventory lot")
+			}
+		}
+		lot.Quantity = take
+		taken = append(taken, lot)
+		remaining -= take
+	}
+
+	// callers check the stock under lock first, a shortfall here means the
+	// lots no longer match the inventory
+	if remaining > stockTolerance {
+		logger.Log.WithField("inventory_id", inventory.Id).WithField("uncovered", remaining).Error("Error stock taken is not covered by inventory lots")
+		return nil, utils.NewConflictError(fmt.Sprintf("Insufficient stock, %g short in the inventory lots", remaining))
+	}
+	return taken, nil
+}
+
+// returnInventoryStock adds quantity back to an inventory and writes the
+// ledger entry. Stock goes back into the lots referenceId drew it from so it
+// keeps their expiry, whatever is not covered by those draws gets a new lot
+// expiring with the earliest open lot of the inventory.
+func returnInventoryStock(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, inventory domain.Inventory, movementType string, quantity float64, reason string, referenceId, actorId *uuid.UUID) error {
+	remaining := quantity
+	if referenceId != nil {
+		draws, err := adapters.InventoryLotDrawRepository.GetOpenByReferenceId(ctx, *referenceId, inventory.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting inventory lot draws")
+			return utils.NewInternalError("Failed to get inventory lots")
+		}
+		for _, draw := range draws {
+			if remaining <= stockTolerance {
+				break
+			}
+			give := math.Min(remaining, draw.Quantity)
+			err = adapters.InventoryLotRepository.AdjustQuantity(ctx, draw.LotId, give)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error updating inventory lot")
+				return utils.NewInternalError("Failed to update inventory lot")
+			}
+			err = adapters.InventoryLotDrawRepository.AdjustQuantity(ctx, draw.Id, -give)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error updating inventory lot draw")
+				return utils.NewInternalError("Failed to update inventory lot")
+			}
+			remaining -= give
+		}
+	}
+
+	if remaining > stockTolerance {
+		lots, err := adapters.InventoryLotRepository.GetOpenByInventoryId(ctx, inventory.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting inventory lots")
+			return utils.NewInternalError("Failed to get inventory lots")
+		}
+		// open lots come earliest expiry first
+		lot := stockLot{}
+		if len(lots) > 0 {
+			lot.ExpiresAt = lots[0].ExpiresAt
+		}
+		err = addInventoryLot(ctx, adapters, inventory, remaining, lot, referenceId)
+		if err != nil {
+			return err
+		}
+	}
+	return appendInventoryMovement(ctx, adapters, alerts, inventory, movementType, quantity, reason, referenceId, actorId)
+}
+
+// drawDownStock takes quantity out of the inventories returned by
+// availableStock, one location after the other. Stock is never booked below
+// zero, what the locations can't cover is refused.
+func drawDownStock(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, ingredientId uuid.UUID, locationId *uuid.UUID, movementType string, quantity float64, reason string, referenceId, actorId *uuid.UUID) error {
+	inventories, _, err := availableStock(ctx, adapters, ingredientId, locationId, true)
+	if err != nil {
+		return err
+	}
+	if len(inventories) == 0 {
+		logger.Log.WithField("ingredient_id", ingredientId).Error("Error ingredient is not stocked")
+		return utils.NewConflictError("Ingredient is not stocked at this location")
+	}
+
+	remaining := quantity
+	for _, inventory := range inventories {
+		if remaining <= stockTolerance {
+			break
+		}
+		take := math.Min(math.Max(inventory.Quantity, 0), remaining)
+		if take <= 0 {
+			continue
+		}
+		err = recordInventoryMovement(ctx, adapters, alerts, inventory, movementType, -take, stockLot{}, reason, referenceId, actorId)
+		if err != nil {
+			return err
+		}
+		remaining -= take
+	}
+
+	if remaining > stockTolerance {
+		logger.Log.WithField("ingredient_id", ingredientId).WithField("uncovered", remaining).Error("Error insufficient ingredient stock")
+		return utils.NewConflictError(fmt.Sprintf("Insufficient stock, %g short", remaining))
+	}
+	return nil
+}
+
+// wasteInventoryLot writes off what is left of one lot and logs it as waste.
+// The inventory and then the lot are locked and the lot read again, so the
+// quantity written off is what the lot holds now. It returns the lot as it
+// was written off, with nothing done when it has been used up meanwhile.
+func wasteInventoryLot(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, lot domain.InventoryLot, wasteReason, notes string, actorId *uuid.UUID) (domain.InventoryLot, error) {
+	inventory, err := adapters.InventoryRepository.GetOneByIdForUpdate(ctx, lot.InventoryId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Inventory")
+		return lot, utils.NewInternalError("Failed to get inventory")
+	}
+	lot, err = adapters.InventoryLotRepository.GetOneByIdForUpdate(ctx, lot.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory lot")
+		return lot, utils.NewInternalError("Failed to get inventory lot")
+	}
+	if lot.Quantity <= stockTolerance {
+		return lot, nil
+	}
+	ingredient, err := adapters.IngredientRepository.GetOneById(ctx, lot.IngredientId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting ingredient")
+		return lot, utils.NewInternalError("Failed to get ingredient")
+	}
+
+	wasteLog := domain.WasteLog{
+		Id:           uuid.New(),
+		IngredientId: &lot.IngredientId,
+		Quantity:     lot.Quantity,
+		Unit:         ingredient.BaseUnit,
+		Reason:       wasteReason,
+		Cost:         lot.Quantity * ingredient.CostPerUnit,
+		ActorId:      actorId,
+	}
+	if notes != "" {
+		wasteLog.Notes = &notes
+	}
+
+	err = adapters.InventoryLotRepository.AdjustQuantity(ctx, lot.Id, -lot.Quantity)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error updating inventory lot")
+		return lot, utils.NewInternalError("Failed to update inventory lot")
+	}
+	err = appendInventoryMovement(ctx, adapters, alerts, inventory, domain.MovementTypeWaste, -lot.Quantity, notes, &wasteLog.Id, actorId)
+	if err != nil {
+		return lot, err
+	}
+
+	err = adapters.WasteLogRepository.Create(ctx, wasteLog)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error creating waste log")
+		return lot, utils.NewInternalError("Failed to create waste log")
+	}
+	return lot, nil
+}
+
+// getOrCreateInventory returns the inventory of an ingredient at a location,
+// creating an empty one the first time the ingredient is stocked there
+func getOrCreateInventory(ctx context.Context, adapters repository.Adapters, ingredientId, locationId uuid.UUID) (domain.Inventory, error) {
+	inventory, err := adapters.InventoryRepository.GetOneByIngredientIdAndLocationId(ctx, ingredientId, locationId)
+	if err == nil {
+		return inventory, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		logger.Log.WithError(err).Error("Error getting Inventory")
+		return domain.Inventory{}, utils.NewInternalError("Failed to get inventory")
+	}
+
+	err = adapters.InventoryRepository.Create(ctx, domain.Inventory{
+		Id:           uuid.New(),
+		IngredientId: ingredientId,
+		LocationId:   locationId,
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error creating inventory")
+		return domain.Inventory{}, utils.NewInternalError("Failed to create inventory")
+	}
+
+	inventory, err = adapters.InventoryRepository.GetOneByIngredientIdAndLocationId(ctx, ingredientId, locationId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Inventory")
+		return domain.Inventory{}, utils.NewInternalError("Failed to get inventory")
+	}
+	return inventory, nil
+}
+
+// deductOrderInventory takes the recipe ingredients of every order item out of inventory
+func deductOrderInventory(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, order domain.Order, actorId uuid.UUID) error {
+	items, err := adapters.OrderMenuRepository.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order menu")
+		return utils.NewInternalError("Failed to get order menu")
+	}
+
+	usages, err := calculateOrderIngredientUsage(ctx, adapters, items, order.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	if err := checkIngredientStock(ctx, adapters, usages, nil); err != nil {
+		return err
+	}
+
+	for _, usage := range usages {
+		err = drawDownStock(ctx, adapters, alerts, usage.IngredientId, nil, domain.MovementTypeSale, usage.Quantity, "Order fulfilment", &order.Id, &actorId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = adapters.OrderRepository.UpdateInventoryDeducted(ctx, order.Id, true)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order inventory state")
+		return utils.NewInternalError("Failed to update order")
+	}
+	return nil
+}
+
+// restoreOrderInventory puts back what deductOrderInventory took for a
+// cancelled order, at the locations it was taken from
+func restoreOrderInventory(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, order domain.Order, actorId uuid.UUID) error {
+	sales, err := adapters.InventoryMovementRepository.SumByReferenceId(ctx, order.Id, domain.MovementTypeSale)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting order inventory movements")
+		return utils.NewInternalError("Failed to get inventory movements")
+	}
+
+	for _, sale := range sales {
+		if sale.Quantity >= -stockTolerance {
+			continue
+		}
+		inventory, err := adapters.InventoryRepository.GetOneById(ctx, sale.InventoryId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting Inventory")
+			return utils.NewInternalError("Failed to get inventory")
+		}
+		err = returnInventoryStock(ctx, adapters, alerts, inventory, domain.MovementTypeSale, -sale.Quantity, "Order cancelled", &order.Id, &actorId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = adapters.OrderRepository.UpdateInventoryDeducted(ctx, order.Id, false)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order inventory state")
+		return utils.NewInternalError("Failed to update order")
+	}
+	return nil
+}
+
+// restockOrderItems puts back the ingredients of some units of an order at
+// the inventories the order drew them from, never more than the order still
+// has on its sale movements
+func restockOrderItems(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, order domain.Order, items []domain.OrderMenu, reason string, actorId uuid.UUID) error {
+	usages, err := calculateOrderIngredientUsage(ctx, adapters, items, order.CreatedAt)
+	if err != nil {
+		return err
+	}
+
+	sales, err := adapters.InventoryMovementRepository.SumByReferenceId(ctx, order.Id, domain.MovementTypeSale)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting order inventory movements")
+		return utils.NewInternalError("Failed to get inventory movements")
+	}
+
+	for _, usage := range usages {
+		remaining := usage.Quantity
+		for _, sale := range sales {
+			if sale.IngredientId != usage.IngredientId || sale.Quantity >= -stockTolerance || remaining <= stockTolerance {
+				continue
+			}
+			inventory, err := adapters.InventoryRepository.GetOneById(ctx, sale.InventoryId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting Inventory")
+				return utils.NewInternalError("Failed to get inventory")
+			}
+			quantity := math.Min(remaining, -sale.Quantity)
+			err = returnInventoryStock(ctx, adapters, alerts, inventory, domain.MovementTypeSale, quantity, reason, &order.Id, &actorId)
+			if err != nil {
+				return err
+			}
+			remaining -= quantity
+		}
+		if remaining > stockTolerance {
+			logger.Log.WithField("ingredient_id", usage.IngredientId).WithField("uncovered", remaining).Warn("Restocked quantity is not covered by the order sales")
+		}
+	}
+	return nil
+}
+
+// calculatePortions returns how many portions the kitchen stock allows and the
+// ingredient that runs out first. kitchenStock is the stock loaded by
+// loadKitchenStock, with nil the stock of each ingredient is read on its own.
+func calculatePortions(ctx context.Context, adapters repository.Adapters, recipeIngredients []domain.SimpleRecipeIngredient, kitchenStock map[uuid.UUID]float64) (float64, *domain.SimpleRecipeIngredient, error) {
+	if len(recipeIngredients) == 0 {
+		return 0, nil, nil
+	}
+
+	totalPortions := math.MaxFloat64
+	var limiting *domain.SimpleRecipeIngredient
+	for i, recipeIngredient := range recipeIngredients {
+		if recipeIngredient.BaseQuantity <= 0 {
+			return 0, nil, utils.NewInternalError("Invalid ingredient quantity in recipe")
+		}
+
+		available := kitchenStock[recipeIngredient.IngredientId]
+		if kitchenStock == nil {
+			var err error
+			_, available, err = availableStock(ctx, adapters, recipeIngredient.IngredientId, nil, false)
+			if err != nil {
+				return 0, nil, err
+			}
+		}
+
+		portions := math.Floor(math.Max(available, 0) / recipeIngredient.BaseQuantity)
+		if portions < totalPortions {
+			totalPortions = portions
+			limiting = &recipeIngredients[i]
+		}
+	}
+
+	return totalPortions, limiting, nil
+}
+
+// loadKitchenStock reads the kitchen stock of every ingredient at once for
+// pages that work out the portions of many menu items
+func loadKitchenStock(ctx context.Context, adapters repository.Adapters) (map[uuid.UUID]float64, error) {
+	kitchenStock, err := adapters.InventoryRepository.SumKitchenQuantities(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Inventory")
+		return nil, utils.NewInternalError("Failed to get inventory")
+	}
+	return kitchenStock, nil
+}
+
+// explodeMenuPortion resolves the recipe of a menu down to the raw
+// ingredients of one portion, nil when the menu has no recipe
+func explodeMenuPortion(ctx context.Context, adapters repository.Adapters, menuId uuid.UUID, at time.Time) ([]domain.SimpleRecipeIngredient, error) {
+	recipe, err := adapters.RecipeRepository.GetOneByMenuId(ctx, menuId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Recipe")
+		return nil, utils.NewInternalError("Failed to get recipe")
+	}
+	return explodeRecipePortion(ctx, adapters, recipe.Id, at)
+}
+
+// explodeMenuPortions is explodeMenuPortion for a list of menu items, keyed
+// by menu id. The recipes are loaded in one query and each is resolved once
+// however many times the caller needs it.
+func explodeMenuPortions(ctx context.Context, adapters repository.Adapters, menus []domain.Menu, at time.Time) (map[uuid.UUID][]domain.SimpleRecipeIngredient, error) {
+	recipes, err := adapters.RecipeRepository.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Recipe")
+		return nil, utils.NewInternalError("Failed to get recipes")
+	}
+	listed := map[uuid.UUID]bool{}
+	for _, menu := range menus {
+		listed[menu.Id] = true
+	}
+
+	portions := map[uuid.UUID][]domain.SimpleRecipeIngredient{}
+	for _, recipe := range recipes {
+		if recipe.MenuId == nil || !listed[*recipe.MenuId] {
+			continue
+		}
+		recipeIngredients, err := explodeRecipePortion(ctx, adapters, recipe.Id, at)
+		if err != nil {
+			return nil, err
+		}
+		portions[*recipe.MenuId] = recipeIngredients
+	}
+	return portions, nil
+}
+
+// calculateMenuAvailability reports how many portions of a menu can be made
+// from the ingredients of one portion. Menu items without a recipe are not
+// tracked and never sold out.
+func calculateMenuAvailability(ctx context.Context, adapters repository.Adapters, menu domain.Menu, recipeIngredients []domain.SimpleRecipeIngredient, kitchenStock map[uuid.UUID]float64) (domain.MenuAvailability, error) {
+	result := domain.MenuAvailability{Menu: menu}
+	if len(recipeIngredients) == 0 {
+		return result, nil
+	}
+
+	totalPortions, limiting, err := calculatePortions(ctx, adapters, recipeIngredients, kitchenStock)
+	if err != nil {
+		return result, err
+	}
+
+	result.Tracked = true
+	result.TotalPortions = totalPortions
+	result.LimitingIngredient = limiting
+	result.SoldOut = totalPortions < 1
+	return result, nil
+}
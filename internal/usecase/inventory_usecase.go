@@ -9,11 +9,20 @@ import (
 )
 
 type InventoryUsecase interface {
-	Create(ctx context.Context, req dto.CreateInventoryRequest) (domain.Inventory, error)
-	GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.Inventory, error)
+	Create(ctx context.Context, req dto.CreateInventoryRequest, actorId uuid.UUID) (domain.Inventory, error)
+	GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.IngredientStock, error)
 	GetOneById(ctx context.Context, id uuid.UUID) (domain.Inventory, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.UpdateInventoryRequest) (domain.Inventory, error)
+	Update(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateInventoryRequest) (domain.Inventory, error)
+	CreateAdjustment(ctx context.Context, id, actorId uuid.UUID, req dto.CreateInventoryAdjustmentRequest) (domain.Inventory, error)
+	GetMovements(ctx context.Context, id uuid.UUID) (domain.InventoryLedger, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) (domain.Inventory, error)
 	CalculateMenuPortions(ctx context.Context, menuId uuid.UUID) (domain.InventoryMenu, error)
+	GetMenuAvailability(ctx context.Context) ([]domain.MenuAvailability, error)
+	GetLowStock(ctx context.Context) ([]domain.LowStockItem, error)
+	GetLots(ctx context.Context, id uuid.UUID) ([]domain.InventoryLot, error)
+	GetExpiringLots(ctx context.Context, req dto.GetExpiringLotsRequest) ([]domain.InventoryLot, error)
+	WasteExpiredLots(ctx context.Context) ([]domain.InventoryLot, error)
+	Transfer(ctx context.Context, req dto.CreateStockTransferRequest, actorId uuid.UUID) (domain.StockTransfer, error)
+	GetTransfers(ctx context.Context, req dto.GetStockTransfersRequest) ([]domain.StockTransfer, error)
 }
@@ -2,46 +2,80 @@ package usecase
 
 import (
 	"context"
-	"math"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
 	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
 	"github.com/ryvasa/go-restaurant/internal/repository"
 	"github.com/ryvasa/go-restaurant/pkg/logger"
 	"github.com/ryvasa/go-restaurant/utils"
 )
 
 type InventoryUsecaseImpl struct {
-	inventoryRepo repository.InventoryRepository
-	txRepo        repository.TransactionRepository
+	inventoryRepo         repository.InventoryRepository
+	inventoryMovementRepo repository.InventoryMovementRepository
+	inventoryLotRepo      repository.InventoryLotRepository
+	stockTransferRepo     repository.StockTransferRepository
+	stockAlertNotifier    notifier.StockAlertNotifier
+	txRepo                repository.TransactionRepository
 }
 
-func NewInventoryUsecase(inventoryRepo repository.InventoryRepository, txRepo repository.TransactionRepository) InventoryUsecase {
+func NewInventoryUsecase(inventoryRepo repository.InventoryRepository, inventoryMovementRepo repository.InventoryMovementRepository, inventoryLotRepo repository.InventoryLotRepository, stockTransferRepo repository.StockTransferRepository, stockAlertNotifier notifier.StockAlertNotifier, txRepo repository.TransactionRepository) InventoryUsecase {
 	return &InventoryUsecaseImpl{
-		inventoryRepo: inventoryRepo,
-		txRepo:        txRepo,
+		inventoryRepo:         inventoryRepo,
+		inventoryMovementRepo: inventoryMovementRepo,
+		inventoryLotRepo:      inventoryLotRepo,
+		stockTransferRepo:     stockTransferRepo,
+		stockAlertNotifier:    stockAlertNotifier,
+		txRepo:                txRepo,
 	}
 }
 
-func (u *InventoryUsecaseImpl) Create(ctx context.Context, req dto.CreateInventoryRequest) (domain.Inventory, error) {
+func (u *InventoryUsecaseImpl) Create(ctx context.Context, req dto.CreateInventoryRequest, actorId uuid.UUID) (domain.Inventory, error) {
 	result := domain.Inventory{}
+	alerts := stockAlerts{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		if err := utils.ValidateStruct(req); len(err) > 0 {
 			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
 			return utils.NewValidationError(err)
 		}
 		// TODO: apakah jika ingedient tidak ditemukan maka membuat ingredient baru?
-		_, err := adapters.IngredientRepository.GetOneById(ctx, req.IngredientId)
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, req.IngredientId)
 		if err != nil {
 			logger.Log.WithError(err).Error("Ingredient not found")
 			return utils.NewNotFoundError("Ingredient not found")
 
 		}
+		quantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, req.Quantity, req.Unit)
+		if err != nil {
+			return err
+		}
+		location, err := getStorageLocation(ctx, adapters, req.LocationId)
+		if err != nil {
+			return err
+		}
+		_, err = adapters.InventoryRepository.GetOneByIngredientIdAndLocationId(ctx, ingredient.Id, location.Id)
+		if err == nil {
+			logger.Log.WithField("location_id", location.Id).Error("Error inventory already exists")
+			return utils.NewConflictError(fmt.Sprintf("%s is already stocked at %s", ingredient.Name, location.Name))
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Log.WithError(err).Error("Error getting inventory")
+			return utils.NewInternalError("Failed to get inventory")
+		}
+
+		// stock starts empty, the initial quantity is booked as a receipt
 		inventory := domain.Inventory{
 			Id:           uuid.New(),
 			IngredientId: req.IngredientId,
-			Quantity:     req.Quantity,
+			LocationId:   location.Id,
 		}
 		err = adapters.InventoryRepository.Create(ctx, inventory)
 		if err != nil {
@@ -50,6 +84,11 @@ func (u *InventoryUsecaseImpl) Create(ctx context.Context, req dto.CreateInvento
 
 		}
 
+		err = recordInventoryMovement(ctx, adapters, &alerts, inventory, domain.MovementTypeReceipt, quantity, stockLot{LotNumber: req.LotNumber, ExpiresAt: req.ExpiresAt}, "Initial stock", nil, &actorId)
+		if err != nil {
+			return err
+		}
+
 		createdInventory, err := adapters.InventoryRepository.GetOneById(ctx, inventory.Id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error getting inventory")
@@ -64,17 +103,34 @@ func (u *InventoryUsecaseImpl) Create(ctx context.Context, req dto.CreateInvento
 	if err != nil {
 		return result, err
 	}
+	alerts.notify(ctx, u.stockAlertNotifier)
 	return result, nil
 }
 
-func (u *InventoryUsecaseImpl) GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.Inventory, error) {
-	inventory, err := u.inventoryRepo.GetOneByIngredientId(ctx, ingredientId)
+// GetOneByIngredientId returns the stock of an ingredient at every location
+func (u *InventoryUsecaseImpl) GetOneByIngredientId(ctx context.Context, ingredientId uuid.UUID) (domain.IngredientStock, error) {
+	inventories, err := u.inventoryRepo.GetAllByIngredientId(ctx, ingredientId)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error getting inventory")
-		return inventory, utils.NewInternalError("Failed to get restored inventory")
+		return domain.IngredientStock{}, utils.NewInternalError("Failed to get inventory")
+	}
+	if len(inventories) == 0 {
+		logger.Log.WithField("ingredient_id", ingredientId).Error("Error ingredient is not stocked")
+		return domain.IngredientStock{}, utils.NewNotFoundError("Inventory not found")
+	}
 
+	stock := domain.IngredientStock{
+		IngredientId: ingredientId,
+		Unit:         inventories[0].Unit,
+		Locations:    inventories,
 	}
-	return inventory, nil
+	for _, inventory := range inventories {
+		stock.Quantity += inventory.Quantity
+		if inventory.FeedsKitchen {
+			stock.KitchenQuantity += inventory.Quantity
+		}
+	}
+	return stock, nil
 }
 
 func (u *InventoryUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Inventory, error) {
@@ -86,34 +142,43 @@ func (u *InventoryUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (do
 	return inventory, nil
 }
 
-func (u *InventoryUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateInventoryRequest) (domain.Inventory, error) {
+func (u *InventoryUsecaseImpl) Update(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateInventoryRequest) (domain.Inventory, error) {
 	result := domain.Inventory{}
+	alerts := stockAlerts{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		if err := utils.ValidateStruct(req); len(err) > 0 {
 			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
 			return utils.NewValidationError(err)
 		}
 
-		_, err := adapters.InventoryRepository.GetOneById(ctx, id)
+		// locked so the difference is taken from the quantity booked last
+		existingInventory, err := adapters.InventoryRepository.GetOneByIdForUpdate(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error getting inventory")
 			return utils.NewNotFoundError("Inventory not found")
 		}
 
-		inventory := domain.Inventory{
-			Quantity: req.Quantity,
+		quantity, err := toBaseQuantity(ctx, adapters, existingInventory.IngredientId, existingInventory.Unit, req.Quantity, req.Unit)
+		if err != nil {
+			return err
 		}
 
-		err = adapters.InventoryRepository.Update(ctx, id, inventory)
-		if err != nil {
-			logger.Log.WithError(err).Error("Error updating inventory")
-			return utils.NewInternalError("Failed to get restored inventory")
+		// setting the quantity directly is booked as an adjustment of the difference
+		if delta := quantity - existingInventory.Quantity; delta != 0 {
+			reason := req.Reason
+			if reason == "" {
+				reason = "Manual correction"
+			}
+			err = recordInventoryMovement(ctx, adapters, &alerts, existingInventory, domain.MovementTypeAdjustment, delta, stockLot{}, reason, nil, &actorId)
+			if err != nil {
+				return err
+			}
 		}
 
 		updatedInventory, err := adapters.InventoryRepository.GetOneById(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error getting inventory")
-			return utils.NewInternalError("Failed to get restored inventory")
+			return utils.NewInternalError("Failed to get updated inventory")
 		}
 
 		result = updatedInventory
@@ -123,9 +188,92 @@ func (u *InventoryUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto
 	if err != nil {
 		return result, err
 	}
+	alerts.notify(ctx, u.stockAlertNotifier)
 	return result, nil
 }
 
+func (u *InventoryUsecaseImpl) CreateAdjustment(ctx context.Context, id, actorId uuid.UUID, req dto.CreateInventoryAdjustmentRequest) (domain.Inventory, error) {
+	result := domain.Inventory{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		existingInventory, err := adapters.InventoryRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting inventory")
+			return utils.NewNotFoundError("Inventory not found")
+		}
+
+		delta, err := toBaseQuantity(ctx, adapters, existingInventory.IngredientId, existingInventory.Unit, req.Quantity, req.Unit)
+		if err != nil {
+			return err
+		}
+		switch req.Type {
+		case domain.MovementTypeReceipt, domain.MovementTypeWaste:
+			if req.Quantity < 0 {
+				logger.Log.WithField("quantity", req.Quantity).Error("Error invalid movement quantity")
+				return utils.NewBadRequestError(fmt.Sprintf("Quantity of a %s must be positive", req.Type))
+			}
+			if req.Type == domain.MovementTypeWaste {
+				delta = -delta
+			}
+		}
+
+		if existingInventory.Quantity+delta < 0 {
+			logger.Log.WithField("quantity", existingInventory.Quantity).WithField("delta", delta).Error("Error stock would become negative")
+			return utils.NewConflictError("Insufficient stock for this movement")
+		}
+
+		err = recordInventoryMovement(ctx, adapters, &alerts, existingInventory, req.Type, delta, stockLot{LotNumber: req.LotNumber, ExpiresAt: req.ExpiresAt}, req.Reason, req.ReferenceId, &actorId)
+		if err != nil {
+			return err
+		}
+
+		updatedInventory, err := adapters.InventoryRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting inventory")
+			return utils.NewInternalError("Failed to get updated inventory")
+		}
+		result = updatedInventory
+
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+func (u *InventoryUsecaseImpl) GetMovements(ctx context.Context, id uuid.UUID) (domain.InventoryLedger, error) {
+	inventory, err := u.inventoryRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory")
+		return domain.InventoryLedger{}, utils.NewNotFoundError("Inventory not found")
+	}
+
+	movements, err := u.inventoryMovementRepo.GetAllByInventoryId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory movements")
+		return domain.InventoryLedger{}, utils.NewInternalError("Failed to get inventory movements")
+	}
+
+	balance, err := u.inventoryMovementRepo.SumByInventoryId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory ledger balance")
+		return domain.InventoryLedger{}, utils.NewInternalError("Failed to get inventory ledger balance")
+	}
+
+	return domain.InventoryLedger{
+		Inventory:     inventory,
+		LedgerBalance: balance,
+		Movements:     movements,
+	}, nil
+}
+
 func (u *InventoryUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		_, err := adapters.InventoryRepository.GetOneById(ctx, id)
@@ -193,37 +341,303 @@ func (u *InventoryUsecaseImpl) CalculateMenuPortions(ctx context.Context, menuId
 		}
 		result.Recipe = recipe
 
-		recipeIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeId(ctx, recipe.Id)
+		recipeIngredients, err := explodeRecipePortion(ctx, adapters, recipe.Id, time.Now())
 		if err != nil {
-			logger.Log.WithError(err).Error("Error getting Ingredients")
-			return utils.NewInternalError("Failed to get ingredients")
+			return err
 		}
 		result.Ingredients = recipeIngredients
 
-		for _, recipeIngredient := range recipeIngredients {
-			inventory, err := adapters.InventoryRepository.GetOneByIngredientId(ctx, recipeIngredient.IngredientId)
+		totalPortions, limiting, err := calculatePortions(ctx, adapters, recipeIngredients, nil)
+		if err != nil {
+			return err
+		}
+		result.TotalPortions = totalPortions
+		result.LimitingIngredient = limiting
+
+		return nil
+	})
+
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating menu portions")
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (u *InventoryUsecaseImpl) GetMenuAvailability(ctx context.Context) ([]domain.MenuAvailability, error) {
+	result := []domain.MenuAvailability{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		menus, err := adapters.MenuRepository.GetAll(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting Menu")
+			return utils.NewInternalError("Failed to get all menu")
+		}
+
+		portions, err := explodeMenuPortions(ctx, adapters, menus, time.Now())
+		if err != nil {
+			return err
+		}
+		kitchenStock, err := loadKitchenStock(ctx, adapters)
+		if err != nil {
+			return err
+		}
+		for _, menu := range menus {
+			availability, err := calculateMenuAvailability(ctx, adapters, menu, portions[menu.Id], kitchenStock)
 			if err != nil {
-				logger.Log.WithError(err).Error("Error getting Inventory")
-				return utils.NewInternalError("Failed to get inventory")
+				return err
 			}
+			result = append(result, availability)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating menu availability")
+		return result, err
+	}
 
-			if recipeIngredient.Quantity > 0 {
-				var minPortions float64 = math.MaxFloat64
-				maxPortions := inventory.Quantity / recipeIngredient.Quantity
+	return result, nil
+}
 
-				result.TotalPortions = math.Floor(math.Min(minPortions, maxPortions))
-			} else {
-				return utils.NewInternalError("Invalid ingredient quantity in recipe")
-			}
+func (u *InventoryUsecaseImpl) GetLowStock(ctx context.Context) ([]domain.LowStockItem, error) {
+	items, err := u.inventoryRepo.GetLowStock(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting low stock")
+		return nil, utils.NewInternalError("Failed to get low stock")
+	}
+	return items, nil
+}
+
+func (u *InventoryUsecaseImpl) GetLots(ctx context.Context, id uuid.UUID) ([]domain.InventoryLot, error) {
+	_, err := u.inventoryRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory")
+		return nil, utils.NewNotFoundError("Inventory not found")
+	}
+
+	lots, err := u.inventoryLotRepo.GetOpenByInventoryId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting inventory lots")
+		return nil, utils.NewInternalError("Failed to get inventory lots")
+	}
+	return lots, nil
+}
+
+// defaultExpiringDays is used when the expiring lots are listed without days
+const defaultExpiringDays = 3
+
+func (u *InventoryUsecaseImpl) GetExpiringLots(ctx context.Context, req dto.GetExpiringLotsRequest) ([]domain.InventoryLot, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return nil, utils.NewValidationError(err)
+	}
+
+	days := defaultExpiringDays
+	if req.Days != "" {
+		parsedDays, err := strconv.Atoi(req.Days)
+		if err != nil || parsedDays < 0 {
+			logger.Log.WithField("days", req.Days).Error("Error invalid days")
+			return nil, utils.NewValidationError(utils.FieldError("days", "Days must be a whole number of 0 or more"))
+		}
+		days = parsedDays
+	}
+
+	lots, err := u.inventoryLotRepo.GetExpiringBefore(ctx, time.Now().AddDate(0, 0, days))
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting expiring lots")
+		return nil, utils.NewInternalError("Failed to get expiring lots")
+	}
+	return lots, nil
+}
+
+// WasteExpiredLots writes off every lot past its expiry date, it is run by
+// the scheduler. The lots are written off in ingredient and inventory order
+// so the inventories are locked in the same order as stock checks lock them.
+func (u *InventoryUsecaseImpl) WasteExpiredLots(ctx context.Context) ([]domain.InventoryLot, error) {
+	result := []domain.InventoryLot{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		lots, err := adapters.InventoryLotRepository.GetExpiringBefore(ctx, time.Now())
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting expired lots")
+			return utils.NewInternalError("Failed to get expired lots")
 		}
+		sort.SliceStable(lots, func(i, j int) bool {
+			if lots[i].IngredientId != lots[j].IngredientId {
+				return lots[i].IngredientId.String() < lots[j].IngredientId.String()
+			}
+			return lots[i].InventoryId.String() < lots[j].InventoryId.String()
+		})
 
+		for _, lot := range lots {
+			reason := "Lot expired"
+			if lot.LotNumber != nil {
+				reason = fmt.Sprintf("Lot %s expired", *lot.LotNumber)
+			}
+			wasted, err := wasteInventoryLot(ctx, adapters, &alerts, lot, domain.WasteReasonExpired, reason, nil)
+			if err != nil {
+				return err
+			}
+			if wasted.Quantity > stockTolerance {
+				result = append(result, wasted)
+			}
+		}
 		return nil
 	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error wasting expired lots")
+		return nil, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+// Transfer moves stock between two locations in one transaction. The lots
+// taken at the source are recreated at the destination with their lot number
+// and expiry.
+func (u *InventoryUsecaseImpl) Transfer(ctx context.Context, req dto.CreateStockTransferRequest, actorId uuid.UUID) (domain.StockTransfer, error) {
+	result := domain.StockTransfer{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+		if req.FromLocationId == req.ToLocationId {
+			logger.Log.WithField("location_id", req.FromLocationId).Error("Error transfer to the same location")
+			return utils.NewValidationError(utils.FieldError("to_location_id", "Stock can not be transferred to the location it is taken from"))
+		}
+
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, req.IngredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Ingredient not found")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+		from, err := getStorageLocation(ctx, adapters, &req.FromLocationId)
+		if err != nil {
+			return err
+		}
+		to, err := getStorageLocation(ctx, adapters, &req.ToLocationId)
+		if err != nil {
+			return err
+		}
+		quantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, req.Quantity, req.Unit)
+		if err != nil {
+			return err
+		}
+
+		_, available, err := availableStock(ctx, adapters, ingredient.Id, &from.Id, true)
+		if err != nil {
+			return err
+		}
+		if available < quantity-stockTolerance {
+			logger.Log.WithField("available", available).WithField("quantity", quantity).Error("Error insufficient stock to transfer")
+			return utils.NewConflictError(fmt.Sprintf("Only %g %s of %s is stocked at %s", available, ingredient.BaseUnit, ingredient.Name, from.Name))
+		}
+		source, err := adapters.InventoryRepository.GetOneByIngredientIdAndLocationId(ctx, ingredient.Id, from.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting inventory")
+			return utils.NewInternalError("Failed to get inventory")
+		}
+		destination, err := getOrCreateInventory(ctx, adapters, ingredient.Id, to.Id)
+		if err != nil {
+			return err
+		}
+
+		transfer := domain.StockTransfer{
+			Id:             uuid.New(),
+			IngredientId:   ingredient.Id,
+			FromLocationId: from.Id,
+			ToLocationId:   to.Id,
+			Quantity:       quantity,
+			ActorId:        &actorId,
+		}
+		reason := fmt.Sprintf("Transfer from %s to %s", from.Name, to.Name)
+		if req.Reason != "" {
+			transfer.Reason = &req.Reason
+			reason = fmt.Sprintf("%s: %s", reason, req.Reason)
+		}
+		err = adapters.StockTransferRepository.Create(ctx, transfer)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error creating stock transfer")
+			return utils.NewInternalError("Failed to create stock transfer")
+		}
+
+		// the total stock does not change, so no stock alerts are raised
+		lots, err := consumeInventoryLots(ctx, adapters, source, quantity, &transfer.Id)
+		if err != nil {
+			return err
+		}
+		err = appendInventoryMovement(ctx, adapters, nil, source, domain.MovementTypeTransfer, -quantity, reason, &transfer.Id, &actorId)
+		if err != nil {
+			return err
+		}
+
+		moved := 0.0
+		for _, lot := range lots {
+			movedLot := stockLot{ExpiresAt: lot.ExpiresAt}
+			if lot.LotNumber != nil {
+				movedLot.LotNumber = *lot.LotNumber
+			}
+			err = addInventoryLot(ctx, adapters, destination, lot.Quantity, movedLot, &transfer.Id)
+			if err != nil {
+				return err
+			}
+			moved += lot.Quantity
+		}
+		if rest := quantity - moved; rest > stockTolerance {
+			err = addInventoryLot(ctx, adapters, destination, rest, stockLot{}, &transfer.Id)
+			if err != nil {
+				return err
+			}
+		}
+		err = appendInventoryMovement(ctx, adapters, nil, destination, domain.MovementTypeTransfer, quantity, reason, &transfer.Id, &actorId)
+		if err != nil {
+			return err
+		}
 
+		createdTransfer, err := adapters.StockTransferRepository.GetOneById(ctx, transfer.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting stock transfer")
+			return utils.NewInternalError("Failed to get stock transfer")
+		}
+		result = createdTransfer
+		return nil
+	})
 	if err != nil {
-		logger.Log.WithError(err).Error("Error calculating menu portions")
+		logger.Log.WithError(err).Error("Error transferring stock")
 		return result, err
 	}
-
 	return result, nil
 }
+
+func (u *InventoryUsecaseImpl) GetTransfers(ctx context.Context, req dto.GetStockTransfersRequest) ([]domain.StockTransfer, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return nil, utils.NewValidationError(err)
+	}
+
+	filter := domain.StockTransferFilter{}
+	if req.IngredientId != "" {
+		ingredientId, err := uuid.Parse(req.IngredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid ingredient id format")
+			return nil, utils.NewValidationError("Invalid ingredient id format")
+		}
+		filter.IngredientId = &ingredientId
+	}
+	if req.LocationId != "" {
+		locationId, err := uuid.Parse(req.LocationId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid location id format")
+			return nil, utils.NewValidationError("Invalid location id format")
+		}
+		filter.LocationId = &locationId
+	}
+
+	transfers, err := u.stockTransferRepo.GetAll(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting stock transfers")
+		return nil, utils.NewInternalError("Failed to get stock transfers")
+	}
+	return transfers, nil
+}
@@ -10,9 +10,9 @@ import (
 )
 
 type MenuUsecase interface {
-	GetAll(ctx context.Context) ([]domain.Menu, error)
+	GetAll(ctx context.Context, req dto.GetMenuRequest) ([]domain.MenuDetail, error)
 	Create(ctx context.Context, req dto.CreateMenuRequest, file multipart.File) (domain.Menu, error)
-	Get(ctx context.Context, id uuid.UUID) (domain.Menu, error)
+	Get(ctx context.Context, id uuid.UUID) (domain.MenuDetail, error)
 	Update(ctx context.Context, id uuid.UUID, req dto.UpdateMenuRequest, file multipart.File) (domain.Menu, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) (domain.Menu, error)
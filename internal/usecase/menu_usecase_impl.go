@@ -3,6 +3,7 @@ package usecase
 import (
 	"context"
 	"mime/multipart"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -20,14 +21,67 @@ type MenuUsecaseImpl struct {
 func NewMenuUsecase(menuRepo repository.MenuRepository, txRepo repository.TransactionRepository) MenuUsecase {
 	return &MenuUsecaseImpl{menuRepo, txRepo}
 }
-func (u *MenuUsecaseImpl) GetAll(ctx context.Context) ([]domain.Menu, error) {
-	menu, err := u.menuRepo.GetAll(ctx)
+
+// GetAll lists the menu with allergens, diets and nutrition, filtered by the
+// allergens to exclude and the diets to fit
+func (u *MenuUsecaseImpl) GetAll(ctx context.Context, req dto.GetMenuRequest) ([]domain.MenuDetail, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return []domain.MenuDetail{}, utils.NewValidationError(err)
+	}
+	excludeAllergens, err := parseDietaryList("exclude_allergens", req.ExcludeAllergens, domain.Allergens)
+	if err != nil {
+		return []domain.MenuDetail{}, err
+	}
+	diets, err := parseDietaryList("diets", req.Diets, domain.MenuDiets)
+	if err != nil {
+		return []domain.MenuDetail{}, err
+	}
+
+	result := []domain.MenuDetail{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		menus, err := adapters.MenuRepository.GetAll(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get all menu")
+			return utils.NewInternalError("Failed to get all menu")
+		}
+
+		// recipes and stock are loaded once for the whole listing, sold out
+		// menu is hidden until the ingredients are restocked
+		portions, err := explodeMenuPortions(ctx, adapters, menus, time.Now())
+		if err != nil {
+			return err
+		}
+		kitchenStock, err := loadKitchenStock(ctx, adapters)
+		if err != nil {
+			return err
+		}
+		ingredientCache := map[uuid.UUID]domain.Ingredient{}
+		nutritionCache := map[uuid.UUID]*domain.IngredientNutrition{}
+		for _, menu := range menus {
+			availability, err := calculateMenuAvailability(ctx, adapters, menu, portions[menu.Id], kitchenStock)
+			if err != nil {
+				return err
+			}
+			if availability.SoldOut {
+				continue
+			}
+			detail, err := calculateMenuDetail(ctx, adapters, menu, portions[menu.Id], ingredientCache, nutritionCache)
+			if err != nil {
+				return err
+			}
+			if !menuMatchesDietary(detail, excludeAllergens, diets) {
+				continue
+			}
+			result = append(result, detail)
+		}
+		return nil
+	})
 	if err != nil {
-		logger.Log.WithError(err).Error("Error failed to get all menu")
-		return []domain.Menu{}, utils.NewInternalError("Failed to get all menu")
+		return []domain.MenuDetail{}, err
 	}
 
-	return menu, nil
+	return result, nil
 }
 func (u *MenuUsecaseImpl) Create(ctx context.Context, req dto.CreateMenuRequest, file multipart.File) (domain.Menu, error) {
 	result := domain.Menu{}
@@ -72,13 +126,25 @@ func (u *MenuUsecaseImpl) Create(ctx context.Context, req dto.CreateMenuRequest,
 	return result, nil
 }
 
-func (u *MenuUsecaseImpl) Get(ctx context.Context, id uuid.UUID) (domain.Menu, error) {
-	menu, err := u.menuRepo.Get(ctx, id)
+func (u *MenuUsecaseImpl) Get(ctx context.Context, id uuid.UUID) (domain.MenuDetail, error) {
+	result := domain.MenuDetail{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		menu, err := adapters.MenuRepository.Get(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error menu not found")
+			return utils.NewNotFoundError("Menu not found")
+		}
+		recipeIngredients, err := explodeMenuPortion(ctx, adapters, menu.Id, time.Now())
+		if err != nil {
+			return err
+		}
+		result, err = calculateMenuDetail(ctx, adapters, menu, recipeIngredients, map[uuid.UUID]domain.Ingredient{}, map[uuid.UUID]*domain.IngredientNutrition{})
+		return err
+	})
 	if err != nil {
-		logger.Log.WithError(err).Error("Error menu not found")
-		return domain.Menu{}, utils.NewNotFoundError("Menu not found")
+		return domain.MenuDetail{}, err
 	}
-	return menu, nil
+	return result, nil
 }
 
 func (u *MenuUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateMenuRequest, file multipart.File) (domain.Menu, error) {
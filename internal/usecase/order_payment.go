@@ -0,0 +1,490 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/payment"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// Money is never moved by the provider inside a transaction. A payment is
+// committed as capturing before the provider is asked for it and the answer
+// is applied in a second transaction, a capture whose answer got lost stays
+// capturing until the webhook of the provider or a retry settles it. Intents
+// are still opened inside the transaction, an intent left behind by a
+// rollback holds no money.
+
+// splitPaymentMethod is the payment method of an order paid with several
+// methods
+const splitPaymentMethod = "split"
+
+// checkOrderPayable rejects orders that are closed or already paid
+func checkOrderPayable(order domain.Order) error {
+	if order.Status == domain.OrderStatusCancelled || order.Status == domain.OrderStatusRefunded {
+		logger.Log.WithField("status", order.Status).Error("Error order can not be paid")
+		return utils.NewConflictError(fmt.Sprintf("Order can not be paid, status already '%s'", order.Status))
+	}
+	if order.PaymentStatus == domain.OrderPaymentPaid {
+		logger.Log.WithField("order_id", order.Id).Error("Error order already paid")
+		return utils.NewConflictError("Order is already paid")
+	}
+	return nil
+}
+
+// openPayment creates an intent with the provider for the share of the order
+// the request covers and records it as a pending payment
+func (u *OrderUsecaseImpl) openPayment(ctx context.Context, adapters repository.Adapters, order domain.Order, req dto.CreatePaymentRequest, actorId uuid.UUID) (domain.Payment, error) {
+	if err := checkOrderPayable(order); err != nil {
+		return domain.Payment{}, err
+	}
+	if order.Currency != u.currency {
+		logger.Log.WithField("order_currency", order.Currency).WithField("currency", u.currency).Error("Error order currency not accepted")
+		return domain.Payment{}, utils.NewConflictError(fmt.Sprintf("Order is in %s, payments are taken in %s", order.Currency, u.currency))
+	}
+	amount, allocations, err := resolvePaymentShare(ctx, adapters, order, req)
+	if err != nil {
+		return domain.Payment{}, err
+	}
+	// cash settling the whole balance is rounded to the smallest coin
+	var rounding domain.Money
+	if req.Method == cashPaymentMethod && req.Amount == 0 && req.SplitParts == 0 && len(req.Items) == 0 {
+		if rounded := u.cashRounding.round(amount); rounded > 0 {
+			rounding = rounded - amount
+			amount = rounded
+		}
+	}
+
+	intent, err := u.paymentProvider.CreateIntent(ctx, payment.IntentRequest{
+		OrderId:  order.Id.String(),
+		Amount:   amount,
+		Currency: order.Currency,
+		Method:   req.Method,
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create payment intent")
+		return domain.Payment{}, utils.NewInternalError("Failed to create payment with the payment provider")
+	}
+
+	orderPayment := domain.Payment{
+		Id:                uuid.New(),
+		OrderId:           order.Id,
+		Provider:          u.paymentProvider.Name(),
+		ProviderReference: intent.Reference,
+		Method:            req.Method,
+		Amount:            amount,
+		Rounding:          rounding,
+		Currency:          order.Currency,
+		Status:            domain.PaymentStatusPending,
+		CreatedBy:         actorId,
+	}
+	if req.SplitParts > 0 {
+		orderPayment.SplitParts = &req.SplitParts
+	}
+	err = adapters.PaymentRepository.Create(ctx, orderPayment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create payment")
+		return domain.Payment{}, utils.NewInternalError("Failed to create payment")
+	}
+
+	for i := range allocations {
+		allocations[i].Id = uuid.New()
+		allocations[i].PaymentId = orderPayment.Id
+		err = adapters.PaymentAllocationRepository.Create(ctx, allocations[i])
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create payment allocation")
+			return domain.Payment{}, utils.NewInternalError("Failed to create payment allocation")
+		}
+	}
+	orderPayment.Allocations = allocations
+	orderPayment.ClientSecret = intent.ClientSecret
+	return orderPayment, nil
+}
+
+// resolvePaymentShare works out the amount of a payment: the amount asked
+// for, an even share of the order, the price of the order lines or else the
+// whole outstanding balance
+func resolvePaymentShare(ctx context.Context, adapters repository.Adapters, order domain.Order, req dto.CreatePaymentRequest) (domain.Money, []domain.PaymentAllocation, error) {
+	options := 0
+	for _, set := range []bool{req.Amount > 0, req.SplitParts > 0, len(req.Items) > 0} {
+		if set {
+			options++
+		}
+	}
+	if options > 1 {
+		logger.Log.Error("Error payment share given more than once")
+		return 0, nil, utils.NewValidationError(utils.FieldError("amount", "Use only one of amount, split_parts or items"))
+	}
+
+	balance := order.Balance
+	amount := balance
+	allocations := []domain.PaymentAllocation{}
+	switch {
+	case req.Amount > 0:
+		amount = req.Amount
+	case req.SplitParts > 0:
+		amount = order.Amount.Div(req.SplitParts)
+		// the last share also takes the cents an even split leaves over
+		if balance-amount < domain.Money(req.SplitParts) {
+			amount = balance
+		}
+	case len(req.Items) > 0:
+		var err error
+		amount, allocations, err = allocatePaymentItems(ctx, adapters, order, req.Items)
+		if err != nil {
+			return 0, nil, err
+		}
+		// lines are rounded one by one, the last lines may be a few cents over
+		if amount > balance && amount-balance < domain.Money(len(req.Items)) {
+			amount = balance
+		}
+	}
+
+	if amount <= 0 {
+		logger.Log.WithField("amount", amount).Error("Error payment amount not positive")
+		return 0, nil, utils.NewValidationError(utils.FieldError("amount", "Payment amount must be at least 0.01"))
+	}
+	if amount > balance {
+		logger.Log.WithField("amount", amount).WithField("balance", balance).Error("Error payment exceeds balance")
+		return 0, nil, utils.NewValidationError(utils.FieldError("amount", fmt.Sprintf("Payment of %s exceeds the outstanding balance of %s", amount, balance)))
+	}
+	return amount, allocations, nil
+}
+
+// allocatePaymentItems prices the order lines a guest pays for with their
+// taxes and service charge, units already paid by captured payments can not
+// be paid again
+func allocatePaymentItems(ctx context.Context, adapters repository.Adapters, order domain.Order, items []dto.OrderItemRequest) (domain.Money, []domain.PaymentAllocation, error) {
+	lines, paid, err := getOrderLinesToPay(ctx, adapters, order.Id)
+	if err != nil {
+		return 0, nil, err
+	}
+	taxes, err := getOrderItemTaxes(ctx, adapters, order.Id)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var amount domain.Money
+	allocations := []domain.PaymentAllocation{}
+	seen := map[uuid.UUID]bool{}
+	for i, item := range items {
+		line, err := resolveOrderItem(lines, seen, i, item)
+		if err != nil {
+			return 0, nil, err
+		}
+		if left := line.Quantity - paid[line.Id]; item.Quantity > left {
+			logger.Log.WithField("order_menu_id", line.Id).WithField("left", left).Error("Error order line already paid")
+			return 0, nil, utils.NewConflictError(fmt.Sprintf("Only %d of %s left to pay", left, line.MenuName))
+		}
+
+		allocation := domain.PaymentAllocation{
+			OrderMenuId: line.Id,
+			MenuName:    line.MenuName,
+			Quantity:    item.Quantity,
+			Amount:      orderLineAmount(order, line, taxes[line.Id], item.Quantity),
+		}
+		amount += allocation.Amount
+		allocations = append(allocations, allocation)
+	}
+	return amount, allocations, nil
+}
+
+// getOrderLines returns the lines of an order by id
+func getOrderLines(ctx context.Context, adapters repository.Adapters, orderId uuid.UUID) (map[uuid.UUID]domain.OrderMenu, error) {
+	orderMenus, err := adapters.OrderMenuRepository.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order items")
+		return nil, utils.NewInternalError("Failed to get order items")
+	}
+	lines := map[uuid.UUID]domain.OrderMenu{}
+	for _, orderMenu := range orderMenus {
+		lines[orderMenu.Id] = orderMenu
+	}
+	return lines, nil
+}
+
+// getOrderLinesToPay returns the lines of an order by id with the units of
+// each already paid
+func getOrderLinesToPay(ctx context.Context, adapters repository.Adapters, orderId uuid.UUID) (map[uuid.UUID]domain.OrderMenu, map[uuid.UUID]int, error) {
+	lines, err := getOrderLines(ctx, adapters, orderId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	paid, err := adapters.PaymentAllocationRepository.SumCapturedQuantitiesByOrderId(ctx, orderId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get paid order items")
+		return nil, nil, utils.NewInternalError("Failed to get paid order items")
+	}
+	return lines, paid, nil
+}
+
+// resolveOrderItem finds the order line the i-th item of a request points
+// at, seen makes sure every line is listed once
+func resolveOrderItem(lines map[uuid.UUID]domain.OrderMenu, seen map[uuid.UUID]bool, i int, item dto.OrderItemRequest) (domain.OrderMenu, error) {
+	field := fmt.Sprintf("items[%d].order_menu_id", i)
+	orderMenuId, _ := uuid.Parse(item.OrderMenuId)
+	line, ok := lines[orderMenuId]
+	if !ok {
+		logger.Log.WithField("order_menu_id", item.OrderMenuId).Error("Error order line not found")
+		return domain.OrderMenu{}, utils.NewValidationError(utils.FieldError(field, "Item is not part of this order"))
+	}
+	if seen[orderMenuId] {
+		logger.Log.WithField("order_menu_id", item.OrderMenuId).Error("Error order line listed twice")
+		return domain.OrderMenu{}, utils.NewValidationError(utils.FieldError(field, "Item is listed more than once"))
+	}
+	seen[orderMenuId] = true
+	return line, nil
+}
+
+// checkPaymentFits makes sure a pending payment still fits the order before
+// it is captured, other guests may have paid or be paying in the meantime
+func checkPaymentFits(ctx context.Context, adapters repository.Adapters, order domain.Order, orderPayment domain.Payment) error {
+	payments, err := adapters.PaymentRepository.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payments")
+		return utils.NewInternalError("Failed to get payments")
+	}
+	balance := order.Balance
+	for _, other := range payments {
+		if other.Status == domain.PaymentStatusCapturing && other.Id != orderPayment.Id {
+			balance -= other.Amount - other.Rounding
+		}
+	}
+	if orderPayment.Amount-orderPayment.Rounding > balance {
+		logger.Log.WithField("amount", orderPayment.Amount).WithField("balance", balance).Error("Error payment exceeds balance")
+		return utils.NewConflictError(fmt.Sprintf("Payment of %s exceeds the outstanding balance of %s", orderPayment.Amount-orderPayment.Rounding, balance))
+	}
+
+	allocations, err := adapters.PaymentAllocationRepository.GetAllByPaymentId(ctx, orderPayment.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payment allocations")
+		return utils.NewInternalError("Failed to get payment allocations")
+	}
+	if len(allocations) == 0 {
+		return nil
+	}
+	lines, paid, err := getOrderLinesToPay(ctx, adapters, order.Id)
+	if err != nil {
+		return err
+	}
+	for _, allocation := range allocations {
+		if left := lines[allocation.OrderMenuId].Quantity - paid[allocation.OrderMenuId]; allocation.Quantity > left {
+			logger.Log.WithField("order_menu_id", allocation.OrderMenuId).WithField("left", left).Error("Error order line already paid")
+			return utils.NewConflictError(fmt.Sprintf("Only %d of %s left to pay", left, allocation.MenuName))
+		}
+	}
+	return nil
+}
+
+// beginCapture marks a pending payment as capturing once it still fits the
+// order, the caller commits it before the provider is asked. A payment that
+// is already capturing may be captured again, providers answer a repeated
+// capture with the outcome of the first one.
+func beginCapture(ctx context.Context, adapters repository.Adapters, order domain.Order, orderPayment *domain.Payment) error {
+	if orderPayment.Status == domain.PaymentStatusCapturing {
+		return nil
+	}
+	if orderPayment.Status != domain.PaymentStatusPending {
+		logger.Log.WithField("status", orderPayment.Status).Error("Error payment can not be captured")
+		return utils.NewConflictError(fmt.Sprintf("Payment can not be captured, status already '%s'", orderPayment.Status))
+	}
+	if err := checkOrderPayable(order); err != nil {
+		return err
+	}
+	if err := checkPaymentFits(ctx, adapters, order, *orderPayment); err != nil {
+		return err
+	}
+
+	orderPayment.Status = domain.PaymentStatusCapturing
+	err := adapters.PaymentRepository.UpdateStatus(ctx, orderPayment.Id, *orderPayment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update payment")
+		return utils.NewInternalError("Failed to update payment")
+	}
+	return nil
+}
+
+// capturePayment asks the provider to capture a payment committed as
+// capturing and applies the outcome. A decline is stored on the payment and
+// returned with status failed instead of an error. An excess the capture
+// left on the order is refunded once the outcome is committed.
+func (u *OrderUsecaseImpl) capturePayment(ctx context.Context, alerts *stockAlerts, orderPayment domain.Payment, actorId uuid.UUID) (domain.Payment, error) {
+	result, err := u.paymentProvider.Capture(ctx, orderPayment.ProviderReference, orderPayment.Amount)
+	if err != nil {
+		logger.Log.WithError(err).WithField("payment_id", orderPayment.Id).Error("Error failed to capture payment")
+		return orderPayment, utils.NewInternalError("Failed to capture payment with the payment provider")
+	}
+
+	requests := []paymentRefundRequest{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, orderPayment.OrderId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		current, err := adapters.PaymentRepository.GetOneByIdForUpdate(ctx, orderPayment.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get payment")
+			return utils.NewInternalError("Failed to get payment")
+		}
+		current.Allocations = orderPayment.Allocations
+		orderPayment = current
+
+		// the webhook of the provider may have settled the payment first
+		if orderPayment.Status != domain.PaymentStatusCapturing {
+			return nil
+		}
+		if result.Status != payment.StatusCaptured {
+			return failPayment(ctx, adapters, &orderPayment, result.FailureReason)
+		}
+		requests, err = u.applyCapturedPayment(ctx, adapters, alerts, order, &orderPayment, actorId, "Payment received")
+		return err
+	})
+	if err != nil {
+		logger.Log.WithError(err).WithField("payment_id", orderPayment.Id).Error("Error capture outcome not recorded, payment left capturing")
+		return orderPayment, err
+	}
+
+	// the capture stands, a refund the provider failed stays pending
+	if err := u.refundPayments(ctx, requests); err != nil {
+		logger.Log.WithError(err).WithField("payment_id", orderPayment.Id).Error("Error excess of the payment not refunded")
+	}
+	return orderPayment, nil
+}
+
+// failPayment records why the provider did not capture a payment
+func failPayment(ctx context.Context, adapters repository.Adapters, orderPayment *domain.Payment, reason string) error {
+	if reason == "" {
+		reason = "declined"
+	}
+	orderPayment.Status = domain.PaymentStatusFailed
+	orderPayment.FailureReason = &reason
+	err := adapters.PaymentRepository.UpdateStatus(ctx, orderPayment.Id, *orderPayment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update payment")
+		return utils.NewInternalError("Failed to update payment")
+	}
+	return nil
+}
+
+// applyCapturedPayment records a capture confirmed by the provider and adds
+// it to the paid amount of the order, the order is paid once nothing is left
+// to pay and an order that was already handed over is then finished. What
+// the order does not owe is reserved as a refund, the caller asks the
+// provider for it once the transaction is committed.
+func (u *OrderUsecaseImpl) applyCapturedPayment(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, order domain.Order, orderPayment *domain.Payment, actorId uuid.UUID, note string) ([]paymentRefundRequest, error) {
+	capturedAt := time.Now()
+	orderPayment.Status = domain.PaymentStatusCaptured
+	orderPayment.FailureReason = nil
+	orderPayment.CapturedAt = &capturedAt
+	err := adapters.PaymentRepository.UpdateStatus(ctx, orderPayment.Id, *orderPayment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update payment")
+		return nil, utils.NewInternalError("Failed to update payment")
+	}
+
+	// the cash rounding of the payment becomes part of the order total
+	if orderPayment.Rounding != 0 {
+		order.Rounding += orderPayment.Rounding
+		order.Amount += orderPayment.Rounding
+		err = adapters.OrderRepository.UpdateTotals(ctx, order.Id, order)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update order totals")
+			return nil, utils.NewInternalError("Failed to update order")
+		}
+	}
+
+	paidAmount := order.PaidAmount + orderPayment.Amount
+	paymentStatus := domain.OrderPaymentPartiallyPaid
+	if paidAmount >= order.Amount {
+		paymentStatus = domain.OrderPaymentPaid
+	}
+	method := orderPayment.Method
+	if order.PaidAmount > 0 && order.PaymentMethod != nil && *order.PaymentMethod != method {
+		method = splitPaymentMethod
+	}
+
+	err = adapters.OrderRepository.UpdatePayment(ctx, order.Id, domain.Order{
+		PaymentMethod: &method,
+		PaymentStatus: paymentStatus,
+		PaidAmount:    paidAmount,
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order payment")
+		return nil, utils.NewInternalError("Failed to update order payment")
+	}
+
+	// a capture confirmed late by the provider may land on a closed order or
+	// go beyond what is owed, the excess is given back instead of kept
+	excess := paidAmount - order.Amount
+	if order.Status == domain.OrderStatusCancelled || order.Status == domain.OrderStatusRefunded {
+		excess = orderPayment.Amount
+	}
+	requests := []paymentRefundRequest{}
+	if excess > 0 {
+		logger.Log.WithField("order_id", order.Id).WithField("payment_id", orderPayment.Id).WithField("excess", excess).Warn("Payment captured beyond the order amount, refunding the excess")
+		request, err := refundExcessPayment(ctx, adapters, order, orderPayment, excess, actorId)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+	}
+
+	if paymentStatus == domain.OrderPaymentPaid && canTransitionOrder(order.Status, domain.OrderStatusCompleted) {
+		err = u.changeOrderStatus(ctx, adapters, alerts, order, domain.OrderStatusCompleted, actorId, note)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return requests, nil
+}
+
+// refundExcessPayment records a refund of what a capture paid beyond the
+// order and reserves it against that payment
+func refundExcessPayment(ctx context.Context, adapters repository.Adapters, order domain.Order, orderPayment *domain.Payment, excess domain.Money, actorId uuid.UUID) (paymentRefundRequest, error) {
+	note := "Captured beyond what the order owes"
+	refund := domain.Refund{
+		Id:         uuid.New(),
+		OrderId:    order.Id,
+		Amount:     excess,
+		ReasonCode: domain.RefundReasonOvercharge,
+		Note:       &note,
+		CreatedBy:  &actorId,
+		Items:      []domain.RefundItem{},
+	}
+	err := adapters.RefundRepository.Create(ctx, refund)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create refund")
+		return paymentRefundRequest{}, utils.NewInternalError("Failed to create refund")
+	}
+	err = adapters.OrderRepository.UpdateRefundedAmount(ctx, order.Id, order.RefundedAmount+excess)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order refunded amount")
+		return paymentRefundRequest{}, utils.NewInternalError("Failed to update order")
+	}
+	return reservePaymentRefund(ctx, adapters, refund, orderPayment, excess)
+}
+
+// getOrderPayment loads and locks a payment and makes sure it belongs to the
+// order
+func getOrderPayment(ctx context.Context, adapters repository.Adapters, orderId, paymentId uuid.UUID) (domain.Payment, error) {
+	orderPayment, err := adapters.PaymentRepository.GetOneByIdForUpdate(ctx, paymentId)
+	if err != nil || orderPayment.OrderId != orderId {
+		logger.Log.WithError(err).WithField("payment_id", paymentId).Error("Error payment not found")
+		return domain.Payment{}, utils.NewNotFoundError("Payment not found")
+	}
+
+	orderPayment.Allocations, err = adapters.PaymentAllocationRepository.GetAllByPaymentId(ctx, paymentId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payment allocations")
+		return domain.Payment{}, utils.NewInternalError("Failed to get payment allocations")
+	}
+	return orderPayment, nil
+}
@@ -0,0 +1,223 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// The pricing helpers only work on the tax rates copied onto the order lines
+// so the breakdown of an order can be worked out again the same way after a
+// void, whatever happened to the tax rates since. Amounts are exact, only
+// applying a percentage rounds to the hundredth.
+
+// cashPaymentMethod is the payment method cash rounding applies to
+const cashPaymentMethod = "cash"
+
+// cashRounding rounds what is paid in cash to the smallest coin in use,
+// nothing is rounded without an increment
+type cashRounding struct {
+	increment domain.Money
+	mode      string
+}
+
+func (c cashRounding) round(amount domain.Money) domain.Money {
+	if c.increment <= 0 {
+		return amount
+	}
+	steps := amount / c.increment
+	remainder := amount % c.increment
+	switch c.mode {
+	case "up":
+		if remainder > 0 {
+			steps++
+		}
+	case "down":
+	default:
+		if remainder*2 >= c.increment {
+			steps++
+		}
+	}
+	return steps * c.increment
+}
+
+// applicableTaxRates picks the rates of a menu category, a category without
+// rates of its own falls back to the rates without a category
+func applicableTaxRates(rates []domain.TaxRate, category string) []domain.TaxRate {
+	specific := []domain.TaxRate{}
+	general := []domain.TaxRate{}
+	for _, rate := range rates {
+		switch {
+		case rate.Category == nil:
+			general = append(general, rate)
+		case *rate.Category == category:
+			specific = append(specific, rate)
+		}
+	}
+	if len(specific) > 0 {
+		return specific
+	}
+	return general
+}
+
+// orderItemTaxes copies the rates charged on an order line onto it
+func orderItemTaxes(orderId, orderMenuId uuid.UUID, rates []domain.TaxRate) []domain.OrderItemTax {
+	taxes := []domain.OrderItemTax{}
+	for _, rate := range rates {
+		taxRateId := rate.Id
+		taxes = append(taxes, domain.OrderItemTax{
+			Id:          uuid.New(),
+			OrderId:     orderId,
+			OrderMenuId: orderMenuId,
+			TaxRateId:   &taxRateId,
+			Name:        rate.Name,
+			RatePercent: rate.RatePercent,
+			Inclusive:   rate.Inclusive,
+		})
+	}
+	return taxes
+}
+
+// netLinePrice takes the inclusive taxes out of the price of a number of
+// units of a line, every tax of the line is charged on what is left
+func netLinePrice(line domain.OrderMenu, taxes []domain.OrderItemTax, quantity int) domain.Money {
+	var inclusivePercent float64
+	for _, tax := range taxes {
+		if tax.Inclusive {
+			inclusivePercent += tax.RatePercent
+		}
+	}
+	return line.UnitPrice.Mul(quantity).ExcludePercent(inclusivePercent)
+}
+
+// orderLineAmount is what a number of units of a line add to the total of
+// the order: their price, their exclusive taxes and their share of the
+// service charge. Payments, refunds and voids of order lines use it.
+func orderLineAmount(order domain.Order, line domain.OrderMenu, taxes []domain.OrderItemTax, quantity int) domain.Money {
+	net := netLinePrice(line, taxes, quantity)
+	amount := line.UnitPrice.Mul(quantity) + net.MulPercent(order.ServiceChargePercent)
+	for _, tax := range taxes {
+		if !tax.Inclusive {
+			amount += net.MulPercent(tax.RatePercent)
+		}
+	}
+	return amount
+}
+
+// calculateOrderBreakdown works out the subtotal, the tax lines, the service
+// charge and the total of an order from its lines. The service charge is
+// charged on the price without taxes and is not taxed itself, each tax is
+// charged once on everything its rate applies to. The rounding already on the
+// order is kept.
+func calculateOrderBreakdown(order *domain.Order, lines []domain.OrderMenu, taxes map[uuid.UUID][]domain.OrderItemTax) {
+	type taxKey struct {
+		taxRateId   uuid.UUID
+		name        string
+		ratePercent float64
+		inclusive   bool
+	}
+
+	var subtotal, net domain.Money
+	taxLines := []domain.OrderTaxLine{}
+	index := map[taxKey]int{}
+	for _, line := range lines {
+		if line.Quantity <= 0 {
+			continue
+		}
+		lineNet := netLinePrice(line, taxes[line.Id], line.Quantity)
+		subtotal += line.UnitPrice.Mul(line.Quantity)
+		net += lineNet
+
+		for _, tax := range taxes[line.Id] {
+			key := taxKey{name: tax.Name, ratePercent: tax.RatePercent, inclusive: tax.Inclusive}
+			if tax.TaxRateId != nil {
+				key.taxRateId = *tax.TaxRateId
+			}
+			i, ok := index[key]
+			if !ok {
+				i = len(taxLines)
+				index[key] = i
+				taxLines = append(taxLines, domain.OrderTaxLine{
+					Id:          uuid.New(),
+					OrderId:     order.Id,
+					TaxRateId:   tax.TaxRateId,
+					Name:        tax.Name,
+					RatePercent: tax.RatePercent,
+					Inclusive:   tax.Inclusive,
+				})
+			}
+			taxLines[i].TaxableAmount += lineNet
+		}
+	}
+
+	var taxTotal domain.Money
+	for i := range taxLines {
+		taxLines[i].Amount = taxLines[i].TaxableAmount.MulPercent(taxLines[i].RatePercent)
+		if !taxLines[i].Inclusive {
+			taxTotal += taxLines[i].Amount
+		}
+	}
+
+	order.Subtotal = subtotal
+	order.TaxTotal = taxTotal
+	order.ServiceCharge = net.MulPercent(order.ServiceChargePercent)
+	order.Amount = order.Subtotal + order.TaxTotal + order.ServiceCharge + order.Rounding
+	order.TaxLines = taxLines
+}
+
+// getOrderItemTaxes returns the rates charged on each line of an order
+func getOrderItemTaxes(ctx context.Context, adapters repository.Adapters, orderId uuid.UUID) (map[uuid.UUID][]domain.OrderItemTax, error) {
+	itemTaxes, err := adapters.OrderItemTaxRepository.GetAllByOrderId(ctx, orderId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order item taxes")
+		return nil, utils.NewInternalError("Failed to get order item taxes")
+	}
+	taxes := map[uuid.UUID][]domain.OrderItemTax{}
+	for _, tax := range itemTaxes {
+		taxes[tax.OrderMenuId] = append(taxes[tax.OrderMenuId], tax)
+	}
+	return taxes, nil
+}
+
+// saveOrderTaxLines replaces the tax lines of an order
+func saveOrderTaxLines(ctx context.Context, adapters repository.Adapters, order domain.Order) error {
+	err := adapters.OrderTaxLineRepository.DeleteByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to clear order tax lines")
+		return utils.NewInternalError("Failed to update order taxes")
+	}
+	for _, taxLine := range order.TaxLines {
+		err = adapters.OrderTaxLineRepository.Create(ctx, taxLine)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create order tax line")
+			return utils.NewInternalError("Failed to update order taxes")
+		}
+	}
+	return nil
+}
+
+// repriceOrder works the breakdown of an order out again after its lines
+// changed and stores it, the new total is left on order
+func repriceOrder(ctx context.Context, adapters repository.Adapters, order *domain.Order) error {
+	orderMenus, err := adapters.OrderMenuRepository.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order items")
+		return utils.NewInternalError("Failed to get order items")
+	}
+	taxes, err := getOrderItemTaxes(ctx, adapters, order.Id)
+	if err != nil {
+		return err
+	}
+
+	calculateOrderBreakdown(order, orderMenus, taxes)
+	err = adapters.OrderRepository.UpdateTotals(ctx, order.Id, *order)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order totals")
+		return utils.NewInternalError("Failed to update order")
+	}
+	return saveOrderTaxLines(ctx, adapters, *order)
+}
@@ -0,0 +1,492 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// refundApprovalObject and refundApprovalAction are checked against the
+// Casbin policy for refunds above the approval threshold
+const (
+	refundApprovalObject = "refunds"
+	refundApprovalAction = "approve_above_threshold"
+)
+
+// authorizeRefund lets refunds up to the approval threshold through, larger
+// refunds need a role the policy allows to approve them. It reports whether
+// the actor approved the refund.
+func (u *OrderUsecaseImpl) authorizeRefund(role string, amount domain.Money) (bool, error) {
+	if amount <= u.refundApprovalThreshold {
+		return false, nil
+	}
+
+	allowed, err := u.enforcer.Enforce(role, refundApprovalObject, refundApprovalAction)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error invalid permission")
+		return false, utils.NewInternalError("Authorization check failed")
+	}
+	if !allowed {
+		logger.Log.WithField("role", role).WithField("amount", amount).Error("Error refund needs a manager")
+		return false, utils.NewForbiddenError(fmt.Sprintf("Refunds above %s need a manager", u.refundApprovalThreshold))
+	}
+	return true, nil
+}
+
+// refundOrderItems prices the units of order lines being refunded with their
+// taxes and service charge, a unit can only be refunded once
+func refundOrderItems(ctx context.Context, adapters repository.Adapters, order domain.Order, items []dto.OrderItemRequest) (domain.Money, []domain.RefundItem, []domain.OrderMenu, error) {
+	lines, err := getOrderLines(ctx, adapters, order.Id)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+	refunded, err := adapters.RefundItemRepository.SumQuantitiesByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get refunded order items")
+		return 0, nil, nil, utils.NewInternalError("Failed to get refunded order items")
+	}
+	taxes, err := getOrderItemTaxes(ctx, adapters, order.Id)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var amount domain.Money
+	refundItems := []domain.RefundItem{}
+	orderMenus := []domain.OrderMenu{}
+	seen := map[uuid.UUID]bool{}
+	for i, item := range items {
+		line, err := resolveOrderItem(lines, seen, i, item)
+		if err != nil {
+			return 0, nil, nil, err
+		}
+		if left := line.Quantity - refunded[line.Id]; item.Quantity > left {
+			logger.Log.WithField("order_menu_id", line.Id).WithField("left", left).Error("Error order line already refunded")
+			return 0, nil, nil, utils.NewConflictError(fmt.Sprintf("Only %d of %s left to refund", left, line.MenuName))
+		}
+
+		refundItem := domain.RefundItem{
+			OrderMenuId: line.Id,
+			MenuName:    line.MenuName,
+			Quantity:    item.Quantity,
+			Amount:      orderLineAmount(order, line, taxes[line.Id], item.Quantity),
+		}
+		amount += refundItem.Amount
+		refundItems = append(refundItems, refundItem)
+		line.Quantity = item.Quantity
+		orderMenus = append(orderMenus, line)
+	}
+	return amount, refundItems, orderMenus, nil
+}
+
+// paymentRefundRequest is a refund recorded as pending that still has to be
+// made by the provider
+type paymentRefundRequest struct {
+	paymentRefund domain.PaymentRefund
+	reference     string
+}
+
+// reservePaymentRefunds takes the amount off the newest captured payments
+// first and records what each of them gives back as pending, the provider is
+// only asked once they are committed
+func reservePaymentRefunds(ctx context.Context, adapters repository.Adapters, order domain.Order, refund domain.Refund) ([]paymentRefundRequest, error) {
+	payments, err := adapters.PaymentRepository.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payments")
+		return nil, utils.NewInternalError("Failed to get payments")
+	}
+
+	requests := []paymentRefundRequest{}
+	remaining := refund.Amount
+	for i := len(payments) - 1; i >= 0 && remaining > 0; i-- {
+		orderPayment := payments[i]
+		refundable := orderPayment.Amount - orderPayment.RefundedAmount
+		if orderPayment.Status != domain.PaymentStatusCaptured || refundable <= 0 {
+			continue
+		}
+		portion := remaining
+		if refundable < portion {
+			portion = refundable
+		}
+
+		request, err := reservePaymentRefund(ctx, adapters, refund, &orderPayment, portion)
+		if err != nil {
+			return nil, err
+		}
+		requests = append(requests, request)
+		remaining -= portion
+	}
+
+	if remaining > 0 {
+		logger.Log.WithField("order_id", order.Id).WithField("uncovered", remaining).Error("Error refund not covered by captured payments")
+		return nil, utils.NewConflictError(fmt.Sprintf("Captured payments only cover %s of the refund", refund.Amount-remaining))
+	}
+	return requests, nil
+}
+
+// reservePaymentRefund records part of a captured payment as given back by a
+// refund, the provider is asked once it is committed
+func reservePaymentRefund(ctx context.Context, adapters repository.Adapters, refund domain.Refund, orderPayment *domain.Payment, amount domain.Money) (paymentRefundRequest, error) {
+	orderPayment.RefundedAmount += amount
+	if orderPayment.RefundedAmount >= orderPayment.Amount {
+		orderPayment.Status = domain.PaymentStatusRefunded
+	}
+	err := adapters.PaymentRepository.UpdateStatus(ctx, orderPayment.Id, *orderPayment)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update payment")
+		return paymentRefundRequest{}, utils.NewInternalError("Failed to update payment")
+	}
+
+	paymentRefund := domain.PaymentRefund{
+		Id:        uuid.New(),
+		RefundId:  refund.Id,
+		PaymentId: orderPayment.Id,
+		Amount:    amount,
+		Status:    domain.PaymentRefundStatusPending,
+	}
+	err = adapters.PaymentRefundRepository.Create(ctx, paymentRefund)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create payment refund")
+		return paymentRefundRequest{}, utils.NewInternalError("Failed to create payment refund")
+	}
+	return paymentRefundRequest{paymentRefund: paymentRefund, reference: orderPayment.ProviderReference}, nil
+}
+
+// refundPayments asks the provider for the refunds reserved by
+// reservePaymentRefunds and completes each one it confirms. A refund the
+// provider failed stays pending to be reconciled, it is never asked twice.
+func (u *OrderUsecaseImpl) refundPayments(ctx context.Context, requests []paymentRefundRequest) error {
+	for _, request := range requests {
+		paymentRefund := request.paymentRefund
+		result, err := u.paymentProvider.Refund(ctx, request.reference, paymentRefund.Amount)
+		if err != nil {
+			logger.Log.WithError(err).WithField("payment_refund_id", paymentRefund.Id).Error("Error failed to refund payment")
+			return utils.NewInternalError("Refund recorded but the payment provider failed to give the money back, it is left pending")
+		}
+		logger.Log.WithField("payment_id", paymentRefund.PaymentId).WithField("reference", result.Reference).WithField("amount", paymentRefund.Amount).Info("Payment refunded")
+
+		err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+			return adapters.PaymentRefundRepository.Complete(ctx, paymentRefund.Id, result.Reference)
+		})
+		if err != nil {
+			logger.Log.WithError(err).WithField("payment_refund_id", paymentRefund.Id).Error("Error payment refunded but not completed")
+			return utils.NewInternalError("Failed to update payment refund")
+		}
+	}
+	return nil
+}
+
+func (u *OrderUsecaseImpl) CreateRefund(ctx context.Context, id, actorId uuid.UUID, role string, req dto.CreateRefundRequest) (domain.Refund, error) {
+	result := domain.Refund{}
+	alerts := stockAlerts{}
+	requests := []paymentRefundRequest{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+		if req.Amount > 0 && len(req.Items) > 0 {
+			logger.Log.Error("Error refund given both amount and items")
+			return utils.NewValidationError(utils.FieldError("amount", "Use either amount or items"))
+		}
+		if req.Restock && len(req.Items) == 0 {
+			logger.Log.Error("Error restock without refunded items")
+			return utils.NewValidationError(utils.FieldError("restock", "Only refunded items can be returned to inventory"))
+		}
+
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		refundable := order.PaidAmount - order.RefundedAmount
+		if refundable <= 0 {
+			logger.Log.WithField("order_id", id).Error("Error order has nothing to refund")
+			return utils.NewConflictError("Order has nothing left to refund")
+		}
+
+		refund := domain.Refund{
+			Id:         uuid.New(),
+			OrderId:    id,
+			Amount:     refundable,
+			ReasonCode: req.ReasonCode,
+			Restocked:  req.Restock && order.InventoryDeducted,
+			CreatedBy:  &actorId,
+			Items:      []domain.RefundItem{},
+		}
+		if req.Note != "" {
+			refund.Note = &req.Note
+		}
+
+		var refundedMenus []domain.OrderMenu
+		switch {
+		case req.Amount > 0:
+			refund.Amount = req.Amount
+		case len(req.Items) > 0:
+			refund.Amount, refund.Items, refundedMenus, err = refundOrderItems(ctx, adapters, order, req.Items)
+			if err != nil {
+				return err
+			}
+		}
+		if refund.Amount <= 0 {
+			logger.Log.WithField("amount", refund.Amount).Error("Error refund amount not positive")
+			return utils.NewValidationError(utils.FieldError("amount", "Refund amount must be at least 0.01"))
+		}
+		if refund.Amount > refundable {
+			logger.Log.WithField("amount", refund.Amount).WithField("refundable", refundable).Error("Error refund exceeds paid amount")
+			return utils.NewValidationError(utils.FieldError("amount", fmt.Sprintf("Refund of %s exceeds the %s left to refund", refund.Amount, refundable)))
+		}
+
+		approved, err := u.authorizeRefund(role, refund.Amount)
+		if err != nil {
+			return err
+		}
+		if approved {
+			refund.ApprovedBy = &actorId
+		}
+
+		err = adapters.RefundRepository.Create(ctx, refund)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create refund")
+			return utils.NewInternalError("Failed to create refund")
+		}
+		for i := range refund.Items {
+			refund.Items[i].Id = uuid.New()
+			refund.Items[i].RefundId = refund.Id
+			err = adapters.RefundItemRepository.Create(ctx, refund.Items[i])
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create refund item")
+				return utils.NewInternalError("Failed to create refund item")
+			}
+		}
+
+		refundedAmount := order.RefundedAmount + refund.Amount
+		err = adapters.OrderRepository.UpdateRefundedAmount(ctx, id, refundedAmount)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update order refunded amount")
+			return utils.NewInternalError("Failed to update order")
+		}
+
+		if refund.Restocked {
+			err = restockOrderItems(ctx, adapters, &alerts, order, refundedMenus, "Order items refunded", actorId)
+			if err != nil {
+				return err
+			}
+		}
+
+		// a finished order that got all its money back is refunded, no other
+		// path may set that status
+		if refundedAmount >= order.PaidAmount && order.Status == domain.OrderStatusCompleted {
+			err = adapters.OrderRepository.UpdateOrderStatus(ctx, id, domain.Order{Status: domain.OrderStatusRefunded})
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update order status")
+				return utils.NewInternalError("Failed to update order status")
+			}
+			err = u.recordOrderStatus(ctx, adapters, id, &order.Status, domain.OrderStatusRefunded, actorId, req.ReasonCode)
+			if err != nil {
+				return err
+			}
+		}
+
+		requests, err = reservePaymentRefunds(ctx, adapters, order, refund)
+		if err != nil {
+			return err
+		}
+		result = refund
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+
+	if err := u.refundPayments(ctx, requests); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *OrderUsecaseImpl) GetRefunds(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.Refund, error) {
+	result := []domain.Refund{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		if role == "customer" && order.UserId != userId {
+			logger.Log.WithField("user_id", userId).WithField("order.user_id", order.UserId).Error("Error order belongs to another user")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		refunds, err := adapters.RefundRepository.GetAllByOrderId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get refunds")
+			return utils.NewInternalError("Failed to get refunds")
+		}
+		items, err := adapters.RefundItemRepository.GetAllByOrderId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get refund items")
+			return utils.NewInternalError("Failed to get refund items")
+		}
+
+		index := map[uuid.UUID]int{}
+		for i, refund := range refunds {
+			index[refund.Id] = i
+		}
+		for _, item := range items {
+			i := index[item.RefundId]
+			refunds[i].Items = append(refunds[i].Items, item)
+		}
+		result = refunds
+		return nil
+	})
+	return result, err
+}
+
+// CreateVoid takes unpaid units off an order, the breakdown of the order is
+// worked out again and its total may never drop below what was already paid
+func (u *OrderUsecaseImpl) CreateVoid(ctx context.Context, id, actorId uuid.UUID, req dto.CreateOrderVoidRequest) ([]domain.OrderVoid, error) {
+	result := []domain.OrderVoid{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		switch order.Status {
+		case domain.OrderStatusCompleted, domain.OrderStatusCancelled, domain.OrderStatusRefunded:
+			logger.Log.WithField("status", order.Status).Error("Error order items can not be voided")
+			return utils.NewConflictError(fmt.Sprintf("Items can not be voided, order already '%s'", order.Status))
+		}
+
+		lines, paid, err := getOrderLinesToPay(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+		taxes, err := getOrderItemTaxes(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+
+		voidedMenus := []domain.OrderMenu{}
+		seen := map[uuid.UUID]bool{}
+		for i, item := range req.Items {
+			line, err := resolveOrderItem(lines, seen, i, item)
+			if err != nil {
+				return err
+			}
+			if left := line.Quantity - paid[line.Id]; item.Quantity > left {
+				logger.Log.WithField("order_menu_id", line.Id).WithField("left", left).Error("Error order line already paid")
+				return utils.NewConflictError(fmt.Sprintf("Only %d of %s are unpaid", left, line.MenuName))
+			}
+
+			void := domain.OrderVoid{
+				Id:          uuid.New(),
+				OrderId:     id,
+				OrderMenuId: line.Id,
+				MenuName:    line.MenuName,
+				Quantity:    item.Quantity,
+				Amount:      orderLineAmount(order, line, taxes[line.Id], item.Quantity),
+				ReasonCode:  req.ReasonCode,
+				Restocked:   req.Restock && order.InventoryDeducted,
+				CreatedBy:   &actorId,
+			}
+			if req.Note != "" {
+				void.Note = &req.Note
+			}
+
+			err = adapters.OrderMenuRepository.UpdateQuantity(ctx, line.Id, line.Quantity-item.Quantity)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update order item")
+				return utils.NewInternalError("Failed to update order item")
+			}
+			err = adapters.OrderVoidRepository.Create(ctx, void)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create order void")
+				return utils.NewInternalError("Failed to create order void")
+			}
+
+			line.Quantity = item.Quantity
+			voidedMenus = append(voidedMenus, line)
+			result = append(result, void)
+		}
+
+		repriced := order
+		if err := repriceOrder(ctx, adapters, &repriced); err != nil {
+			return err
+		}
+		newAmount := repriced.Amount
+		if newAmount < order.PaidAmount {
+			logger.Log.WithField("amount", newAmount).WithField("paid_amount", order.PaidAmount).Error("Error void below paid amount")
+			return utils.NewConflictError(fmt.Sprintf("Voiding would leave the order below the %s already paid, refund instead", order.PaidAmount))
+		}
+
+		if req.Restock && order.InventoryDeducted {
+			err = restockOrderItems(ctx, adapters, &alerts, order, voidedMenus, "Order items voided", actorId)
+			if err != nil {
+				return err
+			}
+		}
+
+		// what was already paid may now cover the whole order
+		if order.PaidAmount > 0 && order.PaymentStatus != domain.OrderPaymentPaid && newAmount <= order.PaidAmount {
+			err = adapters.OrderRepository.UpdatePayment(ctx, id, domain.Order{
+				PaymentMethod: order.PaymentMethod,
+				PaymentStatus: domain.OrderPaymentPaid,
+				PaidAmount:    order.PaidAmount,
+			})
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update order payment")
+				return utils.NewInternalError("Failed to update order payment")
+			}
+			if canTransitionOrder(order.Status, domain.OrderStatusCompleted) {
+				err = u.changeOrderStatus(ctx, adapters, &alerts, order, domain.OrderStatusCompleted, actorId, "Remaining items voided")
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+func (u *OrderUsecaseImpl) GetVoids(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.OrderVoid, error) {
+	result := []domain.OrderVoid{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		if role == "customer" && order.UserId != userId {
+			logger.Log.WithField("user_id", userId).WithField("order.user_id", order.UserId).Error("Error order belongs to another user")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		voids, err := adapters.OrderVoidRepository.GetAllByOrderId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get order voids")
+			return utils.NewInternalError("Failed to get order voids")
+		}
+		result = voids
+		return nil
+	})
+	return result, err
+}
@@ -10,7 +10,18 @@ import (
 
 type OrderUsecase interface {
 	Create(ctx context.Context, req dto.CreateOrderDto, userId uuid.UUID) (domain.Order, error)
-	GetOneById(ctx context.Context, id uuid.UUID) (domain.Order, error)
-	UpdateOrderStatus(ctx context.Context, id uuid.UUID, req dto.UpdateOrderStatusDto) (domain.Order, error)
-	UpdatePayment(ctx context.Context, id uuid.UUID, req dto.UpdatePaymentDto) (domain.Order, error)
+	GetOneById(ctx context.Context, id, userId uuid.UUID, role string) (domain.Order, error)
+	GetAll(ctx context.Context, req dto.GetOrdersRequest, userId uuid.UUID, role string) (domain.OrderList, error)
+	GetStatusHistory(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.OrderStatusHistory, error)
+	UpdateOrderStatus(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateOrderStatusDto) (domain.Order, error)
+	Cancel(ctx context.Context, id, userId uuid.UUID, role string, req dto.CancelOrderDto) (domain.Order, error)
+	UpdatePayment(ctx context.Context, id, actorId uuid.UUID, req dto.UpdatePaymentDto) (domain.Order, error)
+	GetPayments(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.Payment, error)
+	CreatePayment(ctx context.Context, id, actorId uuid.UUID, req dto.CreatePaymentRequest) (domain.Payment, error)
+	CapturePayment(ctx context.Context, id, paymentId, actorId uuid.UUID) (domain.Payment, error)
+	HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error
+	GetRefunds(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.Refund, error)
+	CreateRefund(ctx context.Context, id, actorId uuid.UUID, role string, req dto.CreateRefundRequest) (domain.Refund, error)
+	GetVoids(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.OrderVoid, error)
+	CreateVoid(ctx context.Context, id, actorId uuid.UUID, req dto.CreateOrderVoidRequest) ([]domain.OrderVoid, error)
 }
@@ -3,33 +3,168 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/casbin/casbin/v2"
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
 	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/payment"
 	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/config"
 	"github.com/ryvasa/go-restaurant/pkg/logger"
 	"github.com/ryvasa/go-restaurant/utils"
 )
 
+// orderStatusTransitions lists every status an order may move to from its current status
+var orderStatusTransitions = map[string][]string{
+	domain.OrderStatusPending:   {domain.OrderStatusAccepted, domain.OrderStatusCancelled},
+	domain.OrderStatusAccepted:  {domain.OrderStatusPreparing, domain.OrderStatusCancelled},
+	domain.OrderStatusPreparing: {domain.OrderStatusReady, domain.OrderStatusCancelled},
+	domain.OrderStatusReady:     {domain.OrderStatusServed, domain.OrderStatusPickedUp, domain.OrderStatusCancelled},
+	domain.OrderStatusServed:    {domain.OrderStatusCompleted},
+	domain.OrderStatusPickedUp:  {domain.OrderStatusCompleted},
+	domain.OrderStatusCompleted: {},
+	domain.OrderStatusCancelled: {},
+	domain.OrderStatusRefunded:  {},
+}
+
+// nextOrderStatus is used when staff advance an order without naming the status
+var nextOrderStatus = map[string]string{
+	domain.OrderStatusPending:   domain.OrderStatusAccepted,
+	domain.OrderStatusAccepted:  domain.OrderStatusPreparing,
+	domain.OrderStatusPreparing: domain.OrderStatusReady,
+	domain.OrderStatusServed:    domain.OrderStatusCompleted,
+	domain.OrderStatusPickedUp:  domain.OrderStatusCompleted,
+}
+
+// customerCancellableStatuses are the statuses before preparation starts
+var customerCancellableStatuses = map[string]bool{
+	domain.OrderStatusPending:  true,
+	domain.OrderStatusAccepted: true,
+}
+
+func canTransitionOrder(from, to string) bool {
+	for _, status := range orderStatusTransitions[from] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
 type OrderUsecaseImpl struct {
-	orderRepo     repository.OrderRepository
-	menuRepo      repository.MenuRepository
-	userRepo      repository.UserRepository
-	orderMenuRepo repository.OrderMenuRepository
-	txRepo        repository.TransactionRepository
+	orderRepo               repository.OrderRepository
+	menuRepo                repository.MenuRepository
+	userRepo                repository.UserRepository
+	orderMenuRepo           repository.OrderMenuRepository
+	orderStatusHistoryRepo  repository.OrderStatusHistoryRepository
+	orderTaxLineRepo        repository.OrderTaxLineRepository
+	stockAlertNotifier      notifier.StockAlertNotifier
+	paymentProvider         payment.PaymentProvider
+	currency                string
+	enforcer                *casbin.Enforcer
+	refundApprovalThreshold domain.Money
+	serviceChargePercent    float64
+	cashRounding            cashRounding
+	txRepo                  repository.TransactionRepository
 }
 
-func NewOrderUsecase(orderRepo repository.OrderRepository, menuRepo repository.MenuRepository, userRepo repository.UserRepository, orderMenuRepo repository.OrderMenuRepository, txRepo repository.TransactionRepository) OrderUsecase {
+func NewOrderUsecase(cfg *config.Config, orderRepo repository.OrderRepository, menuRepo repository.MenuRepository, userRepo repository.UserRepository, orderMenuRepo repository.OrderMenuRepository, orderStatusHistoryRepo repository.OrderStatusHistoryRepository, orderTaxLineRepo repository.OrderTaxLineRepository, stockAlertNotifier notifier.StockAlertNotifier, paymentProvider payment.PaymentProvider, enforcer *casbin.Enforcer, txRepo repository.TransactionRepository) OrderUsecase {
 	return &OrderUsecaseImpl{
 		orderRepo,
 		menuRepo,
 		userRepo,
 		orderMenuRepo,
+		orderStatusHistoryRepo,
+		orderTaxLineRepo,
+		stockAlertNotifier,
+		paymentProvider,
+		cfg.Payment.Currency,
+		enforcer,
+		domain.MoneyFromFloat(cfg.Refund.ApprovalThreshold),
+		cfg.Pricing.ServiceChargePercent,
+		cashRounding{domain.MoneyFromFloat(cfg.Pricing.CashRoundingIncrement), cfg.Pricing.CashRoundingMode},
 		txRepo,
 	}
 }
 
+// checkOrderCancellable refuses to cancel an order that holds money or has a
+// payment in flight, that money only goes back through a refund
+func checkOrderCancellable(ctx context.Context, adapters repository.Adapters, order domain.Order) error {
+	if kept := order.PaidAmount - order.RefundedAmount; kept > 0 {
+		logger.Log.WithField("order_id", order.Id).WithField("kept", kept).Error("Error cancelling a paid order")
+		return utils.NewConflictError(fmt.Sprintf("Order has %s paid, refund it or void the unpaid items instead of cancelling", kept))
+	}
+
+	payments, err := adapters.PaymentRepository.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get payments")
+		return utils.NewInternalError("Failed to get payments")
+	}
+	for _, orderPayment := range payments {
+		if orderPayment.Status == domain.PaymentStatusPending || orderPayment.Status == domain.PaymentStatusCapturing {
+			logger.Log.WithField("order_id", order.Id).WithField("payment_id", orderPayment.Id).Error("Error cancelling an order with a payment in flight")
+			return utils.NewConflictError(fmt.Sprintf("Order has a payment that is still '%s', settle and refund it instead of cancelling", orderPayment.Status))
+		}
+	}
+	return nil
+}
+
+// changeOrderStatus enforces the transition table, keeps inventory in step with
+// the order and records who made the change
+func (u *OrderUsecaseImpl) changeOrderStatus(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, order domain.Order, status string, actorId uuid.UUID, note string) error {
+	if !canTransitionOrder(order.Status, status) {
+		logger.Log.WithField("from", order.Status).WithField("to", status).Error("Error invalid order status transition")
+		return utils.NewBadRequestError(fmt.Sprintf("Invalid status transition from '%s' to '%s'", order.Status, status))
+	}
+	if status == domain.OrderStatusCancelled {
+		if err := checkOrderCancellable(ctx, adapters, order); err != nil {
+			return err
+		}
+	}
+
+	switch {
+	case (status == domain.OrderStatusPreparing || status == domain.OrderStatusCompleted) && !order.InventoryDeducted:
+		if err := deductOrderInventory(ctx, adapters, alerts, order, actorId); err != nil {
+			return err
+		}
+	case status == domain.OrderStatusCancelled && order.InventoryDeducted:
+		if err := restoreOrderInventory(ctx, adapters, alerts, order, actorId); err != nil {
+			return err
+		}
+	}
+
+	err := adapters.OrderRepository.UpdateOrderStatus(ctx, order.Id, domain.Order{Status: status})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update order status")
+		return utils.NewInternalError("Failed to update order status")
+	}
+
+	return u.recordOrderStatus(ctx, adapters, order.Id, &order.Status, status, actorId, note)
+}
+
+func (u *OrderUsecaseImpl) recordOrderStatus(ctx context.Context, adapters repository.Adapters, orderId uuid.UUID, from *string, to string, actorId uuid.UUID, note string) error {
+	history := domain.OrderStatusHistory{
+		Id:         uuid.New(),
+		OrderId:    orderId,
+		FromStatus: from,
+		ToStatus:   to,
+		ChangedBy:  actorId,
+	}
+	if note != "" {
+		history.Note = &note
+	}
+
+	err := adapters.OrderStatusHistoryRepository.Create(ctx, history)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create order status history")
+		return utils.NewInternalError("Failed to create order status history")
+	}
+	return nil
+}
+
 func (u *OrderUsecaseImpl) Create(ctx context.Context, req dto.CreateOrderDto, userId uuid.UUID) (domain.Order, error) {
 	result := domain.Order{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
@@ -44,7 +179,27 @@ func (u *OrderUsecaseImpl) Create(ctx context.Context, req dto.CreateOrderDto, u
 			logger.Log.WithError(err).Error("Error user not found")
 			return utils.NewNotFoundError("User not found, order rejected")
 		}
-		var amount float64
+		order := domain.Order{
+			Id:        uuid.New(),
+			UserId:    user.Id,
+			OrderType: domain.OrderTypeTakeaway,
+			Currency:  u.currency,
+		}
+		if req.OrderType != "" {
+			order.OrderType = req.OrderType
+		}
+		if order.OrderType == domain.OrderTypeDineIn {
+			order.ServiceChargePercent = u.serviceChargePercent
+		}
+
+		rates, err := adapters.TaxRateRepository.GetAll(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get tax rates")
+			return utils.NewInternalError("Failed to get tax rates")
+		}
+
+		orderMenus := []domain.OrderMenu{}
+		taxes := map[uuid.UUID][]domain.OrderItemTax{}
 		for _, menu := range req.Menu {
 			menuId, err := uuid.Parse(menu.MenuId)
 			if err != nil {
@@ -58,13 +213,27 @@ func (u *OrderUsecaseImpl) Create(ctx context.Context, req dto.CreateOrderDto, u
 				return utils.NewNotFoundError("Menu not found")
 			}
 
-			amount += (existingMenu.Price * float64(menu.Quantity))
+			// snapshot name, price and taxes so later changes don't rewrite what was paid
+			orderMenu := domain.OrderMenu{
+				Id:        uuid.New(),
+				OrderId:   order.Id,
+				MenuId:    existingMenu.Id,
+				MenuName:  existingMenu.Name,
+				Quantity:  menu.Quantity,
+				UnitPrice: existingMenu.Price,
+			}
+			orderMenus = append(orderMenus, orderMenu)
+			taxes[orderMenu.Id] = orderItemTaxes(order.Id, orderMenu.Id, applicableTaxRates(rates, existingMenu.Category))
 		}
+		calculateOrderBreakdown(&order, orderMenus, taxes)
 
-		order := domain.Order{
-			Id:     uuid.New(),
-			UserId: user.Id,
-			Amount: amount,
+		// reject orders the kitchen can't make with the current stock
+		usages, err := calculateOrderIngredientUsage(ctx, adapters, orderMenus, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := checkIngredientStock(ctx, adapters, usages, nil); err != nil {
+			return err
 		}
 
 		err = adapters.OrderRepository.Create(ctx, order)
@@ -73,29 +242,41 @@ func (u *OrderUsecaseImpl) Create(ctx context.Context, req dto.CreateOrderDto, u
 			return utils.NewInternalError("Failed to create order")
 		}
 
+		for _, orderMenu := range orderMenus {
+			err = adapters.OrderMenuRepository.Create(ctx, orderMenu)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create order menu")
+				return utils.NewInternalError("Failed to create order menu")
+			}
+			for _, tax := range taxes[orderMenu.Id] {
+				err = adapters.OrderItemTaxRepository.Create(ctx, tax)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error failed to create order item tax")
+					return utils.NewInternalError("Failed to create order menu")
+				}
+			}
+		}
+		if err := saveOrderTaxLines(ctx, adapters, order); err != nil {
+			return err
+		}
+
+		err = u.recordOrderStatus(ctx, adapters, order.Id, nil, domain.OrderStatusPending, user.Id, "")
+		if err != nil {
+			return err
+		}
+
 		createdOrder, err := adapters.OrderRepository.GetOneById(ctx, order.Id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to get order")
 			return utils.NewInternalError("Failed to get order")
 		}
 
-		for _, menu := range req.Menu {
-			menuId, err := uuid.Parse(menu.MenuId)
-			if err != nil {
-				logger.Log.WithError(err).Error("Error invalid id format")
-				return utils.NewValidationError("Invalid id format")
-			}
-			orderMenu := domain.OrderMenu{
-				OrderId:  createdOrder.Id,
-				MenuId:   menuId,
-				Quantity: menu.Quantity,
-			}
-			err = adapters.OrderMenuRepository.Create(ctx, orderMenu)
-			if err != nil {
-				logger.Log.WithError(err).Error("Error failed to create order menu")
-				return utils.NewInternalError("Failed to create order menu")
-			}
+		createdOrder.Items, err = adapters.OrderMenuRepository.GetAllByOrderId(ctx, order.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get order menu")
+			return utils.NewInternalError("Failed to get order menu")
 		}
+		createdOrder.TaxLines = order.TaxLines
 		result = createdOrder
 
 		return nil
@@ -106,43 +287,159 @@ func (u *OrderUsecaseImpl) Create(ctx context.Context, req dto.CreateOrderDto, u
 	return result, nil
 }
 
-func (u *OrderUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Order, error) {
+func (u *OrderUsecaseImpl) GetOneById(ctx context.Context, id, userId uuid.UUID, role string) (domain.Order, error) {
 	order, err := u.orderRepo.GetOneById(ctx, id)
 	if err != nil {
 		logger.Log.WithError(err).Error("Error order not found")
 		return domain.Order{}, utils.NewNotFoundError("Order not found")
 	}
+	if role == "customer" && order.UserId != userId {
+		logger.Log.WithField("user_id", userId).WithField("order.user_id", order.UserId).Error("Error order belongs to another user")
+		return domain.Order{}, utils.NewNotFoundError("Order not found")
+	}
+
+	order.Items, err = u.orderMenuRepo.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order menu")
+		return domain.Order{}, utils.NewInternalError("Failed to get order menu")
+	}
+
+	order.TaxLines, err = u.orderTaxLineRepo.GetAllByOrderId(ctx, order.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order tax lines")
+		return domain.Order{}, utils.NewInternalError("Failed to get order taxes")
+	}
 	return order, nil
 }
 
-func (u *OrderUsecaseImpl) UpdateOrderStatus(ctx context.Context, id uuid.UUID, req dto.UpdateOrderStatusDto) (domain.Order, error) {
+func (u *OrderUsecaseImpl) GetAll(ctx context.Context, req dto.GetOrdersRequest, userId uuid.UUID, role string) (domain.OrderList, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+		return domain.OrderList{}, utils.NewValidationError(err)
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	filter := domain.OrderFilter{
+		Status:        req.Status,
+		PaymentStatus: req.PaymentStatus,
+		SortBy:        req.SortBy,
+		SortOrder:     req.SortOrder,
+		Limit:         req.Limit,
+		Offset:        (req.Page - 1) * req.Limit,
+	}
+
+	// customers can only see their own orders, staff and admin can filter by any user
+	if role == "customer" {
+		filter.UserId = &userId
+	} else if req.UserId != "" {
+		filterUserId, err := uuid.Parse(req.UserId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid user id format")
+			return domain.OrderList{}, utils.NewValidationError("Invalid user id format")
+		}
+		filter.UserId = &filterUserId
+	}
+
+	if req.CreatedFrom != "" {
+		createdFrom, err := time.ParseInLocation("2006-01-02", req.CreatedFrom, time.Local)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid created_from format")
+			return domain.OrderList{}, utils.NewValidationError("Invalid created_from format")
+		}
+		filter.CreatedFrom = &createdFrom
+	}
+	if req.CreatedTo != "" {
+		createdTo, err := time.ParseInLocation("2006-01-02", req.CreatedTo, time.Local)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid created_to format")
+			return domain.OrderList{}, utils.NewValidationError("Invalid created_to format")
+		}
+		// created_to is inclusive, so include the whole day
+		createdTo = createdTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &createdTo
+	}
+	if filter.CreatedFrom != nil && filter.CreatedTo != nil && !filter.CreatedFrom.Before(*filter.CreatedTo) {
+		logger.Log.WithField("created_from", req.CreatedFrom).WithField("created_to", req.CreatedTo).Error("Error invalid created_at range")
+		return domain.OrderList{}, utils.NewBadRequestError("created_from must not be after created_to")
+	}
+
+	orders, err := u.orderRepo.GetAll(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get orders")
+		return domain.OrderList{}, utils.NewInternalError("Failed to get orders")
+	}
+
+	total, err := u.orderRepo.Count(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to count orders")
+		return domain.OrderList{}, utils.NewInternalError("Failed to count orders")
+	}
+
+	return domain.OrderList{
+		Orders: orders,
+		Pagination: domain.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
+
+func (u *OrderUsecaseImpl) GetStatusHistory(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.OrderStatusHistory, error) {
+	order, err := u.orderRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error order not found")
+		return nil, utils.NewNotFoundError("Order not found")
+	}
+	if role == "customer" && order.UserId != userId {
+		logger.Log.WithField("user_id", userId).WithField("order.user_id", order.UserId).Error("Error order belongs to another user")
+		return nil, utils.NewNotFoundError("Order not found")
+	}
+
+	histories, err := u.orderStatusHistoryRepo.GetAllByOrderId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order status history")
+		return nil, utils.NewInternalError("Failed to get order status history")
+	}
+	return histories, nil
+}
+
+func (u *OrderUsecaseImpl) UpdateOrderStatus(ctx context.Context, id, actorId uuid.UUID, req dto.UpdateOrderStatusDto) (domain.Order, error) {
 	result := domain.Order{}
+	alerts := stockAlerts{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
-		existingOrder, err := adapters.OrderRepository.GetOneById(ctx, id)
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		existingOrder, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error order not found")
 			return utils.NewNotFoundError("Order not found")
 		}
 
 		if req.Status == "" {
-			if existingOrder.Status == "pending" {
-				req.Status = "processing"
-			} else if existingOrder.Status == "processing" {
-				logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
-				return utils.NewBadRequestError("Invalid status, must include success or failed")
-			} else {
-				logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
-				return utils.NewBadRequestError(fmt.Sprintf("Invalid update status, status already '%s'", existingOrder.Status))
+			next, ok := nextOrderStatus[existingOrder.Status]
+			if !ok {
+				logger.Log.WithField("status", existingOrder.Status).Error("Error order status can not be advanced automatically")
+				return utils.NewBadRequestError(fmt.Sprintf("Status is required when order is '%s'", existingOrder.Status))
 			}
+			req.Status = next
 		}
-		order := domain.Order{
-			Status: req.Status,
-		}
-		err = adapters.OrderRepository.UpdateOrderStatus(ctx, id, order)
+
+		err = u.changeOrderStatus(ctx, adapters, &alerts, existingOrder, req.Status, actorId, req.Note)
 		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to update order status")
-			return utils.NewInternalError("Failed to update order status")
+			return err
 		}
+
 		updatedOrder, err := adapters.OrderRepository.GetOneById(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to get order")
@@ -155,51 +452,39 @@ func (u *OrderUsecaseImpl) UpdateOrderStatus(ctx context.Context, id uuid.UUID,
 	if err != nil {
 		return result, err
 	}
+	alerts.notify(ctx, u.stockAlertNotifier)
 	return result, nil
 }
 
-func (u *OrderUsecaseImpl) UpdatePayment(ctx context.Context, id uuid.UUID, req dto.UpdatePaymentDto) (domain.Order, error) {
+func (u *OrderUsecaseImpl) Cancel(ctx context.Context, id, userId uuid.UUID, role string, req dto.CancelOrderDto) (domain.Order, error) {
 	result := domain.Order{}
+	alerts := stockAlerts{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		if err := utils.ValidateStruct(req); len(err) > 0 {
 			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
 			return utils.NewValidationError(err)
 		}
 
-		_, err := adapters.OrderRepository.GetOneById(ctx, id)
+		existingOrder, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error order not found")
 			return utils.NewNotFoundError("Order not found")
 		}
-		var paymentStatus string
-		if req.PaymentMethod == nil {
-			paymentStatus = "unpaid"
-		} else {
-			paymentStatus = "paid"
+		if role == "customer" && existingOrder.UserId != userId {
+			logger.Log.WithField("user_id", userId).WithField("order.user_id", existingOrder.UserId).Error("Error order belongs to another user")
+			return utils.NewNotFoundError("Order not found")
 		}
 
-		order := domain.Order{
-			PaymentMethod: req.PaymentMethod,
-			PaymentStatus: paymentStatus,
+		if !customerCancellableStatuses[existingOrder.Status] {
+			logger.Log.WithField("status", existingOrder.Status).Error("Error order can no longer be cancelled")
+			return utils.NewConflictError(fmt.Sprintf("Order can no longer be cancelled, status already '%s'", existingOrder.Status))
 		}
 
-		err = adapters.OrderRepository.UpdatePayment(ctx, id, order)
+		err = u.changeOrderStatus(ctx, adapters, &alerts, existingOrder, domain.OrderStatusCancelled, userId, req.Reason)
 		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to update order payment")
-			return utils.NewInternalError("Failed to update order payment")
+			return err
 		}
 
-		if order.PaymentStatus == "paid" {
-
-			status := domain.Order{
-				Status: "success",
-			}
-			err = adapters.OrderRepository.UpdateOrderStatus(ctx, id, status)
-			if err != nil {
-				logger.Log.WithError(err).Error("Error failed to update order status")
-				return utils.NewInternalError("Failed to update order status")
-			}
-		}
 		updatedOrder, err := adapters.OrderRepository.GetOneById(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to get order")
@@ -212,5 +497,214 @@ func (u *OrderUsecaseImpl) UpdatePayment(ctx context.Context, id uuid.UUID, req
 	if err != nil {
 		return result, err
 	}
+	alerts.notify(ctx, u.stockAlertNotifier)
 	return result, nil
 }
+
+// UpdatePayment takes the outstanding balance at the counter, the payment is
+// opened and captured with the provider at once and the order only becomes
+// paid when the capture is confirmed
+func (u *OrderUsecaseImpl) UpdatePayment(ctx context.Context, id, actorId uuid.UUID, req dto.UpdatePaymentDto) (domain.Order, error) {
+	result := domain.Order{}
+	alerts := stockAlerts{}
+	orderPayment := domain.Payment{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		existingOrder, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		orderPayment, err = u.openPayment(ctx, adapters, existingOrder, dto.CreatePaymentRequest{Method: *req.PaymentMethod}, actorId)
+		if err != nil {
+			return err
+		}
+		return beginCapture(ctx, adapters, existingOrder, &orderPayment)
+	})
+	if err != nil {
+		return result, err
+	}
+
+	orderPayment, err = u.capturePayment(ctx, &alerts, orderPayment, actorId)
+	if err != nil {
+		return result, err
+	}
+	result, err = u.orderRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get order")
+		return result, utils.NewInternalError("Failed to get order")
+	}
+	if orderPayment.Status == domain.PaymentStatusFailed {
+		return result, paymentDeclinedError(orderPayment)
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+func paymentDeclinedError(orderPayment domain.Payment) error {
+	logger.Log.WithField("payment_id", orderPayment.Id).WithField("reason", *orderPayment.FailureReason).Error("Error payment declined")
+	return utils.NewConflictErrorWithDetails(fmt.Sprintf("Payment declined: %s", *orderPayment.FailureReason), orderPayment)
+}
+
+func (u *OrderUsecaseImpl) GetPayments(ctx context.Context, id, userId uuid.UUID, role string) ([]domain.Payment, error) {
+	result := []domain.Payment{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		if role == "customer" && order.UserId != userId {
+			logger.Log.WithField("user_id", userId).WithField("order.user_id", order.UserId).Error("Error order belongs to another user")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		payments, err := adapters.PaymentRepository.GetAllByOrderId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get payments")
+			return utils.NewInternalError("Failed to get payments")
+		}
+		allocations, err := adapters.PaymentAllocationRepository.GetAllByOrderId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get payment allocations")
+			return utils.NewInternalError("Failed to get payment allocations")
+		}
+
+		index := map[uuid.UUID]int{}
+		for i, orderPayment := range payments {
+			index[orderPayment.Id] = i
+		}
+		for _, allocation := range allocations {
+			i := index[allocation.PaymentId]
+			payments[i].Allocations = append(payments[i].Allocations, allocation)
+		}
+		result = payments
+		return nil
+	})
+	return result, err
+}
+
+func (u *OrderUsecaseImpl) CreatePayment(ctx context.Context, id, actorId uuid.UUID, req dto.CreatePaymentRequest) (domain.Payment, error) {
+	result := domain.Payment{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		orderPayment, err := u.openPayment(ctx, adapters, order, req, actorId)
+		if err != nil {
+			return err
+		}
+		result = orderPayment
+		return nil
+	})
+	return result, err
+}
+
+func (u *OrderUsecaseImpl) CapturePayment(ctx context.Context, id, paymentId, actorId uuid.UUID) (domain.Payment, error) {
+	result := domain.Payment{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+
+		orderPayment, err := getOrderPayment(ctx, adapters, id, paymentId)
+		if err != nil {
+			return err
+		}
+		if err := beginCapture(ctx, adapters, order, &orderPayment); err != nil {
+			return err
+		}
+		result = orderPayment
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	result, err = u.capturePayment(ctx, &alerts, result, actorId)
+	if err != nil {
+		return result, err
+	}
+	if result.Status == domain.PaymentStatusFailed {
+		return result, paymentDeclinedError(result)
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+// HandlePaymentWebhook applies an event sent by the provider. Events may
+// arrive more than once or after the payment was settled through the API,
+// so an event that changes nothing is accepted and ignored.
+func (u *OrderUsecaseImpl) HandlePaymentWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := u.paymentProvider.VerifyWebhook(payload, signature)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error invalid payment webhook")
+		return utils.NewUnauthorizedError("Invalid payment webhook signature")
+	}
+
+	found := domain.Payment{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		found, err = adapters.PaymentRepository.GetOneByProviderReference(ctx, u.paymentProvider.Name(), event.Reference)
+		if err != nil {
+			logger.Log.WithError(err).WithField("reference", event.Reference).Error("Error payment not found")
+			return utils.NewNotFoundError("Payment not found")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// the order is locked before the payment like on every other payment path
+	alerts := stockAlerts{}
+	requests := []paymentRefundRequest{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		order, err := adapters.OrderRepository.GetOneByIdForUpdate(ctx, found.OrderId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error order not found")
+			return utils.NewNotFoundError("Order not found")
+		}
+		orderPayment, err := adapters.PaymentRepository.GetOneByIdForUpdate(ctx, found.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get payment")
+			return utils.NewInternalError("Failed to get payment")
+		}
+
+		switch {
+		case event.Type == payment.EventCaptured && orderPayment.Status != domain.PaymentStatusCaptured && orderPayment.Status != domain.PaymentStatusRefunded:
+			requests, err = u.applyCapturedPayment(ctx, adapters, &alerts, order, &orderPayment, orderPayment.CreatedBy, "Payment confirmed by provider")
+			return err
+		case event.Type == payment.EventFailed && (orderPayment.Status == domain.PaymentStatusPending || orderPayment.Status == domain.PaymentStatusCapturing):
+			return failPayment(ctx, adapters, &orderPayment, event.FailureReason)
+		default:
+			logger.Log.WithField("type", event.Type).WithField("payment_id", orderPayment.Id).Info("Payment webhook ignored")
+			return nil
+		}
+	})
+	if err != nil {
+		return err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+
+	// the event is handled, a refund the provider failed stays pending
+	if err := u.refundPayments(ctx, requests); err != nil {
+		logger.Log.WithError(err).WithField("payment_id", found.Id).Error("Error excess of the payment not refunded")
+	}
+	return nil
+}
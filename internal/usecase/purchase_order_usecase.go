@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type PurchaseOrderUsecase interface {
+	Create(ctx context.Context, req dto.CreatePurchaseOrderRequest, actorId uuid.UUID) (domain.PurchaseOrder, error)
+	GetAll(ctx context.Context, req dto.GetPurchaseOrdersRequest) ([]domain.PurchaseOrder, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error)
+	Send(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error)
+	Cancel(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error)
+	Receive(ctx context.Context, id, actorId uuid.UUID, req dto.ReceivePurchaseOrderRequest) (domain.PurchaseOrder, error)
+	GenerateFromLowStock(ctx context.Context, actorId uuid.UUID) (domain.GeneratedPurchaseOrders, error)
+}
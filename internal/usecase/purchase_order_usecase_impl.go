@@ -0,0 +1,442 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// receiveTolerance absorbs float rounding when comparing received quantities
+const receiveTolerance = 0.0001
+
+type PurchaseOrderUsecaseImpl struct {
+	purchaseOrderRepo     repository.PurchaseOrderRepository
+	purchaseOrderItemRepo repository.PurchaseOrderItemRepository
+	stockAlertNotifier    notifier.StockAlertNotifier
+	txRepo                repository.TransactionRepository
+}
+
+func NewPurchaseOrderUsecase(purchaseOrderRepo repository.PurchaseOrderRepository, purchaseOrderItemRepo repository.PurchaseOrderItemRepository, stockAlertNotifier notifier.StockAlertNotifier, txRepo repository.TransactionRepository) PurchaseOrderUsecase {
+	return &PurchaseOrderUsecaseImpl{
+		purchaseOrderRepo:     purchaseOrderRepo,
+		purchaseOrderItemRepo: purchaseOrderItemRepo,
+		stockAlertNotifier:    stockAlertNotifier,
+		txRepo:                txRepo,
+	}
+}
+
+// getPurchaseOrder loads a purchase order together with its items, paths
+// about to change it pass forUpdate so it stays locked until the transaction
+// ends and concurrent receipts can't both see the same outstanding quantity
+func getPurchaseOrder(ctx context.Context, adapters repository.Adapters, id uuid.UUID, forUpdate bool) (domain.PurchaseOrder, error) {
+	getOne := adapters.PurchaseOrderRepository.GetOneById
+	if forUpdate {
+		getOne = adapters.PurchaseOrderRepository.GetOneByIdForUpdate
+	}
+	purchaseOrder, err := getOne(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error purchase order not found")
+		return domain.PurchaseOrder{}, utils.NewNotFoundError("Purchase order not found")
+	}
+
+	items, err := adapters.PurchaseOrderItemRepository.GetAllByPurchaseOrderId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get purchase order items")
+		return domain.PurchaseOrder{}, utils.NewInternalError("Failed to get purchase order items")
+	}
+	purchaseOrder.Items = items
+	return purchaseOrder, nil
+}
+
+func (u *PurchaseOrderUsecaseImpl) Create(ctx context.Context, req dto.CreatePurchaseOrderRequest, actorId uuid.UUID) (domain.PurchaseOrder, error) {
+	result := domain.PurchaseOrder{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		_, err := adapters.SupplierRepository.GetOneById(ctx, req.SupplierId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error supplier not found")
+			return utils.NewNotFoundError("Supplier not found")
+		}
+
+		purchaseOrder := domain.PurchaseOrder{
+			Id:         uuid.New(),
+			SupplierId: req.SupplierId,
+			Status:     domain.PurchaseOrderStatusDraft,
+			ExpectedAt: req.ExpectedAt,
+			CreatedBy:  &actorId,
+		}
+		if req.Notes != "" {
+			purchaseOrder.Notes = &req.Notes
+		}
+		err = adapters.PurchaseOrderRepository.Create(ctx, purchaseOrder)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create purchase order")
+			return utils.NewInternalError("Failed to create purchase order")
+		}
+
+		seen := map[uuid.UUID]bool{}
+		for _, itemReq := range req.Items {
+			if seen[itemReq.IngredientId] {
+				logger.Log.WithField("ingredient_id", itemReq.IngredientId).Error("Error duplicate purchase order item")
+				return utils.NewBadRequestError("Each ingredient can only appear once in a purchase order")
+			}
+			seen[itemReq.IngredientId] = true
+
+			ingredient, err := adapters.IngredientRepository.GetOneById(ctx, itemReq.IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error ingredient not found")
+				return utils.NewNotFoundError("Ingredient not found")
+			}
+
+			quantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, itemReq.Quantity, itemReq.Unit)
+			if err != nil {
+				return err
+			}
+
+			// without an explicit price the supplier's price list is used
+			var unitCost float64
+			if itemReq.UnitCost != nil {
+				unitCost, err = toBaseUnitCost(ctx, adapters, ingredient, *itemReq.UnitCost, itemReq.Unit)
+				if err != nil {
+					return err
+				}
+			} else {
+				supplierIngredient, err := adapters.SupplierIngredientRepository.GetOneBySupplierIdAndIngredientId(ctx, req.SupplierId, ingredient.Id)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error supplier does not supply ingredient")
+					return utils.NewBadRequestError(fmt.Sprintf("Supplier has no price for %s, provide a unit cost", ingredient.Name))
+				}
+				unitCost = supplierIngredient.UnitCost
+			}
+
+			err = adapters.PurchaseOrderItemRepository.Create(ctx, domain.PurchaseOrderItem{
+				Id:              uuid.New(),
+				PurchaseOrderId: purchaseOrder.Id,
+				IngredientId:    ingredient.Id,
+				Quantity:        quantity,
+				UnitCost:        unitCost,
+			})
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create purchase order item")
+				return utils.NewInternalError("Failed to create purchase order item")
+			}
+		}
+
+		createdPurchaseOrder, err := getPurchaseOrder(ctx, adapters, purchaseOrder.Id, false)
+		if err != nil {
+			return err
+		}
+		result = createdPurchaseOrder
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *PurchaseOrderUsecaseImpl) GetAll(ctx context.Context, req dto.GetPurchaseOrdersRequest) ([]domain.PurchaseOrder, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return nil, utils.NewValidationError(err)
+	}
+
+	filter := domain.PurchaseOrderFilter{Status: req.Status}
+	if req.SupplierId != "" {
+		supplierId, err := uuid.Parse(req.SupplierId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid supplier id format")
+			return nil, utils.NewValidationError("Invalid supplier id format")
+		}
+		filter.SupplierId = &supplierId
+	}
+
+	purchaseOrders, err := u.purchaseOrderRepo.GetAll(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get purchase orders")
+		return nil, utils.NewInternalError("Failed to get purchase orders")
+	}
+	return purchaseOrders, nil
+}
+
+func (u *PurchaseOrderUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error) {
+	purchaseOrder, err := u.purchaseOrderRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error purchase order not found")
+		return domain.PurchaseOrder{}, utils.NewNotFoundError("Purchase order not found")
+	}
+
+	items, err := u.purchaseOrderItemRepo.GetAllByPurchaseOrderId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get purchase order items")
+		return domain.PurchaseOrder{}, utils.NewInternalError("Failed to get purchase order items")
+	}
+	purchaseOrder.Items = items
+	return purchaseOrder, nil
+}
+
+func (u *PurchaseOrderUsecaseImpl) Send(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error) {
+	return u.changeStatus(ctx, id, domain.PurchaseOrderStatusSent, domain.PurchaseOrderStatusDraft)
+}
+
+// Cancel is only possible before anything was received, a partially received
+// order keeps its history and is closed by receiving the rest
+func (u *PurchaseOrderUsecaseImpl) Cancel(ctx context.Context, id uuid.UUID) (domain.PurchaseOrder, error) {
+	return u.changeStatus(ctx, id, domain.PurchaseOrderStatusCancelled, domain.PurchaseOrderStatusDraft, domain.PurchaseOrderStatusSent)
+}
+
+func (u *PurchaseOrderUsecaseImpl) changeStatus(ctx context.Context, id uuid.UUID, status string, allowedFrom ...string) (domain.PurchaseOrder, error) {
+	result := domain.PurchaseOrder{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		purchaseOrder, err := adapters.PurchaseOrderRepository.GetOneByIdForUpdate(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error purchase order not found")
+			return utils.NewNotFoundError("Purchase order not found")
+		}
+
+		allowed := false
+		for _, from := range allowedFrom {
+			if purchaseOrder.Status == from {
+				allowed = true
+			}
+		}
+		if !allowed {
+			logger.Log.WithField("from", purchaseOrder.Status).WithField("to", status).Error("Error invalid purchase order status transition")
+			return utils.NewConflictError(fmt.Sprintf("Purchase order is '%s' and can not become '%s'", purchaseOrder.Status, status))
+		}
+
+		err = adapters.PurchaseOrderRepository.UpdateStatus(ctx, id, status)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update purchase order status")
+			return utils.NewInternalError("Failed to update purchase order status")
+		}
+
+		updatedPurchaseOrder, err := getPurchaseOrder(ctx, adapters, id, false)
+		if err != nil {
+			return err
+		}
+		result = updatedPurchaseOrder
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *PurchaseOrderUsecaseImpl) Receive(ctx context.Context, id, actorId uuid.UUID, req dto.ReceivePurchaseOrderRequest) (domain.PurchaseOrder, error) {
+	result := domain.PurchaseOrder{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		purchaseOrder, err := getPurchaseOrder(ctx, adapters, id, true)
+		if err != nil {
+			return err
+		}
+		if purchaseOrder.Status != domain.PurchaseOrderStatusSent && purchaseOrder.Status != domain.PurchaseOrderStatusPartiallyReceived {
+			logger.Log.WithField("status", purchaseOrder.Status).Error("Error purchase order can not be received")
+			return utils.NewConflictError(fmt.Sprintf("Purchase order is '%s' and can not be received", purchaseOrder.Status))
+		}
+
+		location, err := getStorageLocation(ctx, adapters, req.LocationId)
+		if err != nil {
+			return err
+		}
+
+		items := map[uuid.UUID]domain.PurchaseOrderItem{}
+		for _, item := range purchaseOrder.Items {
+			items[item.Id] = item
+		}
+
+		reason := "Purchase order received"
+		if req.Notes != "" {
+			reason = req.Notes
+		}
+
+		for _, itemReq := range req.Items {
+			item, ok := items[itemReq.ItemId]
+			if !ok {
+				logger.Log.WithField("item_id", itemReq.ItemId).Error("Error item does not belong to purchase order")
+				return utils.NewNotFoundError("Purchase order item not found")
+			}
+
+			quantity, err := toBaseQuantity(ctx, adapters, item.IngredientId, item.Unit, itemReq.Quantity, itemReq.Unit)
+			if err != nil {
+				return err
+			}
+			if outstanding := item.Quantity - item.ReceivedQuantity; quantity > outstanding+receiveTolerance {
+				logger.Log.WithField("quantity", quantity).WithField("outstanding", outstanding).Error("Error receiving more than ordered")
+				return utils.NewBadRequestError(fmt.Sprintf("Only %g %s of %s is outstanding", outstanding, item.Unit, item.Name))
+			}
+
+			inventory, err := getOrCreateInventory(ctx, adapters, item.IngredientId, location.Id)
+			if err != nil {
+				return err
+			}
+			err = recordInventoryMovement(ctx, adapters, &alerts, inventory, domain.MovementTypeReceipt, quantity, stockLot{LotNumber: itemReq.LotNumber, ExpiresAt: itemReq.ExpiresAt}, reason, &purchaseOrder.Id, &actorId)
+			if err != nil {
+				return err
+			}
+
+			err = adapters.PurchaseOrderItemRepository.AddReceivedQuantity(ctx, item.Id, quantity)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update purchase order item")
+				return utils.NewInternalError("Failed to update purchase order item")
+			}
+			item.ReceivedQuantity += quantity
+			items[item.Id] = item
+
+			// the last purchase price becomes the cost of the ingredient
+			if item.UnitCost > 0 {
+				ingredient, err := adapters.IngredientRepository.GetOneById(ctx, item.IngredientId)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error failed to get ingredient")
+					return utils.NewNotFoundError("Ingredient not found")
+				}
+				err = changeIngredientCost(ctx, adapters, ingredient, item.UnitCost, domain.CostSourcePurchaseOrder, &purchaseOrder.Id, &actorId)
+				if err != nil {
+					return err
+				}
+			}
+		}
+
+		status := domain.PurchaseOrderStatusReceived
+		for _, item := range items {
+			if item.ReceivedQuantity+receiveTolerance < item.Quantity {
+				status = domain.PurchaseOrderStatusPartiallyReceived
+				break
+			}
+		}
+		if status != purchaseOrder.Status {
+			err = adapters.PurchaseOrderRepository.UpdateStatus(ctx, id, status)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update purchase order status")
+				return utils.NewInternalError("Failed to update purchase order status")
+			}
+		}
+
+		updatedPurchaseOrder, err := getPurchaseOrder(ctx, adapters, id, false)
+		if err != nil {
+			return err
+		}
+		result = updatedPurchaseOrder
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+// GenerateFromLowStock drafts one purchase order per preferred supplier for
+// every low-stock ingredient that is not already covered by open orders.
+// The reorder quantity is ordered, without one stock is topped up to the
+// reorder point.
+func (u *PurchaseOrderUsecaseImpl) GenerateFromLowStock(ctx context.Context, actorId uuid.UUID) (domain.GeneratedPurchaseOrders, error) {
+	result := domain.GeneratedPurchaseOrders{
+		PurchaseOrders: []domain.PurchaseOrder{},
+		Unassigned:     []domain.LowStockItem{},
+	}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		lowStock, err := adapters.InventoryRepository.GetLowStock(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting low stock")
+			return utils.NewInternalError("Failed to get low stock")
+		}
+
+		supplierOrder := []uuid.UUID{}
+		itemsBySupplier := map[uuid.UUID][]domain.PurchaseOrderItem{}
+		for _, lowStockItem := range lowStock {
+			open, err := adapters.PurchaseOrderItemRepository.SumOpenQuantityByIngredientId(ctx, lowStockItem.IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting open purchase order quantity")
+				return utils.NewInternalError("Failed to get open purchase orders")
+			}
+			projected := lowStockItem.Quantity + open
+			if projected > lowStockItem.ReorderPoint {
+				continue
+			}
+			quantity := lowStockItem.ReorderQuantity
+			if quantity <= 0 {
+				quantity = lowStockItem.ReorderPoint - projected
+			}
+			if quantity <= 0 {
+				continue
+			}
+
+			supplierIngredient, err := adapters.SupplierIngredientRepository.GetPreferredByIngredientId(ctx, lowStockItem.IngredientId)
+			if errors.Is(err, sql.ErrNoRows) {
+				result.Unassigned = append(result.Unassigned, lowStockItem)
+				continue
+			}
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting supplier ingredient")
+				return utils.NewInternalError("Failed to get supplier ingredient")
+			}
+
+			if _, ok := itemsBySupplier[supplierIngredient.SupplierId]; !ok {
+				supplierOrder = append(supplierOrder, supplierIngredient.SupplierId)
+			}
+			itemsBySupplier[supplierIngredient.SupplierId] = append(itemsBySupplier[supplierIngredient.SupplierId], domain.PurchaseOrderItem{
+				Id:           uuid.New(),
+				IngredientId: lowStockItem.IngredientId,
+				Quantity:     quantity,
+				UnitCost:     supplierIngredient.UnitCost,
+			})
+		}
+
+		notes := "Generated from low stock"
+		for _, supplierId := range supplierOrder {
+			purchaseOrder := domain.PurchaseOrder{
+				Id:         uuid.New(),
+				SupplierId: supplierId,
+				Status:     domain.PurchaseOrderStatusDraft,
+				Notes:      &notes,
+				CreatedBy:  &actorId,
+			}
+			err = adapters.PurchaseOrderRepository.Create(ctx, purchaseOrder)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create purchase order")
+				return utils.NewInternalError("Failed to create purchase order")
+			}
+
+			for _, item := range itemsBySupplier[supplierId] {
+				item.PurchaseOrderId = purchaseOrder.Id
+				err = adapters.PurchaseOrderItemRepository.Create(ctx, item)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error failed to create purchase order item")
+					return utils.NewInternalError("Failed to create purchase order item")
+				}
+			}
+
+			createdPurchaseOrder, err := getPurchaseOrder(ctx, adapters, purchaseOrder.Id, false)
+			if err != nil {
+				return err
+			}
+			result.PurchaseOrders = append(result.PurchaseOrders, createdPurchaseOrder)
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
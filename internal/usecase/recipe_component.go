@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// defaultYieldUnit is what a recipe yields when no unit is given, menu
+// recipes always yield portions
+const defaultYieldUnit = "portion"
+
+// componentBatches converts the quantity of a sub-recipe line to batches of
+// the sub-recipe
+func componentBatches(component domain.SimpleRecipeComponent) (float64, error) {
+	quantity, ok := utils.ConvertUnit(component.Quantity, component.Unit, component.YieldUnit)
+	if !ok || component.YieldQuantity <= 0 {
+		logger.Log.WithField("unit", component.Unit).WithField("yield_unit", component.YieldUnit).Error("Error incompatible component unit")
+		return 0, utils.NewInternalError(fmt.Sprintf("Failed to convert %s to the yield of %s", component.Unit, component.Name))
+	}
+	return quantity / component.YieldQuantity, nil
+}
+
+// getComponentVersion returns the version of a sub-recipe in use at a moment
+// and sets the yield of the line from it
+func getComponentVersion(ctx context.Context, adapters repository.Adapters, component *domain.SimpleRecipeComponent, at time.Time) (domain.RecipeVersion, error) {
+	version, found, err := getActiveRecipeVersion(ctx, adapters, component.RecipeId, at)
+	if err != nil {
+		return domain.RecipeVersion{}, err
+	}
+	if !found {
+		logger.Log.WithField("recipe_id", component.RecipeId).Error("Error component recipe has no version in effect")
+		return domain.RecipeVersion{}, utils.NewInternalError(fmt.Sprintf("%s has no version in effect", component.Name))
+	}
+	component.YieldQuantity = version.YieldQuantity
+	component.YieldUnit = version.YieldUnit
+	return version, nil
+}
+
+// recipeExplosion collects the raw ingredients of a recipe, path holds the
+// recipes being resolved so a cycle is caught instead of recursing forever
+type recipeExplosion struct {
+	at          time.Time
+	ingredients []domain.SimpleRecipeIngredient
+	index       map[uuid.UUID]int
+	path        map[uuid.UUID]bool
+}
+
+// explodeRecipePortion resolves the version of a menu recipe in use at a
+// moment down to the raw ingredients of one portion, one batch yields
+// YieldQuantity portions
+func explodeRecipePortion(ctx context.Context, adapters repository.Adapters, recipeId uuid.UUID, at time.Time) ([]domain.SimpleRecipeIngredient, error) {
+	version, found, err := getActiveRecipeVersion(ctx, adapters, recipeId, at)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return []domain.SimpleRecipeIngredient{}, nil
+	}
+	recipeIngredients, err := explodeRecipeVersion(ctx, adapters, version, at)
+	if err != nil {
+		return nil, err
+	}
+	if version.YieldQuantity > 0 {
+		scaleRecipeIngredients(recipeIngredients, 1/version.YieldQuantity)
+	}
+	return recipeIngredients, nil
+}
+
+// scaleRecipeIngredients multiplies recipe lines by factor, both as entered
+// and in the base unit
+func scaleRecipeIngredients(recipeIngredients []domain.SimpleRecipeIngredient, factor float64) {
+	for i := range recipeIngredients {
+		recipeIngredients[i].Quantity *= factor
+		recipeIngredients[i].BaseQuantity *= factor
+	}
+}
+
+// explodeRecipeVersion resolves one version of a recipe, sub-recipes use
+// their version in use at the given moment. Lines taken straight from the
+// version keep the quantity as entered, lines coming from sub-recipes or
+// merged with them are in the base unit.
+func explodeRecipeVersion(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, at time.Time) ([]domain.SimpleRecipeIngredient, error) {
+	explosion := &recipeExplosion{
+		at:          at,
+		ingredients: []domain.SimpleRecipeIngredient{},
+		index:       map[uuid.UUID]int{},
+		path:        map[uuid.UUID]bool{},
+	}
+	if err := explosion.add(ctx, adapters, version, 1); err != nil {
+		return nil, err
+	}
+	return explosion.ingredients, nil
+}
+
+func (e *recipeExplosion) add(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, batches float64) error {
+	if e.path[version.RecipeId] {
+		logger.Log.WithField("recipe_id", version.RecipeId).Error("Error recipe components form a cycle")
+		return utils.NewInternalError("Recipe components form a cycle")
+	}
+	e.path[version.RecipeId] = true
+	defer delete(e.path, version.RecipeId)
+
+	recipeIngredients, err := getRecipeIngredients(ctx, adapters, version.Id)
+	if err != nil {
+		return err
+	}
+	for _, recipeIngredient := range recipeIngredients {
+		recipeIngredient.BaseQuantity *= batches
+		if i, ok := e.index[recipeIngredient.IngredientId]; ok {
+			e.ingredients[i].BaseQuantity += recipeIngredient.BaseQuantity
+			e.ingredients[i].Quantity = e.ingredients[i].BaseQuantity
+			e.ingredients[i].Unit = e.ingredients[i].BaseUnit
+			continue
+		}
+		if len(e.path) > 1 {
+			recipeIngredient.Quantity = recipeIngredient.BaseQuantity
+			recipeIngredient.Unit = recipeIngredient.BaseUnit
+		}
+		e.index[recipeIngredient.IngredientId] = len(e.ingredients)
+		e.ingredients = append(e.ingredients, recipeIngredient)
+	}
+
+	components, err := adapters.RecipeComponentRepository.GetComponentsByRecipeVersionId(ctx, version.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe components")
+		return utils.NewInternalError("Failed to get recipe components")
+	}
+	for _, component := range components {
+		componentVersion, err := getComponentVersion(ctx, adapters, &component, e.at)
+		if err != nil {
+			return err
+		}
+		componentBatches, err := componentBatches(component)
+		if err != nil {
+			return err
+		}
+		if err := e.add(ctx, adapters, componentVersion, batches*componentBatches); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveRecipeComponent checks a sub-recipe line of recipeId: the component
+// recipe has a version in use, the unit converts to its yield unit and using
+// it does not make the recipe contain itself. It returns the unit the line is
+// stored in.
+func resolveRecipeComponent(ctx context.Context, adapters repository.Adapters, recipeId uuid.UUID, req dto.CreateRecipeComponentRequest) (domain.Recipe, string, error) {
+	component, err := adapters.RecipeRepository.GetOneById(ctx, req.RecipeId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error component recipe not found")
+		return domain.Recipe{}, "", utils.NewNotFoundError("Component recipe not found")
+	}
+	if component.VersionId == nil {
+		logger.Log.WithField("recipe_id", component.Id).Error("Error component recipe has no version in effect")
+		return domain.Recipe{}, "", utils.NewValidationError(utils.FieldError("components", fmt.Sprintf("%s has no published version in effect yet", component.Name)))
+	}
+
+	unit := utils.NormalizeUnit(req.Unit)
+	if unit == "" {
+		unit = component.YieldUnit
+	}
+	if _, ok := utils.ConvertUnit(req.Quantity, unit, component.YieldUnit); !ok {
+		logger.Log.WithField("unit", unit).WithField("yield_unit", component.YieldUnit).Error("Error incompatible component unit")
+		return domain.Recipe{}, "", utils.NewValidationError(utils.FieldError("unit", fmt.Sprintf("Unit %s cannot be converted to %s, the yield unit of %s", unit, component.YieldUnit, component.Name)))
+	}
+
+	if err := checkRecipeComponentCycle(ctx, adapters, recipeId, component); err != nil {
+		return domain.Recipe{}, "", err
+	}
+	return component, unit, nil
+}
+
+// checkRecipeComponentCycle rejects a component that is the recipe itself or
+// uses the recipe somewhere below it in any of its versions
+func checkRecipeComponentCycle(ctx context.Context, adapters repository.Adapters, recipeId uuid.UUID, component domain.Recipe) error {
+	cycleErr := utils.NewValidationError(utils.FieldError("components", fmt.Sprintf("%s cannot be a component of this recipe, it would contain itself", component.Name)))
+	if component.Id == recipeId {
+		logger.Log.WithField("recipe_id", recipeId).Error("Error recipe used as its own component")
+		return cycleErr
+	}
+
+	visited := map[uuid.UUID]bool{component.Id: true}
+	pending := []uuid.UUID{component.Id}
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		children, err := adapters.RecipeComponentRepository.GetComponentRecipeIdsByRecipeId(ctx, current)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting recipe components")
+			return utils.NewInternalError("Failed to get recipe components")
+		}
+		for _, child := range children {
+			if child == recipeId {
+				logger.Log.WithField("recipe_id", recipeId).WithField("component_recipe_id", component.Id).Error("Error recipe components form a cycle")
+				return cycleErr
+			}
+			if !visited[child] {
+				visited[child] = true
+				pending = append(pending, child)
+			}
+		}
+	}
+	return nil
+}
+
+// saveRecipeComponents creates the sub-recipe lines of a draft version, or
+// updates the quantity of lines that already exist
+func saveRecipeComponents(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, reqs []dto.CreateRecipeComponentRequest) error {
+	for _, componentReq := range reqs {
+		component, unit, err := resolveRecipeComponent(ctx, adapters, version.RecipeId, componentReq)
+		if err != nil {
+			return err
+		}
+
+		existing, err := adapters.RecipeComponentRepository.GetOneByRecipeVersionIdAndComponentRecipeId(ctx, version.Id, component.Id)
+		if err != nil {
+			recipeComponent := domain.RecipeComponent{
+				Id:                uuid.New(),
+				RecipeId:          version.RecipeId,
+				RecipeVersionId:   version.Id,
+				ComponentRecipeId: component.Id,
+				Quantity:          componentReq.Quantity,
+				Unit:              unit,
+			}
+			err = adapters.RecipeComponentRepository.Create(ctx, recipeComponent)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create recipe component")
+				return utils.NewInternalError("Failed to create recipe component")
+			}
+		} else if existing.Quantity != componentReq.Quantity || existing.Unit != unit {
+			existing.Quantity = componentReq.Quantity
+			existing.Unit = unit
+			err = adapters.RecipeComponentRepository.Update(ctx, existing.Id, existing)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update recipe component")
+				return utils.NewInternalError("Failed to update recipe component")
+			}
+		}
+	}
+	return nil
+}
+
+// resolveRecipeYield applies the requested yield to a version, a menu recipe
+// is sold by the portion so it yields a number of portions per batch
+func resolveRecipeYield(menuId *uuid.UUID, version *domain.RecipeVersion, yieldQuantity float64, yieldUnit string) error {
+	yieldUnit = utils.NormalizeUnit(yieldUnit)
+	if menuId != nil {
+		if yieldUnit != "" && yieldUnit != defaultYieldUnit {
+			logger.Log.WithField("yield_unit", yieldUnit).Error("Error menu recipe yield unit")
+			return utils.NewValidationError(utils.FieldError("yield_unit", "A menu recipe yields portions"))
+		}
+		yieldUnit = defaultYieldUnit
+	}
+
+	if yieldQuantity > 0 {
+		version.YieldQuantity = yieldQuantity
+	}
+	if yieldUnit != "" {
+		version.YieldUnit = yieldUnit
+	}
+	if version.YieldQuantity <= 0 {
+		version.YieldQuantity = 1
+	}
+	if version.YieldUnit == "" {
+		version.YieldUnit = defaultYieldUnit
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// addNutrition adds quantity times the nutrition of one unit to total
+func addNutrition(total *domain.Nutrition, perUnit domain.Nutrition, quantity float64) {
+	total.Kcal += perUnit.Kcal * quantity
+	total.Protein += perUnit.Protein * quantity
+	total.Fat += perUnit.Fat * quantity
+	total.Carbs += perUnit.Carbs * quantity
+	total.Sodium += perUnit.Sodium * quantity
+}
+
+// sumIngredientNutrition adds up the nutrition of raw recipe lines and lists
+// the ingredients without nutrition data. Lookups are cached across calls,
+// nil marks an ingredient without data.
+func sumIngredientNutrition(ctx context.Context, adapters repository.Adapters, recipeIngredients []domain.SimpleRecipeIngredient, cache map[uuid.UUID]*domain.IngredientNutrition) (domain.Nutrition, []string, error) {
+	total := domain.Nutrition{}
+	missing := []string{}
+	for _, recipeIngredient := range recipeIngredients {
+		nutrition, ok := cache[recipeIngredient.IngredientId]
+		if !ok {
+			found, err := adapters.IngredientNutritionRepository.GetOneByIngredientId(ctx, recipeIngredient.IngredientId)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				logger.Log.WithError(err).Error("Error getting ingredient nutrition")
+				return domain.Nutrition{}, nil, utils.NewInternalError("Failed to get ingredient nutrition")
+			}
+			if err == nil {
+				nutrition = &found
+			}
+			cache[recipeIngredient.IngredientId] = nutrition
+		}
+		if nutrition == nil {
+			missing = append(missing, recipeIngredient.Name)
+			continue
+		}
+		addNutrition(&total, nutrition.Nutrition, recipeIngredient.BaseQuantity)
+	}
+	return total, missing, nil
+}
+
+// calculateRecipeNutrition works out the nutrition of the version of a
+// recipe in use now, per batch and per yield unit
+func calculateRecipeNutrition(ctx context.Context, adapters repository.Adapters, recipe domain.Recipe, cache map[uuid.UUID]*domain.IngredientNutrition) (domain.RecipeNutrition, error) {
+	result := domain.RecipeNutrition{
+		RecipeId: recipe.Id,
+		Name:     recipe.Name,
+		Missing:  []string{},
+	}
+
+	now := time.Now()
+	version, found, err := getActiveRecipeVersion(ctx, adapters, recipe.Id, now)
+	if err != nil || !found {
+		return result, err
+	}
+	result.VersionId = &version.Id
+	result.Version = version.Version
+	result.YieldQuantity = version.YieldQuantity
+	result.YieldUnit = version.YieldUnit
+
+	recipeIngredients, err := explodeRecipeVersion(ctx, adapters, version, now)
+	if err != nil {
+		return domain.RecipeNutrition{}, err
+	}
+	result.Batch, result.Missing, err = sumIngredientNutrition(ctx, adapters, recipeIngredients, cache)
+	if err != nil {
+		return domain.RecipeNutrition{}, err
+	}
+	if version.YieldQuantity > 0 {
+		addNutrition(&result.PerYieldUnit, result.Batch, 1/version.YieldQuantity)
+	}
+	result.Complete = len(result.Missing) == 0
+	return result, nil
+}
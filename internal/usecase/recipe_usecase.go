@@ -9,10 +9,19 @@ import (
 )
 
 type RecipeUsecase interface {
-	Create(ctx context.Context, req dto.CreateRecipeRequest) (domain.RecipeAndIngredients, error)
+	Create(ctx context.Context, req dto.CreateRecipeRequest, actorId uuid.UUID) (domain.RecipeAndIngredients, error)
 	GetAll(ctx context.Context) ([]domain.Recipe, error)
 	GetOneById(ctx context.Context, id uuid.UUID) (domain.RecipeAndIngredients, error)
-	Update(ctx context.Context, id uuid.UUID, req dto.UpdateRecipeRequest) (domain.RecipeAndIngredients, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.UpdateRecipeRequest, actorId uuid.UUID) (domain.RecipeAndIngredients, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	Restore(ctx context.Context, id uuid.UUID) (domain.Recipe, error)
+	GetCost(ctx context.Context, id uuid.UUID, req dto.GetRecipeCostRequest) (domain.RecipeCost, error)
+	GetVersions(ctx context.Context, id uuid.UUID) ([]domain.RecipeVersion, error)
+	GetVersion(ctx context.Context, id, versionId uuid.UUID) (domain.RecipeVersion, error)
+	CreateVersion(ctx context.Context, id uuid.UUID, actorId uuid.UUID, req dto.CreateRecipeVersionRequest) (domain.RecipeVersion, error)
+	UpdateVersion(ctx context.Context, id, versionId uuid.UUID, req dto.UpdateRecipeVersionRequest) (domain.RecipeVersion, error)
+	PublishVersion(ctx context.Context, id, versionId uuid.UUID, actorId uuid.UUID, req dto.PublishRecipeVersionRequest) (domain.RecipeVersion, error)
+	DeleteVersion(ctx context.Context, id, versionId uuid.UUID) error
+	GetBatchSheet(ctx context.Context, id uuid.UUID, req dto.GetRecipeBatchSheetRequest) (domain.RecipeBatchSheet, error)
+	GetNutrition(ctx context.Context, id uuid.UUID) (domain.RecipeNutrition, error)
 }
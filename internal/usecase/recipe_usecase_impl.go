@@ -2,6 +2,12 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/ryvasa/go-restaurant/internal/model/domain"
@@ -25,7 +31,7 @@ func NewRecipeUsecase(recipeRepo repository.RecipeRepository, menuRepo repositor
 	}
 }
 
-func (u *RecipeUsecaseImpl) Create(ctx context.Context, req dto.CreateRecipeRequest) (domain.RecipeAndIngredients, error) {
+func (u *RecipeUsecaseImpl) Create(ctx context.Context, req dto.CreateRecipeRequest, actorId uuid.UUID) (domain.RecipeAndIngredients, error) {
 	result := domain.RecipeAndIngredients{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		if err := utils.ValidateStruct(req); len(err) > 0 {
@@ -46,36 +52,24 @@ func (u *RecipeUsecaseImpl) Create(ctx context.Context, req dto.CreateRecipeRequ
 			return utils.NewInternalError("Failed to create recipe")
 		}
 
-		// loop through ingredients and create if not found
-		for _, ingredientReq := range req.Ingredients {
-			ingredient, err := adapters.IngredientRepository.GetOneByName(ctx, ingredientReq.Name)
-
-			// if ingredient not found create new ingredient
-			if err != nil {
-				ingredient = domain.Ingredient{
-					Id:          uuid.New(),
-					Name:        ingredientReq.Name,
-					Description: ingredientReq.Name,
-				}
-				err = adapters.IngredientRepository.Create(ctx, ingredient)
-				if err != nil {
-					logger.Log.WithError(err).Error("Error failed to create ingredient")
-					return utils.NewInternalError("Failed to create ingredient")
-				}
-			}
-
-			// create recipe ingredient / table junction
-			recipeIngredient := domain.RecipeIngredient{
-				Id:           uuid.New(),
-				RecipeId:     recipe.Id,
-				IngredientId: ingredient.Id,
-				Quantity:     ingredientReq.Quantity,
-			}
-			err = adapters.RecipeIngredientRepository.Create(ctx, recipeIngredient)
-			if err != nil {
-				logger.Log.WithError(err).Error("Error failed to create recipe ingredient")
-				return utils.NewInternalError("Failed to create recipe ingredient")
-			}
+		// the first version is published straight away
+		version, err := newRecipeVersion(ctx, adapters, recipe.Id, nil, "", &actorId)
+		if err != nil {
+			return err
+		}
+		err = changeRecipeVersion(ctx, adapters, recipe, &version, dto.UpdateRecipeVersionRequest{
+			YieldQuantity: req.YieldQuantity,
+			YieldUnit:     req.YieldUnit,
+			Ingredients:   req.Ingredients,
+			Components:    req.Components,
+			Steps:         req.Steps,
+		})
+		if err != nil {
+			return err
+		}
+		err = publishRecipeVersion(ctx, adapters, version, time.Now(), &actorId)
+		if err != nil {
+			return err
 		}
 
 		// get created recipe
@@ -84,22 +78,8 @@ func (u *RecipeUsecaseImpl) Create(ctx context.Context, req dto.CreateRecipeRequ
 			logger.Log.WithError(err).Error("Error failed to get created recipe")
 			return utils.NewInternalError("Failed to get created recipe")
 		}
-		result.Id = createdRecipe.Id
-		result.Name = createdRecipe.Name
-		result.Description = createdRecipe.Description
-		result.MenuId = createdRecipe.MenuId
-		result.CreatedAt = createdRecipe.CreatedAt
-		result.UpdatedAt = createdRecipe.UpdatedAt
-
-		// get created ingredients
-		createdIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeId(ctx, createdRecipe.Id)
-		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to get created ingredients")
-			return utils.NewInternalError("Failed to get created ingredients")
-		}
-		result.Ingredients = createdIngredients
-
-		return nil
+		result, err = getRecipeAndIngredients(ctx, adapters, createdRecipe)
+		return err
 	})
 	if err != nil {
 		logger.Log.Error(err)
@@ -125,20 +105,8 @@ func (u *RecipeUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domai
 			logger.Log.WithError(err).Error("Error recipe not found")
 			return utils.NewNotFoundError("Recipe not found")
 		}
-		result.Id = recipe.Id
-		result.Name = recipe.Name
-		result.Description = recipe.Description
-		result.MenuId = recipe.MenuId
-		result.CreatedAt = recipe.CreatedAt
-		result.UpdatedAt = recipe.UpdatedAt
-
-		recipeIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeId(ctx, recipe.Id)
-		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to get ingredients")
-			return utils.NewInternalError("Failed to get ingredients")
-		}
-		result.Ingredients = recipeIngredients
-		return nil
+		result, err = getRecipeAndIngredients(ctx, adapters, recipe)
+		return err
 	})
 	if err != nil {
 		logger.Log.Error(err)
@@ -148,7 +116,9 @@ func (u *RecipeUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domai
 	return result, nil
 }
 
-func (u *RecipeUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateRecipeRequest) (domain.RecipeAndIngredients, error) {
+// Update renames a recipe in place, changes to its lines, steps or yield are
+// published as a new version from now on
+func (u *RecipeUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateRecipeRequest, actorId uuid.UUID) (domain.RecipeAndIngredients, error) {
 	result := domain.RecipeAndIngredients{}
 	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
 		if err := utils.ValidateStruct(req); len(err) > 0 {
@@ -176,80 +146,53 @@ func (u *RecipeUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.Up
 			return utils.NewInternalError("Failed to update recipe")
 		}
 
-		// get updated recipe
-		updatedRecipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
-		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to get updated recipe")
-			return utils.NewInternalError("Failed to get updated recipe")
-		}
-		result.Id = updatedRecipe.Id
-		result.Name = updatedRecipe.Name
-		result.Description = updatedRecipe.Description
-		result.MenuId = updatedRecipe.MenuId
-		result.CreatedAt = updatedRecipe.CreatedAt
-		result.UpdatedAt = updatedRecipe.UpdatedAt
-
-		// if ingredients are provided in request body
-		if req.Ingredients != nil {
-
-			// loop for each ingredient in request body
-			for _, ingredientReq := range req.Ingredients {
-				ingredient, err := adapters.IngredientRepository.GetOneByName(ctx, ingredientReq.Name)
-
-				// if ingredient not found create new ingredient
-				if err != nil {
-					ingredient = domain.Ingredient{
-						Id:          uuid.New(),
-						Name:        ingredientReq.Name,
-						Description: ingredientReq.Name,
-					}
-					err = adapters.IngredientRepository.Create(ctx, ingredient)
-					if err != nil {
-						logger.Log.WithError(err).Error("Error failed to create ingredient")
-						return utils.NewInternalError("Failed to create ingredient")
-					}
-				}
-
-				// if ingredient exists in database, find in table junction
-				recepIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeIdAndIngredientId(ctx, existingRecipe.Id, ingredient.Id)
-
-				// if in table junction does not exist, create new
-				if err != nil {
-					// create recipe ingredient / table junction
-					recipeIngredient := domain.RecipeIngredient{
-						Id:           uuid.New(),
-						RecipeId:     existingRecipe.Id,
-						IngredientId: ingredient.Id,
-						Quantity:     ingredientReq.Quantity,
-					}
-					err = adapters.RecipeIngredientRepository.Create(ctx, recipeIngredient)
-					if err != nil {
-						logger.Log.WithError(err).Error("Error failed to create recipe ingredient")
-						return utils.NewInternalError("Failed to create recipe ingredient")
-					}
-				}
-
-				// if in table junction, ingredient quantity does not match with request body, update,
-				if recepIngredients.Quantity != ingredientReq.Quantity && ingredientReq.Name == ingredient.Name {
-					recepIngredients.Quantity = ingredientReq.Quantity
-					err = adapters.RecipeIngredientRepository.Update(ctx, recepIngredients.Id, recepIngredients)
-					if err != nil {
-						logger.Log.WithError(err).Error("Error failed to update recipe ingredient")
-						return utils.NewInternalError("Failed to update recipe ingredient")
-					}
-				}
+		if req.Ingredients != nil || req.Components != nil || req.Steps != nil || req.YieldQuantity != 0 || req.YieldUnit != "" {
+			_, err := adapters.RecipeVersionRepository.GetDraftByRecipeId(ctx, id)
+			if err == nil {
+				logger.Log.WithField("recipe_id", id).Error("Error recipe has a draft version")
+				return utils.NewConflictError("Recipe has a draft version, change the draft and publish it instead")
+			}
+			if !errors.Is(err, sql.ErrNoRows) {
+				logger.Log.WithError(err).Error("Error getting draft recipe version")
+				return utils.NewInternalError("Failed to get recipe versions")
+			}
 
+			var from *domain.RecipeVersion
+			current, found, err := getActiveRecipeVersion(ctx, adapters, id, time.Now())
+			if err != nil {
+				return err
+			}
+			if found {
+				from = &current
+			}
+			version, err := newRecipeVersion(ctx, adapters, id, from, "", &actorId)
+			if err != nil {
+				return err
+			}
+			err = changeRecipeVersion(ctx, adapters, existingRecipe, &version, dto.UpdateRecipeVersionRequest{
+				YieldQuantity: req.YieldQuantity,
+				YieldUnit:     req.YieldUnit,
+				Ingredients:   req.Ingredients,
+				Components:    req.Components,
+				Steps:         req.Steps,
+			})
+			if err != nil {
+				return err
+			}
+			err = publishRecipeVersion(ctx, adapters, version, time.Now(), &actorId)
+			if err != nil {
+				return err
 			}
 		}
 
-		// get updated ingredients
-		updatedIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeId(ctx, updatedRecipe.Id)
+		// get updated recipe
+		updatedRecipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
 		if err != nil {
-			logger.Log.WithError(err).Error("Error failed to get updated ingredients")
-			return utils.NewInternalError("Failed to get updated ingredients")
+			logger.Log.WithError(err).Error("Error failed to get updated recipe")
+			return utils.NewInternalError("Failed to get updated recipe")
 		}
-		result.Ingredients = updatedIngredients
-		return nil
+		result, err = getRecipeAndIngredients(ctx, adapters, updatedRecipe)
+		return err
 	})
 	if err != nil {
 		logger.Log.Error(err)
@@ -266,6 +209,16 @@ func (u *RecipeUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
 			return utils.NewNotFoundError("Recipe not found")
 		}
 
+		parents, err := adapters.RecipeComponentRepository.GetParentNamesByComponentRecipeId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting recipes using recipe")
+			return utils.NewInternalError("Failed to get recipes using recipe")
+		}
+		if len(parents) > 0 {
+			logger.Log.WithField("recipe_id", id).Error("Error deleting a component recipe")
+			return utils.NewConflictError(fmt.Sprintf("Recipe is used by %s", strings.Join(parents, ", ")))
+		}
+
 		err = adapters.RecipeRepository.Delete(ctx, id)
 		if err != nil {
 			logger.Log.WithError(err).Error("Error failed to delete recipe")
@@ -310,3 +263,402 @@ func (u *RecipeUsecaseImpl) Restore(ctx context.Context, id uuid.UUID) (domain.R
 	}
 	return recipe, nil
 }
+
+// getRecipeAndIngredients adds the lines of the version in use now as
+// entered, ingredients and sub-recipes, and its steps to the recipe
+func getRecipeAndIngredients(ctx context.Context, adapters repository.Adapters, recipe domain.Recipe) (domain.RecipeAndIngredients, error) {
+	result := domain.RecipeAndIngredients{
+		Id:            recipe.Id,
+		Name:          recipe.Name,
+		Description:   recipe.Description,
+		MenuId:        recipe.MenuId,
+		VersionId:     recipe.VersionId,
+		Version:       recipe.Version,
+		YieldQuantity: recipe.YieldQuantity,
+		YieldUnit:     recipe.YieldUnit,
+		Ingredients:   []domain.SimpleRecipeIngredient{},
+		Components:    []domain.SimpleRecipeComponent{},
+		Steps:         []domain.RecipeStep{},
+		CreatedAt:     recipe.CreatedAt,
+		UpdatedAt:     recipe.UpdatedAt,
+	}
+	if recipe.VersionId == nil {
+		return result, nil
+	}
+
+	version, err := getRecipeVersion(ctx, adapters, recipe.Id, *recipe.VersionId)
+	if err != nil {
+		return domain.RecipeAndIngredients{}, err
+	}
+	if version.Ingredients != nil {
+		result.Ingredients = version.Ingredients
+	}
+	result.Components = version.Components
+	result.Steps = version.Steps
+	return result, nil
+}
+
+// resolveRecipeIngredient finds the ingredient of a recipe line by name, or
+// creates it with the reference unit of the line's unit as base unit, and
+// returns the unit the line is stored in after checking it converts
+func resolveRecipeIngredient(ctx context.Context, adapters repository.Adapters, req dto.CreateIngredientRequest) (domain.Ingredient, string, error) {
+	unit := utils.NormalizeUnit(req.Unit)
+
+	ingredient, err := adapters.IngredientRepository.GetOneByName(ctx, req.Name)
+
+	// if ingredient not found create new ingredient
+	if err != nil {
+		baseUnit := "g"
+		if unit != "" {
+			referenceUnit, ok := utils.ReferenceUnit(unit)
+			if !ok {
+				logger.Log.WithField("unit", unit).Error("Error unknown unit for new ingredient")
+				return domain.Ingredient{}, "", utils.NewValidationError(utils.FieldError("unit", fmt.Sprintf("Unit %s is not a standard unit, create %s with a standard unit first", unit, req.Name)))
+			}
+			baseUnit = referenceUnit
+		}
+		ingredient = domain.Ingredient{
+			Id:          uuid.New(),
+			Name:        req.Name,
+			Description: req.Name,
+			BaseUnit:    baseUnit,
+		}
+		err = adapters.IngredientRepository.Create(ctx, ingredient)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create ingredient")
+			return domain.Ingredient{}, "", utils.NewInternalError("Failed to create ingredient")
+		}
+	}
+
+	if unit == "" {
+		return ingredient, ingredient.BaseUnit, nil
+	}
+	if _, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, req.Quantity, unit); err != nil {
+		return domain.Ingredient{}, "", err
+	}
+	return ingredient, unit, nil
+}
+
+func (u *RecipeUsecaseImpl) GetCost(ctx context.Context, id uuid.UUID, req dto.GetRecipeCostRequest) (domain.RecipeCost, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.RecipeCost{}, utils.NewValidationError(err)
+	}
+	// the cost on a date is the cost at the end of that day
+	_, endOfDay, err := parseDateRange("at", "", "at", req.At)
+	if err != nil {
+		return domain.RecipeCost{}, err
+	}
+	var at *time.Time
+	if endOfDay != nil {
+		lastSecond := endOfDay.Add(-time.Second)
+		at = &lastSecond
+	}
+
+	result := domain.RecipeCost{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		recipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+
+		recipeCost, err := calculateRecipeCost(ctx, adapters, recipe, at)
+		if err != nil {
+			return err
+		}
+		result = recipeCost
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating recipe cost")
+		return result, err
+	}
+
+	return result, nil
+}
+
+func (u *RecipeUsecaseImpl) GetVersions(ctx context.Context, id uuid.UUID) ([]domain.RecipeVersion, error) {
+	result := []domain.RecipeVersion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		_, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+
+		versions, err := adapters.RecipeVersionRepository.GetAllByRecipeId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting recipe versions")
+			return utils.NewInternalError("Failed to get recipe versions")
+		}
+		result = versions
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *RecipeUsecaseImpl) GetVersion(ctx context.Context, id, versionId uuid.UUID) (domain.RecipeVersion, error) {
+	result := domain.RecipeVersion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		version, err := getRecipeVersion(ctx, adapters, id, versionId)
+		if err != nil {
+			return err
+		}
+		result = version
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return result, err
+	}
+	return result, nil
+}
+
+// CreateVersion starts a draft from the latest published version, a recipe
+// has at most one draft at a time
+func (u *RecipeUsecaseImpl) CreateVersion(ctx context.Context, id uuid.UUID, actorId uuid.UUID, req dto.CreateRecipeVersionRequest) (domain.RecipeVersion, error) {
+	result := domain.RecipeVersion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+		_, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+
+		versions, err := adapters.RecipeVersionRepository.GetAllByRecipeId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting recipe versions")
+			return utils.NewInternalError("Failed to get recipe versions")
+		}
+		var from *domain.RecipeVersion
+		for i := range versions {
+			if versions[i].Status == domain.RecipeVersionStatusDraft {
+				logger.Log.WithField("recipe_id", id).Error("Error recipe already has a draft version")
+				return utils.NewConflictError(fmt.Sprintf("Recipe already has a draft, version %d", versions[i].Version))
+			}
+			// versions are sorted newest first
+			if from == nil {
+				from = &versions[i]
+			}
+		}
+
+		version, err := newRecipeVersion(ctx, adapters, id, from, req.Notes, &actorId)
+		if err != nil {
+			return err
+		}
+		result, err = getRecipeVersion(ctx, adapters, id, version.Id)
+		return err
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *RecipeUsecaseImpl) UpdateVersion(ctx context.Context, id, versionId uuid.UUID, req dto.UpdateRecipeVersionRequest) (domain.RecipeVersion, error) {
+	result := domain.RecipeVersion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+		recipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+		version, err := getRecipeVersion(ctx, adapters, id, versionId)
+		if err != nil {
+			return err
+		}
+
+		err = changeRecipeVersion(ctx, adapters, recipe, &version, req)
+		if err != nil {
+			return err
+		}
+		result, err = getRecipeVersion(ctx, adapters, id, versionId)
+		return err
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return result, err
+	}
+	return result, nil
+}
+
+// PublishVersion makes a draft the recipe from EffectiveFrom on, an empty
+// version cannot be published
+func (u *RecipeUsecaseImpl) PublishVersion(ctx context.Context, id, versionId uuid.UUID, actorId uuid.UUID, req dto.PublishRecipeVersionRequest) (domain.RecipeVersion, error) {
+	result := domain.RecipeVersion{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		version, err := getRecipeVersion(ctx, adapters, id, versionId)
+		if err != nil {
+			return err
+		}
+		if version.Status != domain.RecipeVersionStatusDraft {
+			logger.Log.WithField("status", version.Status).Error("Error publishing a published recipe version")
+			return utils.NewConflictError("Only a draft version can be published")
+		}
+		if len(version.Ingredients) == 0 && len(version.Components) == 0 {
+			logger.Log.WithField("recipe_version_id", versionId).Error("Error publishing an empty recipe version")
+			return utils.NewValidationError(utils.FieldError("ingredients", "A version needs at least one ingredient or component"))
+		}
+
+		now := time.Now()
+		effectiveFrom := now
+		if req.EffectiveFrom != nil {
+			if req.EffectiveFrom.Before(now.Add(-time.Minute)) {
+				logger.Log.WithField("effective_from", req.EffectiveFrom).Error("Error effective from in the past")
+				return utils.NewValidationError(utils.FieldError("effective_from", "Effective from cannot be in the past"))
+			}
+			if req.EffectiveFrom.After(now) {
+				effectiveFrom = *req.EffectiveFrom
+			}
+		}
+
+		err = publishRecipeVersion(ctx, adapters, version, effectiveFrom, &actorId)
+		if err != nil {
+			return err
+		}
+		result, err = getRecipeVersion(ctx, adapters, id, versionId)
+		return err
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return result, err
+	}
+	return result, nil
+}
+
+// DeleteVersion discards a draft, published versions stay for history
+func (u *RecipeUsecaseImpl) DeleteVersion(ctx context.Context, id, versionId uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		version, err := getRecipeVersion(ctx, adapters, id, versionId)
+		if err != nil {
+			return err
+		}
+		if version.Status != domain.RecipeVersionStatusDraft {
+			logger.Log.WithField("status", version.Status).Error("Error deleting a published recipe version")
+			return utils.NewConflictError("Only a draft version can be deleted")
+		}
+
+		err = adapters.RecipeVersionRepository.DeleteDraft(ctx, versionId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete recipe version")
+			return utils.NewInternalError("Failed to delete recipe version")
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetBatchSheet scales the version of a recipe in use now to the requested
+// quantity, scaled lines are shown in the most readable unit
+func (u *RecipeUsecaseImpl) GetBatchSheet(ctx context.Context, id uuid.UUID, req dto.GetRecipeBatchSheetRequest) (domain.RecipeBatchSheet, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.RecipeBatchSheet{}, utils.NewValidationError(err)
+	}
+	quantity, err := strconv.ParseFloat(req.Quantity, 64)
+	if err != nil || quantity <= 0 {
+		logger.Log.WithField("quantity", req.Quantity).Error("Error invalid batch quantity")
+		return domain.RecipeBatchSheet{}, utils.NewValidationError(utils.FieldError("quantity", "Quantity must be greater than 0"))
+	}
+
+	result := domain.RecipeBatchSheet{}
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		recipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+		if recipe.VersionId == nil {
+			logger.Log.WithField("recipe_id", id).Error("Error recipe has no version in effect")
+			return utils.NewConflictError("Recipe has no published version in effect yet")
+		}
+		version, err := getRecipeVersion(ctx, adapters, id, *recipe.VersionId)
+		if err != nil {
+			return err
+		}
+
+		unit := utils.NormalizeUnit(req.Unit)
+		if unit == "" {
+			unit = version.YieldUnit
+		}
+		yieldQuantity, ok := utils.ConvertUnit(quantity, unit, version.YieldUnit)
+		if !ok {
+			logger.Log.WithField("unit", unit).WithField("yield_unit", version.YieldUnit).Error("Error incompatible batch unit")
+			return utils.NewValidationError(utils.FieldError("unit", fmt.Sprintf("Unit %s cannot be converted to %s, the yield unit of %s", unit, version.YieldUnit, recipe.Name)))
+		}
+		batches := yieldQuantity / version.YieldQuantity
+
+		result = domain.RecipeBatchSheet{
+			RecipeId:      recipe.Id,
+			Name:          recipe.Name,
+			MenuId:        recipe.MenuId,
+			VersionId:     version.Id,
+			Version:       version.Version,
+			YieldQuantity: version.YieldQuantity,
+			YieldUnit:     version.YieldUnit,
+			Quantity:      yieldQuantity,
+			Batches:       batches,
+			Ingredients:   []domain.SimpleRecipeIngredient{},
+			Components:    []domain.SimpleRecipeComponent{},
+			Steps:         version.Steps,
+		}
+		if version.Ingredients != nil {
+			result.Ingredients = version.Ingredients
+		}
+		scaleRecipeIngredients(result.Ingredients, batches)
+		for i := range result.Ingredients {
+			result.Ingredients[i].Quantity, result.Ingredients[i].Unit = utils.ReadableQuantity(result.Ingredients[i].Quantity, result.Ingredients[i].Unit)
+		}
+		for _, component := range version.Components {
+			component.Quantity, component.Unit = utils.ReadableQuantity(component.Quantity*batches, component.Unit)
+			result.Components = append(result.Components, component)
+		}
+		for _, step := range version.Steps {
+			result.TotalDurationMinutes += step.DurationMinutes
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe batch sheet")
+		return result, err
+	}
+	return result, nil
+}
+
+// GetNutrition works out the nutrition of the version of a recipe in use now
+func (u *RecipeUsecaseImpl) GetNutrition(ctx context.Context, id uuid.UUID) (domain.RecipeNutrition, error) {
+	result := domain.RecipeNutrition{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		recipe, err := adapters.RecipeRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error recipe not found")
+			return utils.NewNotFoundError("Recipe not found")
+		}
+
+		result, err = calculateRecipeNutrition(ctx, adapters, recipe, map[uuid.UUID]*domain.IngredientNutrition{})
+		return err
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating recipe nutrition")
+		return result, err
+	}
+	return result, nil
+}
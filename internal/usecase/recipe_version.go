@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// getActiveRecipeVersion returns the version of a recipe in use at a moment,
+// found is false when no version was in effect yet
+func getActiveRecipeVersion(ctx context.Context, adapters repository.Adapters, recipeId uuid.UUID, at time.Time) (domain.RecipeVersion, bool, error) {
+	version, err := adapters.RecipeVersionRepository.GetActiveByRecipeId(ctx, recipeId, at)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.RecipeVersion{}, false, nil
+	}
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe version")
+		return domain.RecipeVersion{}, false, utils.NewInternalError("Failed to get recipe version")
+	}
+	return version, true, nil
+}
+
+// getRecipeVersion loads a version of the recipe with its lines
+func getRecipeVersion(ctx context.Context, adapters repository.Adapters, recipeId, versionId uuid.UUID) (domain.RecipeVersion, error) {
+	version, err := adapters.RecipeVersionRepository.GetOneById(ctx, versionId)
+	if err != nil || version.RecipeId != recipeId {
+		logger.Log.WithError(err).Error("Error recipe version not found")
+		return domain.RecipeVersion{}, utils.NewNotFoundError("Recipe version not found")
+	}
+	if err := getRecipeVersionLines(ctx, adapters, &version); err != nil {
+		return domain.RecipeVersion{}, err
+	}
+	return version, nil
+}
+
+// getRecipeVersionLines sets the ingredient and component lines and the
+// steps of a version, components show the yield of their version in use now
+func getRecipeVersionLines(ctx context.Context, adapters repository.Adapters, version *domain.RecipeVersion) error {
+	recipeIngredients, err := getRecipeIngredients(ctx, adapters, version.Id)
+	if err != nil {
+		return err
+	}
+	version.Ingredients = recipeIngredients
+
+	steps, err := adapters.RecipeStepRepository.GetStepsByRecipeVersionId(ctx, version.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe steps")
+		return utils.NewInternalError("Failed to get recipe steps")
+	}
+	version.Steps = steps
+
+	components, err := adapters.RecipeComponentRepository.GetComponentsByRecipeVersionId(ctx, version.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe components")
+		return utils.NewInternalError("Failed to get recipe components")
+	}
+	now := time.Now()
+	for i := range components {
+		if _, err := getComponentVersion(ctx, adapters, &components[i], now); err != nil {
+			return err
+		}
+	}
+	version.Components = components
+	return nil
+}
+
+// newRecipeVersion creates a draft numbered after the latest version of the
+// recipe, with the yield, lines and steps of from when it is given
+func newRecipeVersion(ctx context.Context, adapters repository.Adapters, recipeId uuid.UUID, from *domain.RecipeVersion, notes string, actorId *uuid.UUID) (domain.RecipeVersion, error) {
+	latest, err := adapters.RecipeVersionRepository.GetMaxVersionByRecipeId(ctx, recipeId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting latest recipe version")
+		return domain.RecipeVersion{}, utils.NewInternalError("Failed to get recipe versions")
+	}
+
+	version := domain.RecipeVersion{
+		Id:            uuid.New(),
+		RecipeId:      recipeId,
+		Version:       latest + 1,
+		Status:        domain.RecipeVersionStatusDraft,
+		YieldQuantity: 1,
+		YieldUnit:     defaultYieldUnit,
+		CreatedBy:     actorId,
+	}
+	if notes != "" {
+		version.Notes = &notes
+	}
+	if from != nil {
+		version.YieldQuantity = from.YieldQuantity
+		version.YieldUnit = from.YieldUnit
+	}
+	err = adapters.RecipeVersionRepository.Create(ctx, version)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create recipe version")
+		return domain.RecipeVersion{}, utils.NewInternalError("Failed to create recipe version")
+	}
+	if from == nil {
+		return version, nil
+	}
+
+	recipeIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeVersionId(ctx, from.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Ingredients")
+		return domain.RecipeVersion{}, utils.NewInternalError("Failed to get ingredients")
+	}
+	for _, recipeIngredient := range recipeIngredients {
+		err = adapters.RecipeIngredientRepository.Create(ctx, domain.RecipeIngredient{
+			Id:              uuid.New(),
+			RecipeId:        recipeId,
+			RecipeVersionId: version.Id,
+			IngredientId:    recipeIngredient.IngredientId,
+			Quantity:        recipeIngredient.Quantity,
+			Unit:            recipeIngredient.Unit,
+		})
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create recipe ingredient")
+			return domain.RecipeVersion{}, utils.NewInternalError("Failed to create recipe ingredient")
+		}
+	}
+
+	components, err := adapters.RecipeComponentRepository.GetComponentsByRecipeVersionId(ctx, from.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe components")
+		return domain.RecipeVersion{}, utils.NewInternalError("Failed to get recipe components")
+	}
+	for _, component := range components {
+		err = adapters.RecipeComponentRepository.Create(ctx, domain.RecipeComponent{
+			Id:                uuid.New(),
+			RecipeId:          recipeId,
+			RecipeVersionId:   version.Id,
+			ComponentRecipeId: component.RecipeId,
+			Quantity:          component.Quantity,
+			Unit:              component.Unit,
+		})
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create recipe component")
+			return domain.RecipeVersion{}, utils.NewInternalError("Failed to create recipe component")
+		}
+	}
+
+	steps, err := adapters.RecipeStepRepository.GetStepsByRecipeVersionId(ctx, from.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting recipe steps")
+		return domain.RecipeVersion{}, utils.NewInternalError("Failed to get recipe steps")
+	}
+	for _, step := range steps {
+		step.Id = uuid.New()
+		step.RecipeVersionId = version.Id
+		err = adapters.RecipeStepRepository.Create(ctx, step)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create recipe step")
+			return domain.RecipeVersion{}, utils.NewInternalError("Failed to create recipe step")
+		}
+	}
+	return version, nil
+}
+
+// changeRecipeVersion applies the yield and line changes of a request to a
+// draft version
+func changeRecipeVersion(ctx context.Context, adapters repository.Adapters, recipe domain.Recipe, version *domain.RecipeVersion, req dto.UpdateRecipeVersionRequest) error {
+	if version.Status != domain.RecipeVersionStatusDraft {
+		logger.Log.WithField("status", version.Status).Error("Error changing a published recipe version")
+		return utils.NewConflictError("Only a draft version can be changed")
+	}
+
+	if err := resolveRecipeYield(recipe.MenuId, version, req.YieldQuantity, req.YieldUnit); err != nil {
+		return err
+	}
+	if req.Notes != "" {
+		version.Notes = &req.Notes
+	}
+	err := adapters.RecipeVersionRepository.UpdateDraft(ctx, version.Id, *version)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to update recipe version")
+		return utils.NewInternalError("Failed to update recipe version")
+	}
+
+	if err := saveRecipeIngredients(ctx, adapters, *version, req.Ingredients); err != nil {
+		return err
+	}
+	for _, ingredientId := range req.RemoveIngredientIds {
+		err := adapters.RecipeIngredientRepository.DeleteByRecipeVersionIdAndIngredientId(ctx, version.Id, ingredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to remove recipe ingredient")
+			return utils.NewNotFoundError(fmt.Sprintf("Ingredient %s is not in this version", ingredientId))
+		}
+	}
+
+	if err := saveRecipeComponents(ctx, adapters, *version, req.Components); err != nil {
+		return err
+	}
+	for _, componentId := range req.RemoveComponentIds {
+		err := adapters.RecipeComponentRepository.DeleteByRecipeVersionIdAndComponentRecipeId(ctx, version.Id, componentId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to remove recipe component")
+			return utils.NewNotFoundError(fmt.Sprintf("Component %s is not in this version", componentId))
+		}
+	}
+
+	if req.Steps != nil {
+		return saveRecipeSteps(ctx, adapters, *version, req.Steps)
+	}
+	return nil
+}
+
+// saveRecipeSteps replaces the steps of a draft version, steps are numbered
+// from 1 in the order given
+func saveRecipeSteps(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, reqs []dto.CreateRecipeStepRequest) error {
+	err := adapters.RecipeStepRepository.DeleteByRecipeVersionId(ctx, version.Id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to remove recipe steps")
+		return utils.NewInternalError("Failed to remove recipe steps")
+	}
+
+	for i, stepReq := range reqs {
+		step := domain.RecipeStep{
+			Id:              uuid.New(),
+			RecipeVersionId: version.Id,
+			Position:        i + 1,
+			Instruction:     strings.TrimSpace(stepReq.Instruction),
+			DurationMinutes: stepReq.DurationMinutes,
+		}
+		if station := strings.TrimSpace(stepReq.Station); station != "" {
+			step.Station = &station
+		}
+		err = adapters.RecipeStepRepository.Create(ctx, step)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create recipe step")
+			return utils.NewInternalError("Failed to create recipe step")
+		}
+	}
+	return nil
+}
+
+// saveRecipeIngredients creates the ingredient lines of a draft version, or
+// updates the quantity of lines that already exist
+func saveRecipeIngredients(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, reqs []dto.CreateIngredientRequest) error {
+	for _, ingredientReq := range reqs {
+		ingredient, unit, err := resolveRecipeIngredient(ctx, adapters, ingredientReq)
+		if err != nil {
+			return err
+		}
+
+		existing, err := adapters.RecipeIngredientRepository.GetOneByRecipeVersionIdAndIngredientId(ctx, version.Id, ingredient.Id)
+		if err != nil {
+			recipeIngredient := domain.RecipeIngredient{
+				Id:              uuid.New(),
+				RecipeId:        version.RecipeId,
+				RecipeVersionId: version.Id,
+				IngredientId:    ingredient.Id,
+				Quantity:        ingredientReq.Quantity,
+				Unit:            unit,
+			}
+			err = adapters.RecipeIngredientRepository.Create(ctx, recipeIngredient)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create recipe ingredient")
+				return utils.NewInternalError("Failed to create recipe ingredient")
+			}
+		} else if existing.Quantity != ingredientReq.Quantity || existing.Unit != unit {
+			existing.Quantity = ingredientReq.Quantity
+			existing.Unit = unit
+			err = adapters.RecipeIngredientRepository.Update(ctx, existing.Id, existing)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update recipe ingredient")
+				return utils.NewInternalError("Failed to update recipe ingredient")
+			}
+		}
+	}
+	return nil
+}
+
+// publishRecipeVersion freezes a draft from effectiveFrom on. Recipes using
+// this one measure it in its yield unit, so while it is used the unit may
+// only change within the same dimension.
+func publishRecipeVersion(ctx context.Context, adapters repository.Adapters, version domain.RecipeVersion, effectiveFrom time.Time, actorId *uuid.UUID) error {
+	current, found, err := getActiveRecipeVersion(ctx, adapters, version.RecipeId, time.Now())
+	if err != nil {
+		return err
+	}
+	if _, ok := utils.ConvertUnit(1, current.YieldUnit, version.YieldUnit); found && !ok {
+		parents, err := adapters.RecipeComponentRepository.GetParentNamesByComponentRecipeId(ctx, version.RecipeId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting recipes using recipe")
+			return utils.NewInternalError("Failed to get recipes using recipe")
+		}
+		if len(parents) > 0 {
+			logger.Log.WithField("yield_unit", version.YieldUnit).Error("Error changing yield unit of a component recipe")
+			return utils.NewConflictError(fmt.Sprintf("Yield unit cannot change to %s while the recipe is used by %s", version.YieldUnit, strings.Join(parents, ", ")))
+		}
+	}
+
+	// timestamps are stored to the second, rounding up could push a version
+	// meant for now into the next second
+	err = adapters.RecipeVersionRepository.Publish(ctx, version.Id, effectiveFrom.Truncate(time.Second), actorId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to publish recipe version")
+		return utils.NewInternalError("Failed to publish recipe version")
+	}
+	return nil
+}
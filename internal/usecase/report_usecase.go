@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type ReportUsecase interface {
+	GetMenuMargins(ctx context.Context, req dto.GetMenuMarginsRequest) (domain.MenuMarginReport, error)
+	GetWaste(ctx context.Context, req dto.GetWasteReportRequest) (domain.WasteReport, error)
+	GetIngredientForecast(ctx context.Context, req dto.GetIngredientForecastRequest) (domain.IngredientForecastReport, error)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/config"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type ReportUsecaseImpl struct {
+	targetMarginPercent float64
+	wasteLogRepo        repository.WasteLogRepository
+	txRepo              repository.TransactionRepository
+}
+
+func NewReportUsecase(cfg *config.Config, wasteLogRepo repository.WasteLogRepository, txRepo repository.TransactionRepository) ReportUsecase {
+	return &ReportUsecaseImpl{
+		targetMarginPercent: cfg.Costing.TargetMarginPercent,
+		wasteLogRepo:        wasteLogRepo,
+		txRepo:              txRepo,
+	}
+}
+
+func (u *ReportUsecaseImpl) GetMenuMargins(ctx context.Context, req dto.GetMenuMarginsRequest) (domain.MenuMarginReport, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.MenuMarginReport{}, utils.NewValidationError(err)
+	}
+
+	result := domain.MenuMarginReport{
+		TargetMarginPercent: u.targetMarginPercent,
+		Items:               []domain.MenuMargin{},
+	}
+	if req.TargetMargin != "" {
+		targetMargin, err := strconv.ParseFloat(req.TargetMargin, 64)
+		if err != nil || targetMargin < 0 || targetMargin > 100 {
+			logger.Log.WithField("target_margin", req.TargetMargin).Error("Error invalid target margin")
+			return domain.MenuMarginReport{}, utils.NewValidationError(utils.FieldError("target_margin", "Target margin must be between 0 and 100"))
+		}
+		result.TargetMarginPercent = targetMargin
+	}
+
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		menus, err := adapters.MenuRepository.GetAll(ctx)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting Menu")
+			return utils.NewInternalError("Failed to get all menu")
+		}
+
+		for _, menu := range menus {
+			margin, err := calculateMenuMargin(ctx, adapters, menu, result.TargetMarginPercent)
+			if err != nil {
+				return err
+			}
+			if margin.BelowTarget {
+				result.BelowTargetCount++
+			}
+			result.Items = append(result.Items, margin)
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating menu margins")
+		return domain.MenuMarginReport{}, err
+	}
+
+	return result, nil
+}
+
+func (u *ReportUsecaseImpl) GetWaste(ctx context.Context, req dto.GetWasteReportRequest) (domain.WasteReport, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.WasteReport{}, utils.NewValidationError(err)
+	}
+
+	if req.Period == "" {
+		req.Period = "day"
+	}
+	from, to, err := parseDateRange("from", req.From, "to", req.To)
+	if err != nil {
+		return domain.WasteReport{}, err
+	}
+	filter := domain.WasteLogFilter{CreatedFrom: from, CreatedTo: to}
+
+	byReason, err := u.wasteLogRepo.SumByReason(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to sum waste by reason")
+		return domain.WasteReport{}, utils.NewInternalError("Failed to get waste report")
+	}
+	byPeriod, err := u.wasteLogRepo.SumByPeriod(ctx, filter, req.Period)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to sum waste by period")
+		return domain.WasteReport{}, utils.NewInternalError("Failed to get waste report")
+	}
+
+	report := domain.WasteReport{
+		From:     from,
+		To:       to,
+		Period:   req.Period,
+		ByReason: byReason,
+		ByPeriod: byPeriod,
+	}
+	for _, summary := range byReason {
+		report.Entries += summary.Entries
+		report.TotalCost += summary.Cost
+	}
+	return report, nil
+}
+
+// forecast defaults, eight weeks of history gives every weekday eight samples
+const (
+	defaultForecastDays        = 7
+	defaultForecastHistoryDays = 56
+	defaultForecastWindow      = 28
+	maxForecastDays            = 90
+	maxForecastHistoryDays     = 365
+)
+
+// parseForecastDays reads a whole number of days between 1 and max, empty
+// values fall back to the default
+func parseForecastDays(field, value string, fallback, max int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > max {
+		logger.Log.WithField(field, value).Errorf("Error invalid %s", field)
+		return 0, utils.NewValidationError(utils.FieldError(field, fmt.Sprintf("Must be a whole number between 1 and %d", max)))
+	}
+	return days, nil
+}
+
+// GetIngredientForecast projects menu sales from the order history, explodes
+// them through the recipes and compares the ingredient usage with the stock
+// on hand and on order
+func (u *ReportUsecaseImpl) GetIngredientForecast(ctx context.Context, req dto.GetIngredientForecastRequest) (domain.IngredientForecastReport, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.IngredientForecastReport{}, utils.NewValidationError(err)
+	}
+
+	days, err := parseForecastDays("days", req.Days, defaultForecastDays, maxForecastDays)
+	if err != nil {
+		return domain.IngredientForecastReport{}, err
+	}
+	historyDays, err := parseForecastDays("history_days", req.HistoryDays, defaultForecastHistoryDays, maxForecastHistoryDays)
+	if err != nil {
+		return domain.IngredientForecastReport{}, err
+	}
+	window := defaultForecastWindow
+	if window > historyDays {
+		window = historyDays
+	}
+	window, err = parseForecastDays("window", req.Window, window, historyDays)
+	if err != nil {
+		return domain.IngredientForecastReport{}, err
+	}
+
+	// today is still being sold, the history ends yesterday
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	historyStart := start.AddDate(0, 0, -historyDays)
+	result := domain.IngredientForecastReport{
+		From:        start,
+		Days:        days,
+		HistoryDays: historyDays,
+		Window:      window,
+	}
+
+	err = u.txRepo.Transact(func(adapters repository.Adapters) error {
+		sales, err := adapters.OrderMenuRepository.SumDailyQuantities(ctx, historyStart, start)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting daily menu sales")
+			return utils.NewInternalError("Failed to get order history")
+		}
+
+		menus, series := dailySalesSeries(sales, historyStart, historyDays)
+		recipes := map[uuid.UUID][]domain.SimpleRecipeIngredient{}
+		for i := range menus {
+			menus[i].Daily = forecastDailyDemand(series[menus[i].MenuId], start, window, days)
+			for _, portions := range menus[i].Daily {
+				menus[i].ProjectedPortions += portions
+			}
+			menus[i].AverageDaily = menus[i].ProjectedPortions / float64(days)
+
+			recipe, err := adapters.RecipeRepository.GetOneByMenuId(ctx, menus[i].MenuId)
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting Recipe")
+				return utils.NewInternalError("Failed to get recipe")
+			}
+			recipeIngredients, err := explodeRecipePortion(ctx, adapters, recipe.Id, now)
+			if err != nil {
+				return err
+			}
+			recipes[menus[i].MenuId] = recipeIngredients
+		}
+
+		ingredients, daily := explodeMenuForecasts(menus, recipes, days)
+		for i := range ingredients {
+			ingredient, err := adapters.IngredientRepository.GetOneById(ctx, ingredients[i].IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting ingredient")
+				return utils.NewInternalError("Failed to get ingredient")
+			}
+			onHand, err := adapters.InventoryRepository.SumQuantityByIngredientId(ctx, ingredient.Id)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting Inventory")
+				return utils.NewInternalError("Failed to get inventory")
+			}
+			onOrder, err := adapters.PurchaseOrderItemRepository.SumOpenQuantityByIngredientId(ctx, ingredient.Id)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error getting open purchase order quantity")
+				return utils.NewInternalError("Failed to get open purchase orders")
+			}
+			ingredients[i].OnHand = onHand
+			ingredients[i].OnOrder = onOrder
+			ingredients[i].ReorderPoint = ingredient.ReorderPoint
+			ingredients[i].ReorderQuantity = ingredient.ReorderQuantity
+			planIngredientOrder(&ingredients[i], daily[ingredients[i].IngredientId], start)
+		}
+		sortIngredientForecasts(ingredients)
+
+		result.Menus = menus
+		result.Ingredients = ingredients
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error calculating ingredient forecast")
+		return domain.IngredientForecastReport{}, err
+	}
+
+	return result, nil
+}
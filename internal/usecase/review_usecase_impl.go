@@ -54,7 +54,7 @@ func (u *ReviewUsecaseImpl) Create(ctx context.Context, req dto.CreateReviewRequ
 			logger.Log.WithError(err).Error("Error order not found")
 			return utils.NewInternalError("Order not found")
 		}
-		if order.PaymentStatus != "paid" && order.Status != "success" {
+		if order.PaymentStatus != "paid" && order.Status != domain.OrderStatusCompleted {
 			logger.Log.WithField("paymeny_status", order.PaymentStatus).WithField("status", order.Status).Error("Order not finished yet")
 			return utils.NewUnauthorizedError("Order not finished yet")
 		}
@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type StocktakeUsecase interface {
+	Create(ctx context.Context, req dto.CreateStocktakeRequest, actorId uuid.UUID) (domain.Stocktake, error)
+	GetAll(ctx context.Context) ([]domain.Stocktake, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.Stocktake, error)
+	SubmitCounts(ctx context.Context, id, actorId uuid.UUID, req dto.SubmitStocktakeCountsRequest) (domain.Stocktake, error)
+	Close(ctx context.Context, id, actorId uuid.UUID) (domain.Stocktake, error)
+	Cancel(ctx context.Context, id, actorId uuid.UUID) (domain.Stocktake, error)
+}
@@ -0,0 +1,332 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type StocktakeUsecaseImpl struct {
+	stocktakeRepo      repository.StocktakeRepository
+	stockAlertNotifier notifier.StockAlertNotifier
+	txRepo             repository.TransactionRepository
+}
+
+func NewStocktakeUsecase(stocktakeRepo repository.StocktakeRepository, stockAlertNotifier notifier.StockAlertNotifier, txRepo repository.TransactionRepository) StocktakeUsecase {
+	return &StocktakeUsecaseImpl{
+		stocktakeRepo:      stocktakeRepo,
+		stockAlertNotifier: stockAlertNotifier,
+		txRepo:             txRepo,
+	}
+}
+
+// getStocktake loads a stocktake with its lines and variance totals
+func getStocktake(ctx context.Context, adapters repository.Adapters, id uuid.UUID) (domain.Stocktake, error) {
+	stocktake, err := adapters.StocktakeRepository.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error stocktake not found")
+		return domain.Stocktake{}, utils.NewNotFoundError("Stocktake not found")
+	}
+
+	lines, err := adapters.StocktakeLineRepository.GetAllByStocktakeId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get stocktake lines")
+		return domain.Stocktake{}, utils.NewInternalError("Failed to get stocktake lines")
+	}
+	stocktake.Lines = lines
+	for _, line := range lines {
+		if line.VarianceCost < 0 {
+			stocktake.ShrinkageCost -= line.VarianceCost
+		} else {
+			stocktake.SurplusCost += line.VarianceCost
+		}
+		stocktake.NetVarianceCost += line.VarianceCost
+	}
+	return stocktake, nil
+}
+
+// getOpenStocktake loads a stocktake that still accepts counts. It is locked
+// until the transaction ends so a second close, cancel or count waits and
+// then finds it closed.
+func getOpenStocktake(ctx context.Context, adapters repository.Adapters, id uuid.UUID) (domain.Stocktake, error) {
+	stocktake, err := adapters.StocktakeRepository.GetOneByIdForUpdate(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error stocktake not found")
+		return domain.Stocktake{}, utils.NewNotFoundError("Stocktake not found")
+	}
+	if stocktake.Status != domain.StocktakeStatusOpen {
+		logger.Log.WithField("status", stocktake.Status).Error("Error stocktake is not open")
+		return domain.Stocktake{}, utils.NewConflictError(fmt.Sprintf("Stocktake is '%s'", stocktake.Status))
+	}
+	return stocktake, nil
+}
+
+func (u *StocktakeUsecaseImpl) Create(ctx context.Context, req dto.CreateStocktakeRequest, actorId uuid.UUID) (domain.Stocktake, error) {
+	result := domain.Stocktake{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		// counts are compared with the stock when they were taken, two
+		// sessions would post the same variance twice
+		_, err := adapters.StocktakeRepository.GetOpen(ctx)
+		if err == nil {
+			logger.Log.Error("Error another stocktake is open")
+			return utils.NewConflictError("Another stocktake is still open")
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			logger.Log.WithError(err).Error("Error failed to get open stocktake")
+			return utils.NewInternalError("Failed to get open stocktake")
+		}
+
+		stocktake := domain.Stocktake{
+			Id:       uuid.New(),
+			Status:   domain.StocktakeStatusOpen,
+			OpenedBy: &actorId,
+		}
+		if req.Notes != "" {
+			stocktake.Notes = &req.Notes
+		}
+		err = adapters.StocktakeRepository.Create(ctx, stocktake)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create stocktake")
+			return utils.NewInternalError("Failed to create stocktake")
+		}
+
+		createdStocktake, err := getStocktake(ctx, adapters, stocktake.Id)
+		if err != nil {
+			return err
+		}
+		result = createdStocktake
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create stocktake")
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *StocktakeUsecaseImpl) GetAll(ctx context.Context) ([]domain.Stocktake, error) {
+	stocktakes, err := u.stocktakeRepo.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get stocktakes")
+		return nil, utils.NewInternalError("Failed to get stocktakes")
+	}
+	return stocktakes, nil
+}
+
+func (u *StocktakeUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Stocktake, error) {
+	result := domain.Stocktake{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		stocktake, err := getStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+		result = stocktake
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// SubmitCounts records counted quantities, an ingredient counted again at the
+// same location replaces its earlier count. The stock and cost at the time of
+// the count are kept on the line as what was expected, so stock moving
+// before the stocktake is closed does not end up in the variance.
+func (u *StocktakeUsecaseImpl) SubmitCounts(ctx context.Context, id, actorId uuid.UUID, req dto.SubmitStocktakeCountsRequest) (domain.Stocktake, error) {
+	result := domain.Stocktake{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		_, err := getOpenStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+
+		for _, count := range req.Counts {
+			ingredient, err := adapters.IngredientRepository.GetOneById(ctx, count.IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error ingredient not found")
+				return utils.NewNotFoundError("Ingredient not found")
+			}
+			quantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, *count.Quantity, count.Unit)
+			if err != nil {
+				return err
+			}
+			location, err := getStorageLocation(ctx, adapters, count.LocationId)
+			if err != nil {
+				return err
+			}
+
+			var expected float64
+			inventory, err := adapters.InventoryRepository.GetOneByIngredientIdAndLocationId(ctx, ingredient.Id, location.Id)
+			if err != nil && !errors.Is(err, sql.ErrNoRows) {
+				logger.Log.WithError(err).Error("Error getting Inventory")
+				return utils.NewInternalError("Failed to get inventory")
+			}
+			if err == nil {
+				expected = inventory.Quantity
+			}
+
+			line, err := adapters.StocktakeLineRepository.GetOneByStocktakeIdAndIngredientIdAndLocationId(ctx, id, ingredient.Id, location.Id)
+			if err == nil {
+				line.CountedQuantity = quantity
+				line.CountedBy = &actorId
+				err = adapters.StocktakeLineRepository.UpdateCount(ctx, line.Id, line)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error failed to update stocktake line")
+					return utils.NewInternalError("Failed to update stocktake line")
+				}
+			} else {
+				if !errors.Is(err, sql.ErrNoRows) {
+					logger.Log.WithError(err).Error("Error failed to get stocktake line")
+					return utils.NewInternalError("Failed to get stocktake line")
+				}
+
+				line = domain.StocktakeLine{
+					Id:              uuid.New(),
+					StocktakeId:     id,
+					IngredientId:    ingredient.Id,
+					LocationId:      location.Id,
+					CountedQuantity: quantity,
+					CountedBy:       &actorId,
+				}
+				err = adapters.StocktakeLineRepository.Create(ctx, line)
+				if err != nil {
+					logger.Log.WithError(err).Error("Error failed to create stocktake line")
+					return utils.NewInternalError("Failed to create stocktake line")
+				}
+			}
+
+			err = adapters.StocktakeLineRepository.UpdateExpected(ctx, line.Id, expected, ingredient.CostPerUnit)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update stocktake line")
+				return utils.NewInternalError("Failed to update stocktake line")
+			}
+		}
+
+		stocktake, err := getStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+		result = stocktake
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to submit stocktake counts")
+		return result, err
+	}
+	return result, nil
+}
+
+// Close posts the difference between every count and the stock expected when
+// it was taken as an adjustment. Ingredients that were not counted are left
+// alone.
+func (u *StocktakeUsecaseImpl) Close(ctx context.Context, id, actorId uuid.UUID) (domain.Stocktake, error) {
+	result := domain.Stocktake{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		stocktake, err := getOpenStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+
+		lines, err := adapters.StocktakeLineRepository.GetAllByStocktakeId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get stocktake lines")
+			return utils.NewInternalError("Failed to get stocktake lines")
+		}
+		if len(lines) == 0 {
+			logger.Log.Error("Error stocktake has no counts")
+			return utils.NewBadRequestError("Nothing has been counted in this stocktake")
+		}
+
+		reason := "Stocktake"
+		if stocktake.Notes != nil {
+			reason = fmt.Sprintf("Stocktake: %s", *stocktake.Notes)
+		}
+		for _, line := range lines {
+			inventory, err := getOrCreateInventory(ctx, adapters, line.IngredientId, line.LocationId)
+			if err != nil {
+				return err
+			}
+
+			// stock found on top of what was expected keeps the expiry of
+			// the lots already there
+			variance := line.CountedQuantity - line.ExpectedQuantity
+			switch {
+			case variance > stockTolerance:
+				err = returnInventoryStock(ctx, adapters, &alerts, inventory, domain.MovementTypeAdjustment, variance, reason, &stocktake.Id, &actorId)
+			case variance < -stockTolerance:
+				err = recordInventoryMovement(ctx, adapters, &alerts, inventory, domain.MovementTypeAdjustment, variance, stockLot{}, reason, &stocktake.Id, &actorId)
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		err = adapters.StocktakeRepository.UpdateStatus(ctx, id, domain.StocktakeStatusClosed, actorId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to close stocktake")
+			return utils.NewInternalError("Failed to close stocktake")
+		}
+
+		closedStocktake, err := getStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+		result = closedStocktake
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to close stocktake")
+		return result, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+func (u *StocktakeUsecaseImpl) Cancel(ctx context.Context, id, actorId uuid.UUID) (domain.Stocktake, error) {
+	result := domain.Stocktake{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		_, err := getOpenStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+
+		err = adapters.StocktakeRepository.UpdateStatus(ctx, id, domain.StocktakeStatusCancelled, actorId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to cancel stocktake")
+			return utils.NewInternalError("Failed to cancel stocktake")
+		}
+
+		cancelledStocktake, err := getStocktake(ctx, adapters, id)
+		if err != nil {
+			return err
+		}
+		result = cancelledStocktake
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to cancel stocktake")
+		return result, err
+	}
+	return result, nil
+}
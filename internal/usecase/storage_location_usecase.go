@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type StorageLocationUsecase interface {
+	Create(ctx context.Context, req dto.CreateStorageLocationRequest) (domain.StorageLocation, error)
+	GetAll(ctx context.Context) ([]domain.StorageLocation, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.StorageLocation, error)
+	GetInventory(ctx context.Context, id uuid.UUID) ([]domain.Inventory, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.UpdateStorageLocationRequest) (domain.StorageLocation, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
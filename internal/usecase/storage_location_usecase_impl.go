@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type StorageLocationUsecaseImpl struct {
+	storageLocationRepo repository.StorageLocationRepository
+	inventoryRepo       repository.InventoryRepository
+	txRepo              repository.TransactionRepository
+}
+
+func NewStorageLocationUsecase(storageLocationRepo repository.StorageLocationRepository, inventoryRepo repository.InventoryRepository, txRepo repository.TransactionRepository) StorageLocationUsecase {
+	return &StorageLocationUsecaseImpl{
+		storageLocationRepo: storageLocationRepo,
+		inventoryRepo:       inventoryRepo,
+		txRepo:              txRepo,
+	}
+}
+
+// checkStorageLocationName rejects a name already used by another location
+func checkStorageLocationName(ctx context.Context, adapters repository.Adapters, id uuid.UUID, name string) error {
+	locations, err := adapters.StorageLocationRepository.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get storage locations")
+		return utils.NewInternalError("Failed to get storage locations")
+	}
+	for _, location := range locations {
+		if location.Id != id && strings.EqualFold(location.Name, name) {
+			logger.Log.WithField("name", name).Error("Error storage location name already exists")
+			return utils.NewConflictError(fmt.Sprintf("Storage location '%s' already exists", location.Name))
+		}
+	}
+	return nil
+}
+
+func (u *StorageLocationUsecaseImpl) Create(ctx context.Context, req dto.CreateStorageLocationRequest) (domain.StorageLocation, error) {
+	result := domain.StorageLocation{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		location := domain.StorageLocation{
+			Id:           uuid.New(),
+			Name:         req.Name,
+			FeedsKitchen: true,
+		}
+		if req.FeedsKitchen != nil {
+			location.FeedsKitchen = *req.FeedsKitchen
+		}
+		if err := checkStorageLocationName(ctx, adapters, location.Id, location.Name); err != nil {
+			return err
+		}
+
+		err := adapters.StorageLocationRepository.Create(ctx, location)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create storage location")
+			return utils.NewInternalError("Failed to create storage location")
+		}
+		if req.IsDefault {
+			err = adapters.StorageLocationRepository.SetDefault(ctx, location.Id)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to set default storage location")
+				return utils.NewInternalError("Failed to set default storage location")
+			}
+		}
+
+		createdLocation, err := adapters.StorageLocationRepository.GetOneById(ctx, location.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get created storage location")
+			return utils.NewInternalError("Failed to get created storage location")
+		}
+		result = createdLocation
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *StorageLocationUsecaseImpl) GetAll(ctx context.Context) ([]domain.StorageLocation, error) {
+	locations, err := u.storageLocationRepo.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get storage locations")
+		return nil, utils.NewInternalError("Failed to get storage locations")
+	}
+	return locations, nil
+}
+
+func (u *StorageLocationUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.StorageLocation, error) {
+	location, err := u.storageLocationRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error storage location not found")
+		return domain.StorageLocation{}, utils.NewNotFoundError("Storage location not found")
+	}
+	return location, nil
+}
+
+func (u *StorageLocationUsecaseImpl) GetInventory(ctx context.Context, id uuid.UUID) ([]domain.Inventory, error) {
+	_, err := u.storageLocationRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error storage location not found")
+		return nil, utils.NewNotFoundError("Storage location not found")
+	}
+
+	inventories, err := u.inventoryRepo.GetAllByLocationId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get inventory")
+		return nil, utils.NewInternalError("Failed to get inventory")
+	}
+	return inventories, nil
+}
+
+func (u *StorageLocationUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateStorageLocationRequest) (domain.StorageLocation, error) {
+	result := domain.StorageLocation{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		location, err := adapters.StorageLocationRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error storage location not found")
+			return utils.NewNotFoundError("Storage location not found")
+		}
+
+		if req.Name != "" {
+			if err := checkStorageLocationName(ctx, adapters, id, req.Name); err != nil {
+				return err
+			}
+			location.Name = req.Name
+		}
+		if req.FeedsKitchen != nil {
+			location.FeedsKitchen = *req.FeedsKitchen
+		}
+
+		err = adapters.StorageLocationRepository.Update(ctx, id, location)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update storage location")
+			return utils.NewInternalError("Failed to update storage location")
+		}
+		if req.IsDefault && !location.IsDefault {
+			err = adapters.StorageLocationRepository.SetDefault(ctx, id)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to set default storage location")
+				return utils.NewInternalError("Failed to set default storage location")
+			}
+		}
+
+		updatedLocation, err := adapters.StorageLocationRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get updated storage location")
+			return utils.NewInternalError("Failed to get updated storage location")
+		}
+		result = updatedLocation
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// Delete removes an empty location, the default location can not be deleted
+func (u *StorageLocationUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		location, err := adapters.StorageLocationRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error storage location not found")
+			return utils.NewNotFoundError("Storage location not found")
+		}
+		if location.IsDefault {
+			logger.Log.WithField("location_id", id).Error("Error deleting default storage location")
+			return utils.NewConflictError("The default storage location can not be deleted")
+		}
+
+		inventories, err := adapters.InventoryRepository.GetAllByLocationId(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get inventory")
+			return utils.NewInternalError("Failed to get inventory")
+		}
+		for _, inventory := range inventories {
+			if inventory.Quantity > stockTolerance {
+				logger.Log.WithField("location_id", id).Error("Error storage location still holds stock")
+				return utils.NewConflictError(fmt.Sprintf("%s still holds stock, transfer it first", location.Name))
+			}
+		}
+
+		err = adapters.StorageLocationRepository.Delete(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete storage location")
+			return utils.NewInternalError("Failed to delete storage location")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
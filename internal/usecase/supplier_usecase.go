@@ -0,0 +1,19 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type SupplierUsecase interface {
+	Create(ctx context.Context, req dto.CreateSupplierRequest) (domain.Supplier, error)
+	GetAll(ctx context.Context) ([]domain.Supplier, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.Supplier, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.UpdateSupplierRequest) (domain.Supplier, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	SetIngredient(ctx context.Context, id uuid.UUID, req dto.SetSupplierIngredientRequest) (domain.SupplierIngredient, error)
+	RemoveIngredient(ctx context.Context, id, ingredientId uuid.UUID) error
+}
@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type SupplierUsecaseImpl struct {
+	supplierRepo           repository.SupplierRepository
+	supplierIngredientRepo repository.SupplierIngredientRepository
+	txRepo                 repository.TransactionRepository
+}
+
+func NewSupplierUsecase(supplierRepo repository.SupplierRepository, supplierIngredientRepo repository.SupplierIngredientRepository, txRepo repository.TransactionRepository) SupplierUsecase {
+	return &SupplierUsecaseImpl{
+		supplierRepo:           supplierRepo,
+		supplierIngredientRepo: supplierIngredientRepo,
+		txRepo:                 txRepo,
+	}
+}
+
+func (u *SupplierUsecaseImpl) Create(ctx context.Context, req dto.CreateSupplierRequest) (domain.Supplier, error) {
+	result := domain.Supplier{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		supplier := domain.Supplier{
+			Id:          uuid.New(),
+			Name:        req.Name,
+			ContactName: req.ContactName,
+			Email:       req.Email,
+			Phone:       req.Phone,
+			Address:     req.Address,
+		}
+		err := adapters.SupplierRepository.Create(ctx, supplier)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create supplier")
+			return utils.NewInternalError("Failed to create supplier")
+		}
+
+		createdSupplier, err := adapters.SupplierRepository.GetOneById(ctx, supplier.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get created supplier")
+			return utils.NewInternalError("Failed to get created supplier")
+		}
+		result = createdSupplier
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *SupplierUsecaseImpl) GetAll(ctx context.Context) ([]domain.Supplier, error) {
+	suppliers, err := u.supplierRepo.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get all suppliers")
+		return nil, utils.NewInternalError("Failed to get all suppliers")
+	}
+	return suppliers, nil
+}
+
+func (u *SupplierUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.Supplier, error) {
+	supplier, err := u.supplierRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error supplier not found")
+		return domain.Supplier{}, utils.NewNotFoundError("Supplier not found")
+	}
+
+	ingredients, err := u.supplierIngredientRepo.GetAllBySupplierId(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get supplier ingredients")
+		return domain.Supplier{}, utils.NewInternalError("Failed to get supplier ingredients")
+	}
+	supplier.Ingredients = ingredients
+
+	return supplier, nil
+}
+
+func (u *SupplierUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateSupplierRequest) (domain.Supplier, error) {
+	result := domain.Supplier{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		supplier, err := adapters.SupplierRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error supplier not found")
+			return utils.NewNotFoundError("Supplier not found")
+		}
+
+		if req.Name != "" {
+			supplier.Name = req.Name
+		}
+		if req.ContactName != nil {
+			supplier.ContactName = req.ContactName
+		}
+		if req.Email != nil {
+			supplier.Email = req.Email
+		}
+		if req.Phone != nil {
+			supplier.Phone = req.Phone
+		}
+		if req.Address != nil {
+			supplier.Address = req.Address
+		}
+
+		err = adapters.SupplierRepository.Update(ctx, id, supplier)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update supplier")
+			return utils.NewInternalError("Failed to update supplier")
+		}
+
+		updatedSupplier, err := adapters.SupplierRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get updated supplier")
+			return utils.NewInternalError("Failed to get updated supplier")
+		}
+		result = updatedSupplier
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *SupplierUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		_, err := adapters.SupplierRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error supplier not found")
+			return utils.NewNotFoundError("Supplier not found")
+		}
+
+		err = adapters.SupplierRepository.Delete(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete supplier")
+			return utils.NewInternalError("Failed to delete supplier")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (u *SupplierUsecaseImpl) SetIngredient(ctx context.Context, id uuid.UUID, req dto.SetSupplierIngredientRequest) (domain.SupplierIngredient, error) {
+	result := domain.SupplierIngredient{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		_, err := adapters.SupplierRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error supplier not found")
+			return utils.NewNotFoundError("Supplier not found")
+		}
+
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, req.IngredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error ingredient not found")
+			return utils.NewNotFoundError("Ingredient not found")
+		}
+
+		unitCost, err := toBaseUnitCost(ctx, adapters, ingredient, req.UnitCost, req.Unit)
+		if err != nil {
+			return err
+		}
+
+		supplierIngredient, err := adapters.SupplierIngredientRepository.GetOneBySupplierIdAndIngredientId(ctx, id, ingredient.Id)
+		if err == nil {
+			supplierIngredient.UnitCost = unitCost
+			supplierIngredient.LeadTimeDays = req.LeadTimeDays
+			err = adapters.SupplierIngredientRepository.Update(ctx, supplierIngredient.Id, supplierIngredient)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to update supplier ingredient")
+				return utils.NewInternalError("Failed to update supplier ingredient")
+			}
+		} else {
+			err = adapters.SupplierIngredientRepository.Create(ctx, domain.SupplierIngredient{
+				Id:           uuid.New(),
+				SupplierId:   id,
+				IngredientId: ingredient.Id,
+				UnitCost:     unitCost,
+				LeadTimeDays: req.LeadTimeDays,
+			})
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to create supplier ingredient")
+				return utils.NewInternalError("Failed to create supplier ingredient")
+			}
+		}
+
+		savedSupplierIngredient, err := adapters.SupplierIngredientRepository.GetOneBySupplierIdAndIngredientId(ctx, id, ingredient.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get supplier ingredient")
+			return utils.NewInternalError("Failed to get supplier ingredient")
+		}
+		result = savedSupplierIngredient
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *SupplierUsecaseImpl) RemoveIngredient(ctx context.Context, id, ingredientId uuid.UUID) error {
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		supplierIngredient, err := adapters.SupplierIngredientRepository.GetOneBySupplierIdAndIngredientId(ctx, id, ingredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error supplier ingredient not found")
+			return utils.NewNotFoundError("Supplier does not supply this ingredient")
+		}
+
+		err = adapters.SupplierIngredientRepository.Delete(ctx, supplierIngredient.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to delete supplier ingredient")
+			return utils.NewInternalError("Failed to delete supplier ingredient")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return nil
+}
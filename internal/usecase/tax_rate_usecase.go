@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type TaxRateUsecase interface {
+	Create(ctx context.Context, req dto.CreateTaxRateRequest) (domain.TaxRate, error)
+	GetAll(ctx context.Context) ([]domain.TaxRate, error)
+	GetOneById(ctx context.Context, id uuid.UUID) (domain.TaxRate, error)
+	Update(ctx context.Context, id uuid.UUID, req dto.UpdateTaxRateRequest) (domain.TaxRate, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
@@ -0,0 +1,177 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// Orders keep a copy of the rates they were charged, changing or deleting a
+// rate only affects orders placed afterwards.
+
+// allTaxCategories makes a rate apply to every category again
+const allTaxCategories = "all"
+
+type TaxRateUsecaseImpl struct {
+	taxRateRepo repository.TaxRateRepository
+	txRepo      repository.TransactionRepository
+}
+
+func NewTaxRateUsecase(taxRateRepo repository.TaxRateRepository, txRepo repository.TransactionRepository) TaxRateUsecase {
+	return &TaxRateUsecaseImpl{
+		taxRateRepo: taxRateRepo,
+		txRepo:      txRepo,
+	}
+}
+
+// checkTaxRateName rejects a name already used by another rate of the same
+// category
+func checkTaxRateName(ctx context.Context, adapters repository.Adapters, rate domain.TaxRate) error {
+	rates, err := adapters.TaxRateRepository.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get tax rates")
+		return utils.NewInternalError("Failed to get tax rates")
+	}
+	for _, existing := range rates {
+		sameCategory := (existing.Category == nil && rate.Category == nil) ||
+			(existing.Category != nil && rate.Category != nil && *existing.Category == *rate.Category)
+		if existing.Id != rate.Id && sameCategory && strings.EqualFold(existing.Name, rate.Name) {
+			logger.Log.WithField("name", rate.Name).Error("Error tax rate name already exists")
+			return utils.NewConflictError(fmt.Sprintf("Tax rate '%s' already exists", existing.Name))
+		}
+	}
+	return nil
+}
+
+func (u *TaxRateUsecaseImpl) Create(ctx context.Context, req dto.CreateTaxRateRequest) (domain.TaxRate, error) {
+	result := domain.TaxRate{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		rate := domain.TaxRate{
+			Id:          uuid.New(),
+			Name:        req.Name,
+			RatePercent: req.RatePercent,
+			Inclusive:   req.Inclusive,
+		}
+		if req.Category != "" {
+			rate.Category = &req.Category
+		}
+		if err := checkTaxRateName(ctx, adapters, rate); err != nil {
+			return err
+		}
+
+		err := adapters.TaxRateRepository.Create(ctx, rate)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to create tax rate")
+			return utils.NewInternalError("Failed to create tax rate")
+		}
+
+		createdRate, err := adapters.TaxRateRepository.GetOneById(ctx, rate.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get created tax rate")
+			return utils.NewInternalError("Failed to get created tax rate")
+		}
+		result = createdRate
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *TaxRateUsecaseImpl) GetAll(ctx context.Context) ([]domain.TaxRate, error) {
+	rates, err := u.taxRateRepo.GetAll(ctx)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get tax rates")
+		return nil, utils.NewInternalError("Failed to get tax rates")
+	}
+	return rates, nil
+}
+
+func (u *TaxRateUsecaseImpl) GetOneById(ctx context.Context, id uuid.UUID) (domain.TaxRate, error) {
+	rate, err := u.taxRateRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error tax rate not found")
+		return domain.TaxRate{}, utils.NewNotFoundError("Tax rate not found")
+	}
+	return rate, nil
+}
+
+func (u *TaxRateUsecaseImpl) Update(ctx context.Context, id uuid.UUID, req dto.UpdateTaxRateRequest) (domain.TaxRate, error) {
+	result := domain.TaxRate{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		rate, err := adapters.TaxRateRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error tax rate not found")
+			return utils.NewNotFoundError("Tax rate not found")
+		}
+
+		if req.Name != "" {
+			rate.Name = req.Name
+		}
+		if req.RatePercent != nil {
+			rate.RatePercent = *req.RatePercent
+		}
+		if req.Inclusive != nil {
+			rate.Inclusive = *req.Inclusive
+		}
+		if req.Category == allTaxCategories {
+			rate.Category = nil
+		} else if req.Category != "" {
+			rate.Category = &req.Category
+		}
+		if err := checkTaxRateName(ctx, adapters, rate); err != nil {
+			return err
+		}
+
+		err = adapters.TaxRateRepository.Update(ctx, id, rate)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to update tax rate")
+			return utils.NewInternalError("Failed to update tax rate")
+		}
+
+		updatedRate, err := adapters.TaxRateRepository.GetOneById(ctx, id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get updated tax rate")
+			return utils.NewInternalError("Failed to get updated tax rate")
+		}
+		result = updatedRate
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+func (u *TaxRateUsecaseImpl) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := u.taxRateRepo.GetOneById(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error tax rate not found")
+		return utils.NewNotFoundError("Tax rate not found")
+	}
+
+	err = u.taxRateRepo.Delete(ctx, id)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to delete tax rate")
+		return utils.NewInternalError("Failed to delete tax rate")
+	}
+	return nil
+}
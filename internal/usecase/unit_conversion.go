@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// toBaseQuantity converts a quantity entered in unit to the base unit of the
+// ingredient. Conversions defined for the ingredient win over the standard
+// ones, so eggs measured in grams can still be entered in pcs.
+func toBaseQuantity(ctx context.Context, adapters repository.Adapters, ingredientId uuid.UUID, baseUnit string, quantity float64, unit string) (float64, error) {
+	unit = utils.NormalizeUnit(unit)
+	if unit == "" || unit == baseUnit {
+		return quantity, nil
+	}
+
+	conversion, err := adapters.IngredientUnitConversionRepository.GetOneByIngredientIdAndUnit(ctx, ingredientId, unit)
+	if err == nil {
+		return quantity * conversion.Factor, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		logger.Log.WithError(err).Error("Error getting unit conversion")
+		return 0, utils.NewInternalError("Failed to get unit conversion")
+	}
+
+	converted, ok := utils.ConvertUnit(quantity, unit, baseUnit)
+	if !ok {
+		logger.Log.WithField("unit", unit).WithField("base_unit", baseUnit).Error("Error incompatible unit")
+		return 0, utils.NewValidationError(utils.FieldError("unit", fmt.Sprintf("Unit %s cannot be converted to %s", unit, baseUnit)))
+	}
+	return converted, nil
+}
+
+// toBaseUnitCost converts a price per unit to the price of one base unit of
+// the ingredient
+func toBaseUnitCost(ctx context.Context, adapters repository.Adapters, ingredient domain.Ingredient, cost float64, unit string) (float64, error) {
+	baseQuantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, 1, unit)
+	if err != nil {
+		return 0, err
+	}
+	return cost / baseQuantity, nil
+}
+
+// getRecipeIngredients loads the lines of a recipe version with their
+// quantity normalized to the base unit of each ingredient
+func getRecipeIngredients(ctx context.Context, adapters repository.Adapters, recipeVersionId uuid.UUID) ([]domain.SimpleRecipeIngredient, error) {
+	recipeIngredients, err := adapters.RecipeIngredientRepository.GetIngredientsByRecipeVersionId(ctx, recipeVersionId)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error getting Ingredients")
+		return nil, utils.NewInternalError("Failed to get ingredients")
+	}
+
+	for i, recipeIngredient := range recipeIngredients {
+		baseQuantity, err := toBaseQuantity(ctx, adapters, recipeIngredient.IngredientId, recipeIngredient.BaseUnit, recipeIngredient.Quantity, recipeIngredient.Unit)
+		if err != nil {
+			return nil, err
+		}
+		recipeIngredients[i].BaseQuantity = baseQuantity
+	}
+	return recipeIngredients, nil
+}
@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+// recordWaste takes the wasted ingredient quantities out of stock at the
+// location, or the kitchen when locationId is nil, and logs the waste at the
+// current cost of the ingredients
+func recordWaste(ctx context.Context, adapters repository.Adapters, alerts *stockAlerts, wasteLog domain.WasteLog, usages []ingredientUsage, locationId *uuid.UUID) error {
+	if err := checkIngredientStock(ctx, adapters, usages, locationId); err != nil {
+		return err
+	}
+
+	reason := fmt.Sprintf("Waste: %s", wasteLog.Reason)
+	for _, usage := range usages {
+		ingredient, err := adapters.IngredientRepository.GetOneById(ctx, usage.IngredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error getting ingredient")
+			return utils.NewInternalError("Failed to get ingredient")
+		}
+		wasteLog.Cost += usage.Quantity * ingredient.CostPerUnit
+
+		err = drawDownStock(ctx, adapters, alerts, usage.IngredientId, locationId, domain.MovementTypeWaste, usage.Quantity, reason, &wasteLog.Id, wasteLog.ActorId)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := adapters.WasteLogRepository.Create(ctx, wasteLog)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error creating waste log")
+		return utils.NewInternalError("Failed to create waste log")
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+)
+
+type WasteUsecase interface {
+	Create(ctx context.Context, actorId uuid.UUID, req dto.CreateWasteLogRequest) (domain.WasteLog, error)
+	GetAll(ctx context.Context, req dto.GetWasteLogsRequest) (domain.WasteLogList, error)
+}
@@ -0,0 +1,182 @@
+package usecase
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/ryvasa/go-restaurant/internal/model/domain"
+	"github.com/ryvasa/go-restaurant/internal/model/dto"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/pkg/logger"
+	"github.com/ryvasa/go-restaurant/utils"
+)
+
+type WasteUsecaseImpl struct {
+	wasteLogRepo       repository.WasteLogRepository
+	stockAlertNotifier notifier.StockAlertNotifier
+	txRepo             repository.TransactionRepository
+}
+
+func NewWasteUsecase(wasteLogRepo repository.WasteLogRepository, stockAlertNotifier notifier.StockAlertNotifier, txRepo repository.TransactionRepository) WasteUsecase {
+	return &WasteUsecaseImpl{
+		wasteLogRepo:       wasteLogRepo,
+		stockAlertNotifier: stockAlertNotifier,
+		txRepo:             txRepo,
+	}
+}
+
+func (u *WasteUsecaseImpl) Create(ctx context.Context, actorId uuid.UUID, req dto.CreateWasteLogRequest) (domain.WasteLog, error) {
+	result := domain.WasteLog{}
+	alerts := stockAlerts{}
+	err := u.txRepo.Transact(func(adapters repository.Adapters) error {
+		if err := utils.ValidateStruct(req); len(err) > 0 {
+			logger.Log.WithField("validation_errors", err).Error("Error invalid request body")
+			return utils.NewValidationError(err)
+		}
+
+		wasteLog := domain.WasteLog{
+			Id:           uuid.New(),
+			IngredientId: req.IngredientId,
+			MenuId:       req.MenuId,
+			Reason:       req.Reason,
+			ActorId:      &actorId,
+		}
+		if req.Notes != "" {
+			wasteLog.Notes = &req.Notes
+		}
+
+		var usages []ingredientUsage
+		if req.IngredientId != nil {
+			ingredient, err := adapters.IngredientRepository.GetOneById(ctx, *req.IngredientId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to get ingredient")
+				return utils.NewNotFoundError("Ingredient not found")
+			}
+			quantity, err := toBaseQuantity(ctx, adapters, ingredient.Id, ingredient.BaseUnit, req.Quantity, req.Unit)
+			if err != nil {
+				return err
+			}
+			wasteLog.Quantity = quantity
+			wasteLog.Unit = ingredient.BaseUnit
+			usages = []ingredientUsage{{
+				IngredientId: ingredient.Id,
+				Name:         ingredient.Name,
+				Quantity:     quantity,
+				Unit:         ingredient.BaseUnit,
+			}}
+		} else {
+			// a wasted dish takes its recipe out of stock, portions are whole
+			if req.Quantity != math.Trunc(req.Quantity) {
+				logger.Log.WithField("quantity", req.Quantity).Error("Error portions must be whole")
+				return utils.NewValidationError(utils.FieldError("quantity", "Quantity of a menu item must be a whole number of portions"))
+			}
+			menu, err := adapters.MenuRepository.Get(ctx, *req.MenuId)
+			if err != nil {
+				logger.Log.WithError(err).Error("Error failed to get menu")
+				return utils.NewNotFoundError("Menu not found")
+			}
+			wasteLog.Quantity = req.Quantity
+			wasteLog.Unit = "portion"
+			usages, err = calculateOrderIngredientUsage(ctx, adapters, []domain.OrderMenu{{
+				MenuId:   menu.Id,
+				MenuName: menu.Name,
+				Quantity: int(req.Quantity),
+			}}, time.Now())
+			if err != nil {
+				return err
+			}
+		}
+
+		if req.LocationId != nil {
+			if _, err := getStorageLocation(ctx, adapters, req.LocationId); err != nil {
+				return err
+			}
+		}
+
+		err := recordWaste(ctx, adapters, &alerts, wasteLog, usages, req.LocationId)
+		if err != nil {
+			return err
+		}
+
+		createdWasteLog, err := adapters.WasteLogRepository.GetOneById(ctx, wasteLog.Id)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error failed to get waste log")
+			return utils.NewInternalError("Failed to get waste log")
+		}
+		result = createdWasteLog
+		return nil
+	})
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to create waste log")
+		return result, err
+	}
+	alerts.notify(ctx, u.stockAlertNotifier)
+	return result, nil
+}
+
+func (u *WasteUsecaseImpl) GetAll(ctx context.Context, req dto.GetWasteLogsRequest) (domain.WasteLogList, error) {
+	if err := utils.ValidateStruct(req); len(err) > 0 {
+		logger.Log.WithField("validation_errors", err).Error("Error invalid query params")
+		return domain.WasteLogList{}, utils.NewValidationError(err)
+	}
+
+	if req.Page == 0 {
+		req.Page = 1
+	}
+	if req.Limit == 0 {
+		req.Limit = 10
+	}
+
+	filter := domain.WasteLogFilter{
+		Reason: req.Reason,
+		Limit:  req.Limit,
+		Offset: (req.Page - 1) * req.Limit,
+	}
+	if req.IngredientId != "" {
+		ingredientId, err := uuid.Parse(req.IngredientId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid ingredient id format")
+			return domain.WasteLogList{}, utils.NewValidationError("Invalid ingredient id format")
+		}
+		filter.IngredientId = &ingredientId
+	}
+	if req.MenuId != "" {
+		menuId, err := uuid.Parse(req.MenuId)
+		if err != nil {
+			logger.Log.WithError(err).Error("Error invalid menu id format")
+			return domain.WasteLogList{}, utils.NewValidationError("Invalid menu id format")
+		}
+		filter.MenuId = &menuId
+	}
+	createdFrom, createdTo, err := parseDateRange("created_from", req.CreatedFrom, "created_to", req.CreatedTo)
+	if err != nil {
+		return domain.WasteLogList{}, err
+	}
+	filter.CreatedFrom = createdFrom
+	filter.CreatedTo = createdTo
+
+	wasteLogs, err := u.wasteLogRepo.GetAll(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to get waste logs")
+		return domain.WasteLogList{}, utils.NewInternalError("Failed to get waste logs")
+	}
+
+	total, err := u.wasteLogRepo.Count(ctx, filter)
+	if err != nil {
+		logger.Log.WithError(err).Error("Error failed to count waste logs")
+		return domain.WasteLogList{}, utils.NewInternalError("Failed to count waste logs")
+	}
+
+	return domain.WasteLogList{
+		WasteLogs: wasteLogs,
+		Pagination: domain.Pagination{
+			Page:       req.Page,
+			Limit:      req.Limit,
+			TotalItems: total,
+			TotalPages: (total + req.Limit - 1) / req.Limit,
+		},
+	}, nil
+}
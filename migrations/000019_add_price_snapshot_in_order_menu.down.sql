@@ -0,0 +1,6 @@
+ALTER TABLE order_menu
+    DROP PRIMARY KEY,
+    DROP COLUMN id,
+    DROP COLUMN menu_name,
+    DROP COLUMN unit_price,
+    DROP COLUMN created_at;
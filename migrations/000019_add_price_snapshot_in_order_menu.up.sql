@@ -0,0 +1,18 @@
+ALTER TABLE order_menu
+    ADD COLUMN id CHAR(36) NULL FIRST,
+    ADD COLUMN menu_name VARCHAR(255) NOT NULL DEFAULT '' AFTER menu_id,
+    ADD COLUMN unit_price FLOAT NOT NULL DEFAULT 0 AFTER quantity,
+    ADD COLUMN created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+-- existing rows never stored what was paid, the current menu price is the best we have
+UPDATE order_menu
+    INNER JOIN menu ON order_menu.menu_id = menu.id
+    SET order_menu.id = UUID(),
+        order_menu.menu_name = menu.name,
+        order_menu.unit_price = menu.price;
+
+UPDATE order_menu SET id = UUID() WHERE id IS NULL;
+
+ALTER TABLE order_menu
+    MODIFY id CHAR(36) NOT NULL,
+    ADD PRIMARY KEY (id);
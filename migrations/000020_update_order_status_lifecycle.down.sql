@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    MODIFY status ENUM("pending","processing","success","failed","accepted","preparing","ready","served","picked_up","completed","cancelled","refunded") NOT NULL DEFAULT 'pending';
+
+UPDATE orders SET status = 'processing' WHERE status IN ('accepted', 'preparing', 'ready', 'served', 'picked_up');
+UPDATE orders SET status = 'success' WHERE status = 'completed';
+UPDATE orders SET status = 'failed' WHERE status IN ('cancelled', 'refunded');
+
+ALTER TABLE orders
+    MODIFY status ENUM("pending","processing","success","failed") NOT NULL DEFAULT 'pending';
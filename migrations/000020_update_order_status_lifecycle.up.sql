@@ -0,0 +1,9 @@
+ALTER TABLE orders
+    MODIFY status ENUM("pending","processing","success","failed","accepted","preparing","ready","served","picked_up","completed","cancelled","refunded") NOT NULL DEFAULT 'pending';
+
+UPDATE orders SET status = 'preparing' WHERE status = 'processing';
+UPDATE orders SET status = 'completed' WHERE status = 'success';
+UPDATE orders SET status = 'cancelled' WHERE status = 'failed';
+
+ALTER TABLE orders
+    MODIFY status ENUM("pending","accepted","preparing","ready","served","picked_up","completed","cancelled","refunded") NOT NULL DEFAULT 'pending';
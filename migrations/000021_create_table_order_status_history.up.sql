@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    from_status VARCHAR(20) DEFAULT NULL,
+    to_status VARCHAR(20) NOT NULL,
+    changed_by CHAR(36) NOT NULL,
+    note TEXT DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,3 @@
+ALTER TABLE order_status_history
+    DROP CONSTRAINT fk_order_status_history_order,
+    DROP CONSTRAINT fk_order_status_history_user;
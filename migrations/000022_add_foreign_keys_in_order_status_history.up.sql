@@ -0,0 +1,10 @@
+ALTER TABLE order_status_history
+    ADD CONSTRAINT fk_order_status_history_order
+    FOREIGN KEY (order_id)
+    REFERENCES orders(id)
+    ON DELETE CASCADE,
+
+    ADD CONSTRAINT fk_order_status_history_user
+    FOREIGN KEY (changed_by)
+    REFERENCES users(id)
+    ON DELETE CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE orders
+    ADD COLUMN inventory_deducted BOOLEAN NOT NULL DEFAULT FALSE AFTER payment_method;
@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS inventory_movements (
+    id CHAR(36) PRIMARY KEY,
+    inventory_id CHAR(36) NOT NULL,
+    ingredient_id CHAR(36) NOT NULL,
+    type ENUM('receipt', 'sale', 'waste', 'adjustment', 'transfer') NOT NULL,
+    quantity FLOAT NOT NULL,
+    reason VARCHAR(255) DEFAULT NULL,
+    reference_id CHAR(36) DEFAULT NULL,
+    actor_id CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_inventory_movements_inventory (inventory_id, created_at)
+);
+
+-- opening balance so the current stock can be derived from the ledger
+INSERT INTO inventory_movements (id, inventory_id, ingredient_id, type, quantity, reason)
+SELECT UUID(), id, ingredient_id, 'adjustment', quantity, 'Opening balance' FROM inventory;
@@ -0,0 +1,4 @@
+ALTER TABLE inventory_movements
+DROP CONSTRAINT fk_inventory_movements_inventory,
+DROP CONSTRAINT fk_inventory_movements_ingredient,
+DROP CONSTRAINT fk_inventory_movements_actor;
@@ -0,0 +1,3 @@
+ALTER TABLE inventory_movements ADD CONSTRAINT fk_inventory_movements_inventory FOREIGN KEY (inventory_id) REFERENCES inventory (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_inventory_movements_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_inventory_movements_actor FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE SET NULL;
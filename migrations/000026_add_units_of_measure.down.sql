@@ -0,0 +1,3 @@
+ALTER TABLE recipes_ingredients DROP COLUMN unit;
+
+ALTER TABLE ingredients DROP COLUMN base_unit;
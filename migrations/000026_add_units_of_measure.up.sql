@@ -0,0 +1,8 @@
+-- existing quantities were entered without a unit, they are assumed to be grams
+ALTER TABLE ingredients ADD COLUMN base_unit VARCHAR(20) NOT NULL DEFAULT 'g' AFTER description;
+
+ALTER TABLE recipes_ingredients ADD COLUMN unit VARCHAR(20) NOT NULL DEFAULT 'g' AFTER quantity;
+
+UPDATE recipes_ingredients
+INNER JOIN ingredients ON recipes_ingredients.ingredient_id = ingredients.id
+SET recipes_ingredients.unit = ingredients.base_unit;
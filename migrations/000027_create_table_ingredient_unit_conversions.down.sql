@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ingredient_unit_conversions;
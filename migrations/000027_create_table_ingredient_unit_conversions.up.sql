@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS ingredient_unit_conversions (
+    id CHAR(36) PRIMARY KEY,
+    ingredient_id CHAR(36) NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    factor FLOAT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_ingredient_unit_conversions (ingredient_id, unit)
+);
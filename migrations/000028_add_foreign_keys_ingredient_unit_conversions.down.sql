@@ -0,0 +1,2 @@
+ALTER TABLE ingredient_unit_conversions
+DROP CONSTRAINT fk_ingredient_unit_conversions_ingredient;
@@ -0,0 +1 @@
+ALTER TABLE ingredient_unit_conversions ADD CONSTRAINT fk_ingredient_unit_conversions_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,3 @@
+ALTER TABLE ingredients
+DROP COLUMN reorder_quantity,
+DROP COLUMN reorder_point;
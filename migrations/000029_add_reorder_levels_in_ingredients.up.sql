@@ -0,0 +1,4 @@
+-- a reorder point of 0 means the ingredient is not monitored
+ALTER TABLE ingredients
+ADD COLUMN reorder_point FLOAT NOT NULL DEFAULT 0 AFTER base_unit,
+ADD COLUMN reorder_quantity FLOAT NOT NULL DEFAULT 0 AFTER reorder_point;
@@ -0,0 +1,11 @@
+-- unit_cost is the price of one base unit of the ingredient
+CREATE TABLE IF NOT EXISTS supplier_ingredients (
+    id CHAR(36) PRIMARY KEY,
+    supplier_id CHAR(36) NOT NULL,
+    ingredient_id CHAR(36) NOT NULL,
+    unit_cost FLOAT NOT NULL,
+    lead_time_days INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_supplier_ingredients (supplier_id, ingredient_id)
+);
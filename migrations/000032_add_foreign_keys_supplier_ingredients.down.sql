@@ -0,0 +1,3 @@
+ALTER TABLE supplier_ingredients
+DROP CONSTRAINT fk_supplier_ingredients_supplier,
+DROP CONSTRAINT fk_supplier_ingredients_ingredient;
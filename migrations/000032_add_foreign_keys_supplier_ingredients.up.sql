@@ -0,0 +1,2 @@
+ALTER TABLE supplier_ingredients ADD CONSTRAINT fk_supplier_ingredients_supplier FOREIGN KEY (supplier_id) REFERENCES suppliers (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_supplier_ingredients_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE;
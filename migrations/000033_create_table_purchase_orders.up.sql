@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS purchase_orders (
+    id CHAR(36) PRIMARY KEY,
+    supplier_id CHAR(36) NOT NULL,
+    status ENUM('draft', 'sent', 'partially_received', 'received', 'cancelled') NOT NULL DEFAULT 'draft',
+    notes VARCHAR(255) DEFAULT NULL,
+    expected_at TIMESTAMP NULL DEFAULT NULL,
+    created_by CHAR(36) DEFAULT NULL,
+    sent_at TIMESTAMP NULL DEFAULT NULL,
+    received_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_purchase_orders_status (status, created_at)
+);
+
+-- quantities are in the base unit of the ingredient
+CREATE TABLE IF NOT EXISTS purchase_order_items (
+    id CHAR(36) PRIMARY KEY,
+    purchase_order_id CHAR(36) NOT NULL,
+    ingredient_id CHAR(36) NOT NULL,
+    quantity FLOAT NOT NULL,
+    received_quantity FLOAT NOT NULL DEFAULT 0,
+    unit_cost FLOAT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
@@ -0,0 +1,7 @@
+ALTER TABLE purchase_order_items
+DROP CONSTRAINT fk_purchase_order_items_purchase_order,
+DROP CONSTRAINT fk_purchase_order_items_ingredient;
+
+ALTER TABLE purchase_orders
+DROP CONSTRAINT fk_purchase_orders_supplier,
+DROP CONSTRAINT fk_purchase_orders_created_by;
@@ -0,0 +1,5 @@
+ALTER TABLE purchase_orders ADD CONSTRAINT fk_purchase_orders_supplier FOREIGN KEY (supplier_id) REFERENCES suppliers (id) ON UPDATE CASCADE,
+ADD CONSTRAINT fk_purchase_orders_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE purchase_order_items ADD CONSTRAINT fk_purchase_order_items_purchase_order FOREIGN KEY (purchase_order_id) REFERENCES purchase_orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_purchase_order_items_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON UPDATE CASCADE;
@@ -0,0 +1,2 @@
+ALTER TABLE ingredients
+DROP COLUMN cost_per_unit;
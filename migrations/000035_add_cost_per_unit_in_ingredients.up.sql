@@ -0,0 +1,3 @@
+-- cost_per_unit is the price of one base unit of the ingredient
+ALTER TABLE ingredients
+ADD COLUMN cost_per_unit FLOAT NOT NULL DEFAULT 0 AFTER reorder_quantity;
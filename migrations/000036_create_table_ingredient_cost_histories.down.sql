@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ingredient_cost_histories;
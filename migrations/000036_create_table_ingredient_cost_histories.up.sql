@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS ingredient_cost_histories (
+    id CHAR(36) PRIMARY KEY,
+    ingredient_id CHAR(36) NOT NULL,
+    cost_per_unit FLOAT NOT NULL,
+    previous_cost_per_unit FLOAT NOT NULL DEFAULT 0,
+    source ENUM('manual', 'purchase_order') NOT NULL DEFAULT 'manual',
+    reference_id CHAR(36) DEFAULT NULL,
+    actor_id CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_ingredient_cost_histories_ingredient (ingredient_id, created_at)
+);
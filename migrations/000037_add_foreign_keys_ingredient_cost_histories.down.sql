@@ -0,0 +1,3 @@
+ALTER TABLE ingredient_cost_histories
+DROP CONSTRAINT fk_ingredient_cost_histories_ingredient,
+DROP CONSTRAINT fk_ingredient_cost_histories_actor;
@@ -0,0 +1,2 @@
+ALTER TABLE ingredient_cost_histories ADD CONSTRAINT fk_ingredient_cost_histories_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_ingredient_cost_histories_actor FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,21 @@
+-- quantity is what is left of the lot, the lots of an inventory add up to
+-- the inventory quantity
+CREATE TABLE IF NOT EXISTS inventory_lots (
+    id CHAR(36) PRIMARY KEY,
+    inventory_id CHAR(36) NOT NULL,
+    ingredient_id CHAR(36) NOT NULL,
+    lot_number VARCHAR(100) DEFAULT NULL,
+    quantity FLOAT NOT NULL,
+    initial_quantity FLOAT NOT NULL,
+    received_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMP NULL DEFAULT NULL,
+    reference_id CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_inventory_lots_inventory (inventory_id, quantity),
+    INDEX idx_inventory_lots_expires_at (expires_at)
+);
+
+-- current stock becomes one lot without expiry
+INSERT INTO inventory_lots (id, inventory_id, ingredient_id, quantity, initial_quantity)
+SELECT UUID(), id, ingredient_id, quantity, quantity FROM inventory WHERE quantity > 0;
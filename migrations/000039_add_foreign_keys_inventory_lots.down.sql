@@ -0,0 +1,3 @@
+ALTER TABLE inventory_lots
+DROP CONSTRAINT fk_inventory_lots_inventory,
+DROP CONSTRAINT fk_inventory_lots_ingredient;
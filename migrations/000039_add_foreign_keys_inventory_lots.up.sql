@@ -0,0 +1,2 @@
+ALTER TABLE inventory_lots ADD CONSTRAINT fk_inventory_lots_inventory FOREIGN KEY (inventory_id) REFERENCES inventory (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_inventory_lots_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE;
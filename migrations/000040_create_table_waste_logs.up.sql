@@ -0,0 +1,15 @@
+-- a waste entry is either one ingredient or whole portions of a menu item,
+-- cost is the food cost at the time the waste was logged
+CREATE TABLE IF NOT EXISTS waste_logs (
+    id CHAR(36) PRIMARY KEY,
+    ingredient_id CHAR(36) DEFAULT NULL,
+    menu_id CHAR(36) DEFAULT NULL,
+    quantity FLOAT NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    reason ENUM('spoiled', 'expired', 'dropped', 'overproduction', 'comped', 'other') NOT NULL,
+    notes VARCHAR(255) DEFAULT NULL,
+    cost FLOAT NOT NULL DEFAULT 0,
+    actor_id CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_waste_logs_created_at (created_at)
+);
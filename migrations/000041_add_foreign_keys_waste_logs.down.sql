@@ -0,0 +1,4 @@
+ALTER TABLE waste_logs
+DROP CONSTRAINT fk_waste_logs_ingredient,
+DROP CONSTRAINT fk_waste_logs_menu,
+DROP CONSTRAINT fk_waste_logs_actor;
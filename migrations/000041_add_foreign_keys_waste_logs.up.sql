@@ -0,0 +1,3 @@
+ALTER TABLE waste_logs ADD CONSTRAINT fk_waste_logs_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_waste_logs_menu FOREIGN KEY (menu_id) REFERENCES menu (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_waste_logs_actor FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE SET NULL;
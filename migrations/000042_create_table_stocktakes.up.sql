@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS stocktakes (
+    id CHAR(36) PRIMARY KEY,
+    status ENUM('open', 'closed', 'cancelled') NOT NULL DEFAULT 'open',
+    notes VARCHAR(255) DEFAULT NULL,
+    opened_by CHAR(36) DEFAULT NULL,
+    closed_by CHAR(36) DEFAULT NULL,
+    closed_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
+
+-- counted_quantity is in the base unit of the ingredient, expected_quantity
+-- and unit_cost are the stock and cost when the count was taken
+CREATE TABLE IF NOT EXISTS stocktake_lines (
+    id CHAR(36) PRIMARY KEY,
+    stocktake_id CHAR(36) NOT NULL,
+    ingredient_id CHAR(36) NOT NULL,
+    counted_quantity FLOAT NOT NULL,
+    expected_quantity FLOAT DEFAULT NULL,
+    unit_cost FLOAT DEFAULT NULL,
+    counted_by CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_stocktake_lines (stocktake_id, ingredient_id)
+);
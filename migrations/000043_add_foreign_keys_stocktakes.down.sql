@@ -0,0 +1,8 @@
+ALTER TABLE stocktake_lines
+DROP CONSTRAINT fk_stocktake_lines_stocktake,
+DROP CONSTRAINT fk_stocktake_lines_ingredient,
+DROP CONSTRAINT fk_stocktake_lines_counted_by;
+
+ALTER TABLE stocktakes
+DROP CONSTRAINT fk_stocktakes_opened_by,
+DROP CONSTRAINT fk_stocktakes_closed_by;
@@ -0,0 +1,6 @@
+ALTER TABLE stocktakes ADD CONSTRAINT fk_stocktakes_opened_by FOREIGN KEY (opened_by) REFERENCES users (id) ON DELETE SET NULL,
+ADD CONSTRAINT fk_stocktakes_closed_by FOREIGN KEY (closed_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE stocktake_lines ADD CONSTRAINT fk_stocktake_lines_stocktake FOREIGN KEY (stocktake_id) REFERENCES stocktakes (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_stocktake_lines_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_stocktake_lines_counted_by FOREIGN KEY (counted_by) REFERENCES users (id) ON DELETE SET NULL;
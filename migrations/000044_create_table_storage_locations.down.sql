@@ -0,0 +1,8 @@
+ALTER TABLE stocktake_lines ADD UNIQUE KEY uq_stocktake_lines (stocktake_id, ingredient_id);
+ALTER TABLE stocktake_lines DROP INDEX uq_stocktake_lines_location,
+DROP COLUMN location_id;
+
+ALTER TABLE inventory DROP INDEX uq_inventory_ingredient_location,
+DROP COLUMN location_id;
+
+DROP TABLE IF EXISTS storage_locations;
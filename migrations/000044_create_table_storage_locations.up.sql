@@ -0,0 +1,29 @@
+-- feeds_kitchen marks the locations the kitchen cooks from, only their stock
+-- counts towards menu availability. New stock goes to the default location
+-- unless another one is given.
+CREATE TABLE IF NOT EXISTS storage_locations (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    feeds_kitchen BOOLEAN NOT NULL DEFAULT TRUE,
+    is_default BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted BOOLEAN DEFAULT FALSE,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    UNIQUE KEY uq_storage_locations_name (name)
+);
+
+-- existing stock is moved to a default main store
+SET @main_store_id = UUID();
+INSERT INTO storage_locations (id, name, feeds_kitchen, is_default) VALUES (@main_store_id, 'Main store', TRUE, TRUE);
+
+ALTER TABLE inventory ADD COLUMN location_id CHAR(36) DEFAULT NULL AFTER ingredient_id;
+UPDATE inventory SET location_id = @main_store_id;
+ALTER TABLE inventory MODIFY location_id CHAR(36) NOT NULL,
+ADD UNIQUE KEY uq_inventory_ingredient_location (ingredient_id, location_id);
+
+ALTER TABLE stocktake_lines ADD COLUMN location_id CHAR(36) DEFAULT NULL AFTER ingredient_id;
+UPDATE stocktake_lines SET location_id = @main_store_id;
+ALTER TABLE stocktake_lines MODIFY location_id CHAR(36) NOT NULL,
+ADD UNIQUE KEY uq_stocktake_lines_location (stocktake_id, ingredient_id, location_id);
+ALTER TABLE stocktake_lines DROP INDEX uq_stocktake_lines;
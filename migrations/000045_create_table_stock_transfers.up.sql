@@ -0,0 +1,13 @@
+-- quantity is in the base unit of the ingredient, the matching transfer
+-- movements reference the transfer id
+CREATE TABLE IF NOT EXISTS stock_transfers (
+    id CHAR(36) PRIMARY KEY,
+    ingredient_id CHAR(36) NOT NULL,
+    from_location_id CHAR(36) NOT NULL,
+    to_location_id CHAR(36) NOT NULL,
+    quantity FLOAT NOT NULL,
+    reason VARCHAR(255) DEFAULT NULL,
+    actor_id CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_stock_transfers_created_at (created_at)
+);
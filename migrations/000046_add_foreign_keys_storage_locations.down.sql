@@ -0,0 +1,9 @@
+ALTER TABLE stock_transfers
+DROP CONSTRAINT fk_stock_transfers_ingredient,
+DROP CONSTRAINT fk_stock_transfers_from_location,
+DROP CONSTRAINT fk_stock_transfers_to_location,
+DROP CONSTRAINT fk_stock_transfers_actor;
+
+ALTER TABLE stocktake_lines DROP CONSTRAINT fk_stocktake_lines_location;
+
+ALTER TABLE inventory DROP CONSTRAINT fk_inventory_location;
@@ -0,0 +1,8 @@
+ALTER TABLE inventory ADD CONSTRAINT fk_inventory_location FOREIGN KEY (location_id) REFERENCES storage_locations (id) ON UPDATE CASCADE;
+
+ALTER TABLE stocktake_lines ADD CONSTRAINT fk_stocktake_lines_location FOREIGN KEY (location_id) REFERENCES storage_locations (id) ON UPDATE CASCADE;
+
+ALTER TABLE stock_transfers ADD CONSTRAINT fk_stock_transfers_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_stock_transfers_from_location FOREIGN KEY (from_location_id) REFERENCES storage_locations (id) ON UPDATE CASCADE,
+ADD CONSTRAINT fk_stock_transfers_to_location FOREIGN KEY (to_location_id) REFERENCES storage_locations (id) ON UPDATE CASCADE,
+ADD CONSTRAINT fk_stock_transfers_actor FOREIGN KEY (actor_id) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS recipe_components;
+
+DELETE FROM recipes WHERE menu_id IS NULL;
+ALTER TABLE recipes MODIFY menu_id CHAR(36) NOT NULL,
+DROP COLUMN yield_quantity,
+DROP COLUMN yield_unit;
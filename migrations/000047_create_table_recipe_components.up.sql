@@ -0,0 +1,17 @@
+-- prep recipes such as sauces and doughs are not sold on their own
+ALTER TABLE recipes MODIFY menu_id CHAR(36) NULL,
+ADD COLUMN yield_quantity FLOAT NOT NULL DEFAULT 1 AFTER description,
+ADD COLUMN yield_unit VARCHAR(20) NOT NULL DEFAULT 'portion' AFTER yield_quantity;
+
+CREATE TABLE IF NOT EXISTS recipe_components (
+    id CHAR(36) PRIMARY KEY,
+    recipe_id CHAR(36) NOT NULL,
+    component_recipe_id CHAR(36) NOT NULL,
+    quantity FLOAT NOT NULL,
+    unit VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted BOOLEAN DEFAULT FALSE,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    UNIQUE KEY uq_recipe_components (recipe_id, component_recipe_id)
+);
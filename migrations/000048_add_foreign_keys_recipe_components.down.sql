@@ -0,0 +1,3 @@
+ALTER TABLE recipe_components
+DROP CONSTRAINT fk_recipe_components_recipe,
+DROP CONSTRAINT fk_recipe_components_component;
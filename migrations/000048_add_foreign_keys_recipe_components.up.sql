@@ -0,0 +1,2 @@
+ALTER TABLE recipe_components ADD CONSTRAINT fk_recipe_components_recipe FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_recipe_components_component FOREIGN KEY (component_recipe_id) REFERENCES recipes (id) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,26 @@
+ALTER TABLE recipes ADD COLUMN yield_quantity FLOAT NOT NULL DEFAULT 1 AFTER description,
+ADD COLUMN yield_unit VARCHAR(20) NOT NULL DEFAULT 'portion' AFTER yield_quantity;
+
+-- only the latest published version of every recipe is kept
+CREATE TEMPORARY TABLE latest_recipe_versions AS
+SELECT recipe_versions.id, recipe_versions.recipe_id, recipe_versions.yield_quantity, recipe_versions.yield_unit FROM recipe_versions
+WHERE recipe_versions.id = (
+    SELECT latest.id FROM recipe_versions latest WHERE latest.recipe_id = recipe_versions.recipe_id AND latest.status = 'published'
+    ORDER BY latest.effective_from DESC, latest.version DESC LIMIT 1
+);
+
+UPDATE recipes JOIN latest_recipe_versions ON latest_recipe_versions.recipe_id = recipes.id
+SET recipes.yield_quantity = latest_recipe_versions.yield_quantity, recipes.yield_unit = latest_recipe_versions.yield_unit;
+
+DELETE FROM recipes_ingredients WHERE recipe_version_id NOT IN (SELECT id FROM latest_recipe_versions);
+DELETE FROM recipe_components WHERE recipe_version_id NOT IN (SELECT id FROM latest_recipe_versions);
+DROP TEMPORARY TABLE latest_recipe_versions;
+
+ALTER TABLE recipe_components ADD UNIQUE KEY uq_recipe_components (recipe_id, component_recipe_id);
+ALTER TABLE recipe_components DROP INDEX uq_recipe_components_version,
+DROP INDEX idx_recipe_components_recipe,
+DROP COLUMN recipe_version_id;
+
+ALTER TABLE recipes_ingredients DROP COLUMN recipe_version_id;
+
+DROP TABLE IF EXISTS recipe_versions;
@@ -0,0 +1,38 @@
+CREATE TABLE IF NOT EXISTS recipe_versions (
+    id CHAR(36) PRIMARY KEY,
+    recipe_id CHAR(36) NOT NULL,
+    version INT NOT NULL,
+    status ENUM('draft', 'published') NOT NULL DEFAULT 'draft',
+    yield_quantity FLOAT NOT NULL DEFAULT 1,
+    yield_unit VARCHAR(20) NOT NULL DEFAULT 'portion',
+    notes VARCHAR(255) DEFAULT NULL,
+    effective_from TIMESTAMP NULL DEFAULT NULL,
+    created_by CHAR(36) DEFAULT NULL,
+    published_by CHAR(36) DEFAULT NULL,
+    published_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_recipe_versions (recipe_id, version),
+    INDEX idx_recipe_versions_effective (recipe_id, status, effective_from)
+);
+
+-- existing recipes become their first version, in effect for every order
+-- placed so far
+INSERT INTO recipe_versions (id, recipe_id, version, status, yield_quantity, yield_unit, effective_from, published_at)
+SELECT UUID(), id, 1, 'published', yield_quantity, yield_unit, '1970-01-01 00:00:01', created_at FROM recipes;
+
+ALTER TABLE recipes_ingredients ADD COLUMN recipe_version_id CHAR(36) NULL AFTER recipe_id;
+UPDATE recipes_ingredients JOIN recipe_versions ON recipe_versions.recipe_id = recipes_ingredients.recipe_id
+SET recipes_ingredients.recipe_version_id = recipe_versions.id;
+ALTER TABLE recipes_ingredients MODIFY recipe_version_id CHAR(36) NOT NULL;
+
+ALTER TABLE recipe_components ADD COLUMN recipe_version_id CHAR(36) NULL AFTER recipe_id;
+UPDATE recipe_components JOIN recipe_versions ON recipe_versions.recipe_id = recipe_components.recipe_id
+SET recipe_components.recipe_version_id = recipe_versions.id;
+ALTER TABLE recipe_components MODIFY recipe_version_id CHAR(36) NOT NULL,
+ADD INDEX idx_recipe_components_recipe (recipe_id),
+ADD UNIQUE KEY uq_recipe_components_version (recipe_version_id, component_recipe_id);
+ALTER TABLE recipe_components DROP INDEX uq_recipe_components;
+
+ALTER TABLE recipes DROP COLUMN yield_quantity,
+DROP COLUMN yield_unit;
@@ -0,0 +1,8 @@
+ALTER TABLE recipe_components DROP CONSTRAINT fk_recipe_components_version;
+
+ALTER TABLE recipes_ingredients DROP CONSTRAINT fk_recipes_ingredients_version;
+
+ALTER TABLE recipe_versions
+DROP CONSTRAINT fk_recipe_versions_recipe,
+DROP CONSTRAINT fk_recipe_versions_created_by,
+DROP CONSTRAINT fk_recipe_versions_published_by;
@@ -0,0 +1,7 @@
+ALTER TABLE recipe_versions ADD CONSTRAINT fk_recipe_versions_recipe FOREIGN KEY (recipe_id) REFERENCES recipes (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_recipe_versions_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL,
+ADD CONSTRAINT fk_recipe_versions_published_by FOREIGN KEY (published_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE recipes_ingredients ADD CONSTRAINT fk_recipes_ingredients_version FOREIGN KEY (recipe_version_id) REFERENCES recipe_versions (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE recipe_components ADD CONSTRAINT fk_recipe_components_version FOREIGN KEY (recipe_version_id) REFERENCES recipe_versions (id) ON DELETE CASCADE ON UPDATE CASCADE;
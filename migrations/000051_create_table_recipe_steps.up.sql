@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS recipe_steps (
+    id CHAR(36) PRIMARY KEY,
+    recipe_version_id CHAR(36) NOT NULL,
+    position INT NOT NULL,
+    instruction TEXT NOT NULL,
+    duration_minutes INT NOT NULL DEFAULT 0,
+    station VARCHAR(50) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_recipe_steps_position (recipe_version_id, position)
+);
@@ -0,0 +1 @@
+ALTER TABLE recipe_steps DROP CONSTRAINT fk_recipe_steps_version;
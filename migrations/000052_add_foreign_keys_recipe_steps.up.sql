@@ -0,0 +1 @@
+ALTER TABLE recipe_steps ADD CONSTRAINT fk_recipe_steps_version FOREIGN KEY (recipe_version_id) REFERENCES recipe_versions (id) ON DELETE CASCADE ON UPDATE CASCADE;
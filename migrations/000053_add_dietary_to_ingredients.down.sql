@@ -0,0 +1,2 @@
+ALTER TABLE ingredients DROP COLUMN allergens,
+DROP COLUMN diets;
@@ -0,0 +1,4 @@
+-- the 14 major allergens and the diets an ingredient is suitable for, menu
+-- allergens and diets are derived from these through the recipes
+ALTER TABLE ingredients ADD COLUMN allergens SET('celery', 'crustaceans', 'eggs', 'fish', 'gluten', 'lupin', 'milk', 'molluscs', 'mustard', 'peanuts', 'sesame', 'soy', 'sulphites', 'tree_nuts') NOT NULL DEFAULT '',
+ADD COLUMN diets SET('vegan', 'vegetarian', 'halal') NOT NULL DEFAULT '';
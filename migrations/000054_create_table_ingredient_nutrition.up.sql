@@ -0,0 +1,12 @@
+-- nutrition of one base unit of the ingredient, DOUBLE keeps the small per
+-- gram values precise
+CREATE TABLE IF NOT EXISTS ingredient_nutrition (
+    ingredient_id CHAR(36) PRIMARY KEY,
+    kcal DOUBLE NOT NULL DEFAULT 0,
+    protein DOUBLE NOT NULL DEFAULT 0,
+    fat DOUBLE NOT NULL DEFAULT 0,
+    carbs DOUBLE NOT NULL DEFAULT 0,
+    sodium DOUBLE NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+);
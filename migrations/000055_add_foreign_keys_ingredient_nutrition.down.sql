@@ -0,0 +1 @@
+ALTER TABLE ingredient_nutrition DROP CONSTRAINT fk_ingredient_nutrition_ingredient;
@@ -0,0 +1 @@
+ALTER TABLE ingredient_nutrition ADD CONSTRAINT fk_ingredient_nutrition_ingredient FOREIGN KEY (ingredient_id) REFERENCES ingredients (id) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,19 @@
+-- every attempt to pay an order, the order is only paid once one of them
+-- has been captured by the provider
+CREATE TABLE IF NOT EXISTS payments (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    provider VARCHAR(50) NOT NULL,
+    provider_reference VARCHAR(255) NOT NULL,
+    method VARCHAR(50) NOT NULL,
+    amount FLOAT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    status ENUM("pending", "captured", "failed", "refunded") NOT NULL DEFAULT 'pending',
+    failure_reason VARCHAR(255) DEFAULT NULL,
+    created_by CHAR(36) NOT NULL,
+    captured_at TIMESTAMP NULL DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_payments_provider_reference (provider, provider_reference),
+    INDEX idx_payments_order (order_id, created_at)
+);
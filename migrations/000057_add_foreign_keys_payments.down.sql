@@ -0,0 +1,2 @@
+ALTER TABLE payments DROP CONSTRAINT fk_payments_order,
+DROP CONSTRAINT fk_payments_created_by;
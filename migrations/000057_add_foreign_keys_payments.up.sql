@@ -0,0 +1,2 @@
+ALTER TABLE payments ADD CONSTRAINT fk_payments_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_payments_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON UPDATE CASCADE;
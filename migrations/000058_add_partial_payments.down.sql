@@ -0,0 +1,7 @@
+ALTER TABLE payments DROP COLUMN split_parts;
+
+UPDATE orders SET payment_status = 'unpaid' WHERE payment_status = 'partially_paid';
+
+ALTER TABLE orders
+    DROP COLUMN paid_amount,
+    MODIFY payment_status ENUM("paid", "unpaid") NOT NULL DEFAULT 'unpaid';
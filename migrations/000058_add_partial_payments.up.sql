@@ -0,0 +1,10 @@
+-- paid_amount is the sum of the captured payments, the order is paid once it
+-- reaches amount
+ALTER TABLE orders
+    MODIFY payment_status ENUM("paid", "unpaid", "partially_paid") NOT NULL DEFAULT 'unpaid',
+    ADD COLUMN paid_amount FLOAT NOT NULL DEFAULT 0 AFTER amount;
+
+UPDATE orders SET paid_amount = amount WHERE payment_status = 'paid';
+
+-- split_parts is set when the payment is an even share of the order
+ALTER TABLE payments ADD COLUMN split_parts INT DEFAULT NULL AFTER amount;
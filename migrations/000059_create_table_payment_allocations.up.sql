@@ -0,0 +1,12 @@
+-- the order lines a payment covers when a bill is split by item
+CREATE TABLE IF NOT EXISTS payment_allocations (
+    id CHAR(36) PRIMARY KEY,
+    payment_id CHAR(36) NOT NULL,
+    order_menu_id CHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    amount FLOAT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_payment_allocations_line (payment_id, order_menu_id),
+    INDEX idx_payment_allocations_order_menu (order_menu_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE payment_allocations DROP CONSTRAINT fk_payment_allocations_payment,
+DROP CONSTRAINT fk_payment_allocations_order_menu;
@@ -0,0 +1,2 @@
+ALTER TABLE payment_allocations ADD CONSTRAINT fk_payment_allocations_payment FOREIGN KEY (payment_id) REFERENCES payments (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_payment_allocations_order_menu FOREIGN KEY (order_menu_id) REFERENCES order_menu (id) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -0,0 +1,3 @@
+UPDATE users SET role = 'staff' WHERE role = 'manager';
+
+ALTER TABLE users MODIFY role ENUM('admin', 'customer', 'staff') NOT NULL DEFAULT 'customer';
@@ -0,0 +1,2 @@
+-- managers get everything staff can do and approve large refunds
+ALTER TABLE users MODIFY role ENUM('admin', 'customer', 'staff', 'manager') NOT NULL DEFAULT 'customer';
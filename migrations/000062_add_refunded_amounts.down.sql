@@ -0,0 +1,3 @@
+ALTER TABLE payments DROP COLUMN refunded_amount;
+
+ALTER TABLE orders DROP COLUMN refunded_amount;
@@ -0,0 +1,5 @@
+-- refunds never lower paid_amount, what the restaurant kept is paid_amount
+-- minus refunded_amount
+ALTER TABLE orders ADD COLUMN refunded_amount FLOAT NOT NULL DEFAULT 0 AFTER paid_amount;
+
+ALTER TABLE payments ADD COLUMN refunded_amount FLOAT NOT NULL DEFAULT 0 AFTER split_parts;
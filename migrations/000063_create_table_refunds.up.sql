@@ -0,0 +1,23 @@
+-- approved_by is set when the amount needed a manager
+CREATE TABLE IF NOT EXISTS refunds (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    amount FLOAT NOT NULL,
+    reason_code ENUM('customer_complaint', 'wrong_item', 'quality_issue', 'overcharge', 'duplicate_charge', 'other') NOT NULL,
+    note VARCHAR(255) DEFAULT NULL,
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by CHAR(36) DEFAULT NULL,
+    approved_by CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_refunds_order (order_id, created_at)
+);
+
+CREATE TABLE IF NOT EXISTS refund_items (
+    id CHAR(36) PRIMARY KEY,
+    refund_id CHAR(36) NOT NULL,
+    order_menu_id CHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    amount FLOAT NOT NULL,
+    UNIQUE KEY uq_refund_items_line (refund_id, order_menu_id),
+    INDEX idx_refund_items_order_menu (order_menu_id)
+);
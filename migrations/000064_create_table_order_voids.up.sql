@@ -0,0 +1,15 @@
+-- units taken off an order before they were paid, the order line quantity
+-- and the order amount are lowered when the void is recorded
+CREATE TABLE IF NOT EXISTS order_voids (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    order_menu_id CHAR(36) NOT NULL,
+    quantity INT NOT NULL,
+    amount FLOAT NOT NULL,
+    reason_code ENUM('customer_request', 'entered_in_error', 'out_of_stock', 'quality_issue', 'other') NOT NULL,
+    note VARCHAR(255) DEFAULT NULL,
+    restocked BOOLEAN NOT NULL DEFAULT FALSE,
+    created_by CHAR(36) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_order_voids_order (order_id, created_at)
+);
@@ -0,0 +1,10 @@
+ALTER TABLE order_voids DROP CONSTRAINT fk_order_voids_order,
+DROP CONSTRAINT fk_order_voids_order_menu,
+DROP CONSTRAINT fk_order_voids_created_by;
+
+ALTER TABLE refund_items DROP CONSTRAINT fk_refund_items_refund,
+DROP CONSTRAINT fk_refund_items_order_menu;
+
+ALTER TABLE refunds DROP CONSTRAINT fk_refunds_order,
+DROP CONSTRAINT fk_refunds_created_by,
+DROP CONSTRAINT fk_refunds_approved_by;
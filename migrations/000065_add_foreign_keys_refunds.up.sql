@@ -0,0 +1,10 @@
+ALTER TABLE refunds ADD CONSTRAINT fk_refunds_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_refunds_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL,
+ADD CONSTRAINT fk_refunds_approved_by FOREIGN KEY (approved_by) REFERENCES users (id) ON DELETE SET NULL;
+
+ALTER TABLE refund_items ADD CONSTRAINT fk_refund_items_refund FOREIGN KEY (refund_id) REFERENCES refunds (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_refund_items_order_menu FOREIGN KEY (order_menu_id) REFERENCES order_menu (id) ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE order_voids ADD CONSTRAINT fk_order_voids_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_order_voids_order_menu FOREIGN KEY (order_menu_id) REFERENCES order_menu (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_order_voids_created_by FOREIGN KEY (created_by) REFERENCES users (id) ON DELETE SET NULL;
@@ -0,0 +1,14 @@
+-- a rate without a category applies to every menu category that has no rates
+-- of its own, inclusive rates are already part of the menu price
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id CHAR(36) PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    rate_percent FLOAT NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    category VARCHAR(50) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted BOOLEAN DEFAULT FALSE,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    INDEX idx_tax_rates_category (category)
+);
@@ -0,0 +1,9 @@
+ALTER TABLE payments DROP COLUMN rounding;
+
+ALTER TABLE orders
+    DROP COLUMN rounding,
+    DROP COLUMN service_charge,
+    DROP COLUMN service_charge_percent,
+    DROP COLUMN tax_total,
+    DROP COLUMN subtotal,
+    DROP COLUMN order_type;
@@ -0,0 +1,16 @@
+-- amount stays the total of the order: subtotal + tax_total + service_charge
+-- + rounding. tax_total only holds the exclusive taxes, inclusive taxes are
+-- part of the subtotal. service_charge_percent is the rate in effect when the
+-- order was placed.
+ALTER TABLE orders
+    ADD COLUMN order_type ENUM("dine_in", "takeaway") NOT NULL DEFAULT 'takeaway' AFTER user_id,
+    ADD COLUMN subtotal FLOAT NOT NULL DEFAULT 0 AFTER order_type,
+    ADD COLUMN tax_total FLOAT NOT NULL DEFAULT 0 AFTER subtotal,
+    ADD COLUMN service_charge_percent FLOAT NOT NULL DEFAULT 0 AFTER tax_total,
+    ADD COLUMN service_charge FLOAT NOT NULL DEFAULT 0 AFTER service_charge_percent,
+    ADD COLUMN rounding FLOAT NOT NULL DEFAULT 0 AFTER service_charge;
+
+UPDATE orders SET subtotal = amount;
+
+-- rounding is what cash rounding added to the balance the payment settled
+ALTER TABLE payments ADD COLUMN rounding FLOAT NOT NULL DEFAULT 0 AFTER amount;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS order_tax_lines;
+DROP TABLE IF EXISTS order_item_taxes;
@@ -0,0 +1,25 @@
+-- order_item_taxes snapshots the rates each order line was charged so the
+-- breakdown can be worked out again after a void, order_tax_lines is the
+-- breakdown per rate printed on the receipt
+CREATE TABLE IF NOT EXISTS order_item_taxes (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    order_menu_id CHAR(36) NOT NULL,
+    tax_rate_id CHAR(36) DEFAULT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate_percent FLOAT NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    INDEX idx_order_item_taxes_order (order_id)
+);
+
+CREATE TABLE IF NOT EXISTS order_tax_lines (
+    id CHAR(36) PRIMARY KEY,
+    order_id CHAR(36) NOT NULL,
+    tax_rate_id CHAR(36) DEFAULT NULL,
+    name VARCHAR(100) NOT NULL,
+    rate_percent FLOAT NOT NULL,
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    taxable_amount FLOAT NOT NULL,
+    amount FLOAT NOT NULL,
+    INDEX idx_order_tax_lines_order (order_id)
+);
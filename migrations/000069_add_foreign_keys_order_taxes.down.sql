@@ -0,0 +1,6 @@
+ALTER TABLE order_tax_lines DROP CONSTRAINT fk_order_tax_lines_order,
+DROP CONSTRAINT fk_order_tax_lines_tax_rate;
+
+ALTER TABLE order_item_taxes DROP CONSTRAINT fk_order_item_taxes_order,
+DROP CONSTRAINT fk_order_item_taxes_order_menu,
+DROP CONSTRAINT fk_order_item_taxes_tax_rate;
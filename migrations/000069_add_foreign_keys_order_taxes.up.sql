@@ -0,0 +1,6 @@
+ALTER TABLE order_item_taxes ADD CONSTRAINT fk_order_item_taxes_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_order_item_taxes_order_menu FOREIGN KEY (order_menu_id) REFERENCES order_menu (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_order_item_taxes_tax_rate FOREIGN KEY (tax_rate_id) REFERENCES tax_rates (id) ON DELETE SET NULL;
+
+ALTER TABLE order_tax_lines ADD CONSTRAINT fk_order_tax_lines_order FOREIGN KEY (order_id) REFERENCES orders (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_order_tax_lines_tax_rate FOREIGN KEY (tax_rate_id) REFERENCES tax_rates (id) ON DELETE SET NULL;
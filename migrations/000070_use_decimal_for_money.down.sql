@@ -0,0 +1,44 @@
+ALTER TABLE stocktake_lines MODIFY unit_cost FLOAT DEFAULT NULL;
+
+ALTER TABLE purchase_order_items MODIFY unit_cost FLOAT NOT NULL;
+
+ALTER TABLE supplier_ingredients MODIFY unit_cost FLOAT NOT NULL;
+
+ALTER TABLE ingredient_cost_histories
+    MODIFY cost_per_unit FLOAT NOT NULL,
+    MODIFY previous_cost_per_unit FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE ingredients MODIFY cost_per_unit FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE waste_logs MODIFY cost FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_tax_lines
+    MODIFY taxable_amount FLOAT NOT NULL,
+    MODIFY amount FLOAT NOT NULL;
+
+ALTER TABLE order_voids MODIFY amount FLOAT NOT NULL;
+
+ALTER TABLE refund_items MODIFY amount FLOAT NOT NULL;
+
+ALTER TABLE refunds MODIFY amount FLOAT NOT NULL;
+
+ALTER TABLE payment_allocations MODIFY amount FLOAT NOT NULL;
+
+ALTER TABLE payments
+    MODIFY amount FLOAT NOT NULL,
+    MODIFY rounding FLOAT NOT NULL DEFAULT 0,
+    MODIFY refunded_amount FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    DROP COLUMN currency,
+    MODIFY subtotal FLOAT NOT NULL DEFAULT 0,
+    MODIFY tax_total FLOAT NOT NULL DEFAULT 0,
+    MODIFY service_charge FLOAT NOT NULL DEFAULT 0,
+    MODIFY rounding FLOAT NOT NULL DEFAULT 0,
+    MODIFY amount FLOAT NOT NULL,
+    MODIFY paid_amount FLOAT NOT NULL DEFAULT 0,
+    MODIFY refunded_amount FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE order_menu MODIFY unit_price FLOAT NOT NULL DEFAULT 0;
+
+ALTER TABLE menu MODIFY price FLOAT NOT NULL;
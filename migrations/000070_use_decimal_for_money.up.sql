@@ -0,0 +1,51 @@
+-- Money is kept as DECIMAL(15,2) so totals add up to the cent, per unit costs
+-- keep more decimals because they are multiplied by fractional quantities.
+ALTER TABLE menu MODIFY price DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE order_menu MODIFY unit_price DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE orders
+    MODIFY subtotal DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY tax_total DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY service_charge DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY rounding DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY amount DECIMAL(15,2) NOT NULL,
+    MODIFY paid_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY refunded_amount DECIMAL(15,2) NOT NULL DEFAULT 0,
+    ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'IDR' AFTER amount;
+
+-- orders that were paid keep the currency they were paid in
+UPDATE orders o
+    JOIN (SELECT order_id, MIN(currency) AS currency FROM payments GROUP BY order_id) p ON p.order_id = o.id
+SET o.currency = p.currency;
+
+ALTER TABLE payments
+    MODIFY amount DECIMAL(15,2) NOT NULL,
+    MODIFY rounding DECIMAL(15,2) NOT NULL DEFAULT 0,
+    MODIFY refunded_amount DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE payment_allocations MODIFY amount DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE refunds MODIFY amount DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE refund_items MODIFY amount DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE order_voids MODIFY amount DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE order_tax_lines
+    MODIFY taxable_amount DECIMAL(15,2) NOT NULL,
+    MODIFY amount DECIMAL(15,2) NOT NULL;
+
+ALTER TABLE waste_logs MODIFY cost DECIMAL(15,2) NOT NULL DEFAULT 0;
+
+ALTER TABLE ingredients MODIFY cost_per_unit DECIMAL(19,6) NOT NULL DEFAULT 0;
+
+ALTER TABLE ingredient_cost_histories
+    MODIFY cost_per_unit DECIMAL(19,6) NOT NULL,
+    MODIFY previous_cost_per_unit DECIMAL(19,6) NOT NULL DEFAULT 0;
+
+ALTER TABLE supplier_ingredients MODIFY unit_cost DECIMAL(19,6) NOT NULL;
+
+ALTER TABLE purchase_order_items MODIFY unit_cost DECIMAL(19,6) NOT NULL;
+
+ALTER TABLE stocktake_lines MODIFY unit_cost DECIMAL(19,6) DEFAULT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS inventory_lot_draws;
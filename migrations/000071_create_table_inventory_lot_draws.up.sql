@@ -0,0 +1,12 @@
+-- what a movement with a reference took out of each lot, quantity is what
+-- has not been put back yet. Returned stock goes back into the lots it came
+-- from so it keeps their expiry.
+CREATE TABLE IF NOT EXISTS inventory_lot_draws (
+    id CHAR(36) PRIMARY KEY,
+    lot_id CHAR(36) NOT NULL,
+    inventory_id CHAR(36) NOT NULL,
+    reference_id CHAR(36) NOT NULL,
+    quantity FLOAT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_inventory_lot_draws_reference (reference_id, inventory_id)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_lot_draws DROP CONSTRAINT fk_inventory_lot_draws_lot,
+DROP CONSTRAINT fk_inventory_lot_draws_inventory;
@@ -0,0 +1,2 @@
+ALTER TABLE inventory_lot_draws ADD CONSTRAINT fk_inventory_lot_draws_lot FOREIGN KEY (lot_id) REFERENCES inventory_lots (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_inventory_lot_draws_inventory FOREIGN KEY (inventory_id) REFERENCES inventory (id) ON DELETE CASCADE ON UPDATE CASCADE;
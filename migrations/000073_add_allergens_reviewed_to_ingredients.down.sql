@@ -0,0 +1 @@
+ALTER TABLE ingredients DROP COLUMN allergens_reviewed;
@@ -0,0 +1,3 @@
+-- an empty allergens set only means allergen free once someone has reviewed
+-- the ingredient, ingredients tagged before this have to be reviewed again
+ALTER TABLE ingredients ADD COLUMN allergens_reviewed BOOLEAN NOT NULL DEFAULT FALSE AFTER allergens;
@@ -0,0 +1,2 @@
+UPDATE payments SET status = 'pending' WHERE status = 'capturing';
+ALTER TABLE payments MODIFY status ENUM("pending", "captured", "failed", "refunded") NOT NULL DEFAULT 'pending';
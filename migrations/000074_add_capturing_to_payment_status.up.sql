@@ -0,0 +1,3 @@
+-- a payment is capturing while the provider is asked for the money, it is
+-- committed before the call so a capture is never lost with a rollback
+ALTER TABLE payments MODIFY status ENUM("pending", "capturing", "captured", "failed", "refunded") NOT NULL DEFAULT 'pending';
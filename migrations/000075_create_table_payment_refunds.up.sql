@@ -0,0 +1,14 @@
+-- the share of a refund given back through one payment, recorded as pending
+-- before the provider is asked and completed once it confirmed. Pending rows
+-- are refunds the provider may not have made and have to be reconciled.
+CREATE TABLE IF NOT EXISTS payment_refunds (
+    id CHAR(36) PRIMARY KEY,
+    refund_id CHAR(36) NOT NULL,
+    payment_id CHAR(36) NOT NULL,
+    amount DECIMAL(15,2) NOT NULL,
+    status ENUM("pending", "completed") NOT NULL DEFAULT 'pending',
+    provider_reference VARCHAR(255) DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    INDEX idx_payment_refunds_status (status, created_at)
+);
@@ -0,0 +1,2 @@
+ALTER TABLE payment_refunds DROP CONSTRAINT fk_payment_refunds_refund,
+DROP CONSTRAINT fk_payment_refunds_payment;
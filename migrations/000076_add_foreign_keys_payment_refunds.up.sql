@@ -0,0 +1,2 @@
+ALTER TABLE payment_refunds ADD CONSTRAINT fk_payment_refunds_refund FOREIGN KEY (refund_id) REFERENCES refunds (id) ON DELETE CASCADE ON UPDATE CASCADE,
+ADD CONSTRAINT fk_payment_refunds_payment FOREIGN KEY (payment_id) REFERENCES payments (id) ON DELETE CASCADE ON UPDATE CASCADE;
@@ -1,7 +1,11 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -19,8 +23,47 @@ type Config struct {
 	Secret struct {
 		JwtSecretKey string
 	}
+	Notification struct {
+		StockAlertWebhookUrl string
+	}
+	Costing struct {
+		TargetMarginPercent float64
+	}
+	Scheduler struct {
+		ExpiredLotInterval time.Duration
+	}
+	Payment struct {
+		Provider      string
+		WebhookSecret string
+		Currency      string
+	}
+	Refund struct {
+		ApprovalThreshold float64
+	}
+	Pricing struct {
+		ServiceChargePercent  float64
+		CashRoundingIncrement float64
+		CashRoundingMode      string
+	}
 }
 
+// defaultTargetMarginPercent is used when TARGET_MARGIN_PERCENT is not set
+const defaultTargetMarginPercent = 70
+
+// defaultExpiredLotInterval is used when EXPIRED_LOT_INTERVAL is not set
+const defaultExpiredLotInterval = time.Hour
+
+// defaultPaymentCurrency is used when PAYMENT_CURRENCY is not set
+const defaultPaymentCurrency = "IDR"
+
+// defaultRefundApprovalThreshold is used when REFUND_APPROVAL_THRESHOLD is
+// not set, every refund needs a manager
+const defaultRefundApprovalThreshold = 0
+
+// defaultCashRoundingMode is used when CASH_ROUNDING_MODE is not set, cash
+// is only rounded when CASH_ROUNDING_INCREMENT is set
+const defaultCashRoundingMode = "nearest"
+
 func LoadConfig() (*Config, error) {
 	err := godotenv.Load()
 	if err != nil {
@@ -41,5 +84,89 @@ func LoadConfig() (*Config, error) {
 	// Secret
 	config.Secret.JwtSecretKey = os.Getenv("JWT_SECRET_KEY")
 
+	// Notification
+	config.Notification.StockAlertWebhookUrl = os.Getenv("STOCK_ALERT_WEBHOOK_URL")
+
+	// Costing
+	config.Costing.TargetMarginPercent = defaultTargetMarginPercent
+	if targetMargin := os.Getenv("TARGET_MARGIN_PERCENT"); targetMargin != "" {
+		config.Costing.TargetMarginPercent, err = strconv.ParseFloat(targetMargin, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Scheduler
+	config.Scheduler.ExpiredLotInterval = defaultExpiredLotInterval
+	if interval := os.Getenv("EXPIRED_LOT_INTERVAL"); interval != "" {
+		config.Scheduler.ExpiredLotInterval, err = time.ParseDuration(interval)
+		if err != nil {
+			return nil, err
+		}
+		if config.Scheduler.ExpiredLotInterval <= 0 {
+			return nil, fmt.Errorf("EXPIRED_LOT_INTERVAL must be positive")
+		}
+	}
+
+	// Payment
+	// the fake provider moves no money, it has to be asked for by name
+	config.Payment.Provider = os.Getenv("PAYMENT_PROVIDER")
+	if config.Payment.Provider == "" {
+		return nil, fmt.Errorf("PAYMENT_PROVIDER must be set")
+	}
+	// webhooks are public, an empty secret would let anyone sign events
+	config.Payment.WebhookSecret = os.Getenv("PAYMENT_WEBHOOK_SECRET")
+	if config.Payment.WebhookSecret == "" {
+		return nil, fmt.Errorf("PAYMENT_WEBHOOK_SECRET must be set")
+	}
+	config.Payment.Currency = defaultPaymentCurrency
+	if currency := os.Getenv("PAYMENT_CURRENCY"); currency != "" {
+		if len(currency) != 3 {
+			return nil, fmt.Errorf("PAYMENT_CURRENCY must be a three letter currency code")
+		}
+		config.Payment.Currency = strings.ToUpper(currency)
+	}
+
+	// Refund
+	config.Refund.ApprovalThreshold = defaultRefundApprovalThreshold
+	if threshold := os.Getenv("REFUND_APPROVAL_THRESHOLD"); threshold != "" {
+		config.Refund.ApprovalThreshold, err = strconv.ParseFloat(threshold, 64)
+		if err != nil {
+			return nil, err
+		}
+		if config.Refund.ApprovalThreshold < 0 {
+			return nil, fmt.Errorf("REFUND_APPROVAL_THRESHOLD must not be negative")
+		}
+	}
+
+	// Pricing
+	if serviceCharge := os.Getenv("SERVICE_CHARGE_PERCENT"); serviceCharge != "" {
+		config.Pricing.ServiceChargePercent, err = strconv.ParseFloat(serviceCharge, 64)
+		if err != nil {
+			return nil, err
+		}
+		if config.Pricing.ServiceChargePercent < 0 || config.Pricing.ServiceChargePercent > 100 {
+			return nil, fmt.Errorf("SERVICE_CHARGE_PERCENT must be between 0 and 100")
+		}
+	}
+	if increment := os.Getenv("CASH_ROUNDING_INCREMENT"); increment != "" {
+		config.Pricing.CashRoundingIncrement, err = strconv.ParseFloat(increment, 64)
+		if err != nil {
+			return nil, err
+		}
+		if config.Pricing.CashRoundingIncrement < 0 {
+			return nil, fmt.Errorf("CASH_ROUNDING_INCREMENT must not be negative")
+		}
+	}
+	config.Pricing.CashRoundingMode = defaultCashRoundingMode
+	if mode := os.Getenv("CASH_ROUNDING_MODE"); mode != "" {
+		switch mode {
+		case "nearest", "up", "down":
+			config.Pricing.CashRoundingMode = mode
+		default:
+			return nil, fmt.Errorf("CASH_ROUNDING_MODE must be nearest, up or down")
+		}
+	}
+
 	return config, nil
 }
@@ -0,0 +1,14 @@
+package config
+
+import "github.com/casbin/casbin/v2"
+
+const (
+	casbinModelPath  = "pkg/config/casbin/model.conf"
+	casbinPolicyPath = "pkg/config/casbin/policy.csv"
+)
+
+// NewEnforcer loads the Casbin model and policy, the routes and the
+// permissions checked inside usecases share the same policy
+func NewEnforcer() (*casbin.Enforcer, error) {
+	return casbin.NewEnforcer(casbinModelPath, casbinPolicyPath)
+}
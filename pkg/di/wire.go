@@ -8,7 +8,10 @@ import (
 
 	"github.com/google/wire"
 	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/payment"
 	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/internal/scheduler"
 	"github.com/ryvasa/go-restaurant/internal/usecase"
 	"github.com/ryvasa/go-restaurant/pkg/config"
 	"github.com/ryvasa/go-restaurant/pkg/database"
@@ -17,6 +20,8 @@ import (
 
 var orderMenuSet = wire.NewSet(
 	repository.NewOrderMenuRepository,
+	repository.NewOrderStatusHistoryRepository,
+	repository.NewOrderTaxLineRepository,
 )
 
 var orderSet = wire.NewSet(
@@ -72,16 +77,72 @@ var recipeSet = wire.NewSet(
 
 var inventorySet = wire.NewSet(
 	repository.NewInventoryRepository,
+	repository.NewInventoryMovementRepository,
+	repository.NewInventoryLotRepository,
+	repository.NewStockTransferRepository,
 	usecase.NewInventoryUsecase,
 	handler.NewInventoryHandler,
 )
 
 var ingredientSet = wire.NewSet(
 	repository.NewIngredientRepository,
+	repository.NewIngredientUnitConversionRepository,
+	repository.NewIngredientCostHistoryRepository,
 	usecase.NewIngredientUsecase,
 	handler.NewIngredientHandler,
 )
 
+var supplierSet = wire.NewSet(
+	repository.NewSupplierRepository,
+	repository.NewSupplierIngredientRepository,
+	usecase.NewSupplierUsecase,
+	handler.NewSupplierHandler,
+)
+
+var purchaseOrderSet = wire.NewSet(
+	repository.NewPurchaseOrderRepository,
+	repository.NewPurchaseOrderItemRepository,
+	usecase.NewPurchaseOrderUsecase,
+	handler.NewPurchaseOrderHandler,
+)
+
+var wasteSet = wire.NewSet(
+	repository.NewWasteLogRepository,
+	usecase.NewWasteUsecase,
+	handler.NewWasteHandler,
+)
+
+var stocktakeSet = wire.NewSet(
+	repository.NewStocktakeRepository,
+	usecase.NewStocktakeUsecase,
+	handler.NewStocktakeHandler,
+)
+
+var storageLocationSet = wire.NewSet(
+	repository.NewStorageLocationRepository,
+	usecase.NewStorageLocationUsecase,
+	handler.NewStorageLocationHandler,
+)
+
+var taxRateSet = wire.NewSet(
+	repository.NewTaxRateRepository,
+	usecase.NewTaxRateUsecase,
+	handler.NewTaxRateHandler,
+)
+
+var reportSet = wire.NewSet(
+	usecase.NewReportUsecase,
+	handler.NewReportHandler,
+)
+
+var notifierSet = wire.NewSet(
+	notifier.NewStockAlertNotifier,
+)
+
+var paymentSet = wire.NewSet(
+	payment.NewPaymentProvider,
+)
+
 var txSet = wire.NewSet(
 	repository.NewTransactionRepository,
 )
@@ -94,6 +155,7 @@ var utilSet = wire.NewSet(
 func InitializeHandlers() (*handler.Handlers, error) {
 	wire.Build(
 		config.LoadConfig,
+		config.NewEnforcer,
 		database.ProvideDSN,
 		database.NewMySQLConnection,
 		ProvideDBConnection,
@@ -109,8 +171,36 @@ func InitializeHandlers() (*handler.Handlers, error) {
 		recipeSet,
 		inventorySet,
 		ingredientSet,
+		supplierSet,
+		purchaseOrderSet,
+		wasteSet,
+		stocktakeSet,
+		storageLocationSet,
+		taxRateSet,
+		reportSet,
+		notifierSet,
+		paymentSet,
 		txSet,
 		handler.NewHandlers,
 	)
 	return &handler.Handlers{}, nil
 }
+
+// InitializeScheduler initializes the background jobs with dependencies
+func InitializeScheduler() (scheduler.Scheduler, error) {
+	wire.Build(
+		config.LoadConfig,
+		database.ProvideDSN,
+		database.NewMySQLConnection,
+		ProvideDBConnection,
+		repository.NewInventoryRepository,
+		repository.NewInventoryMovementRepository,
+		repository.NewInventoryLotRepository,
+		repository.NewStockTransferRepository,
+		usecase.NewInventoryUsecase,
+		notifierSet,
+		txSet,
+		scheduler.NewScheduler,
+	)
+	return nil, nil
+}
@@ -10,7 +10,10 @@ import (
 	"database/sql"
 	"github.com/google/wire"
 	"github.com/ryvasa/go-restaurant/internal/delivery/http/handler"
+	"github.com/ryvasa/go-restaurant/internal/notifier"
+	"github.com/ryvasa/go-restaurant/internal/payment"
 	"github.com/ryvasa/go-restaurant/internal/repository"
+	"github.com/ryvasa/go-restaurant/internal/scheduler"
 	"github.com/ryvasa/go-restaurant/internal/usecase"
 	"github.com/ryvasa/go-restaurant/pkg/config"
 	"github.com/ryvasa/go-restaurant/pkg/database"
@@ -46,7 +49,18 @@ func InitializeHandlers() (*handler.Handlers, error) {
 	authUsecase := usecase.NewAuthUsecase(userRepository, tokenUtil, transactionRepository)
 	authHandler := handler.NewAuthHandler(authUsecase)
 	orderMenuRepository := repository.NewOrderMenuRepository(repositoryDB)
-	orderUsecase := usecase.NewOrderUsecase(orderRepository, menuRepository, userRepository, orderMenuRepository, transactionRepository)
+	orderStatusHistoryRepository := repository.NewOrderStatusHistoryRepository(repositoryDB)
+	stockAlertNotifier := notifier.NewStockAlertNotifier(configConfig)
+	paymentProvider, err := payment.NewPaymentProvider(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	enforcer, err := config.NewEnforcer()
+	if err != nil {
+		return nil, err
+	}
+	orderTaxLineRepository := repository.NewOrderTaxLineRepository(repositoryDB)
+	orderUsecase := usecase.NewOrderUsecase(configConfig, orderRepository, menuRepository, userRepository, orderMenuRepository, orderStatusHistoryRepository, orderTaxLineRepository, stockAlertNotifier, paymentProvider, enforcer, transactionRepository)
 	orderHandler := handler.NewOrderHandler(orderUsecase)
 	tableRepository := repository.NewTableRepository(repositoryDB)
 	tableUsecase := usecase.NewTableUsecase(tableRepository, transactionRepository)
@@ -58,18 +72,68 @@ func InitializeHandlers() (*handler.Handlers, error) {
 	recipeUsecase := usecase.NewRecipeUsecase(recipeRepository, menuRepository, transactionRepository)
 	recipeHandler := handler.NewRecipeHandler(recipeUsecase)
 	inventoryRepository := repository.NewInventoryRepository(repositoryDB)
-	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepository, transactionRepository)
+	inventoryMovementRepository := repository.NewInventoryMovementRepository(repositoryDB)
+	inventoryLotRepository := repository.NewInventoryLotRepository(repositoryDB)
+	stockTransferRepository := repository.NewStockTransferRepository(repositoryDB)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepository, inventoryMovementRepository, inventoryLotRepository, stockTransferRepository, stockAlertNotifier, transactionRepository)
 	inventoryHandler := handler.NewInventoryHandler(inventoryUsecase)
 	ingredientRepository := repository.NewIngredientRepository(repositoryDB)
-	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepository, recipeRepository, transactionRepository)
+	ingredientUnitConversionRepository := repository.NewIngredientUnitConversionRepository(repositoryDB)
+	ingredientCostHistoryRepository := repository.NewIngredientCostHistoryRepository(repositoryDB)
+	ingredientUsecase := usecase.NewIngredientUsecase(ingredientRepository, recipeRepository, ingredientUnitConversionRepository, ingredientCostHistoryRepository, transactionRepository)
 	ingredientHandler := handler.NewIngredientHandler(ingredientUsecase)
-	handlers := handler.NewHandlers(menuHandler, userHandler, reviewHandler, authHandler, orderHandler, tableHandler, reservationHandler, recipeHandler, inventoryHandler, ingredientHandler)
+	supplierRepository := repository.NewSupplierRepository(repositoryDB)
+	supplierIngredientRepository := repository.NewSupplierIngredientRepository(repositoryDB)
+	supplierUsecase := usecase.NewSupplierUsecase(supplierRepository, supplierIngredientRepository, transactionRepository)
+	supplierHandler := handler.NewSupplierHandler(supplierUsecase)
+	purchaseOrderRepository := repository.NewPurchaseOrderRepository(repositoryDB)
+	purchaseOrderItemRepository := repository.NewPurchaseOrderItemRepository(repositoryDB)
+	purchaseOrderUsecase := usecase.NewPurchaseOrderUsecase(purchaseOrderRepository, purchaseOrderItemRepository, stockAlertNotifier, transactionRepository)
+	purchaseOrderHandler := handler.NewPurchaseOrderHandler(purchaseOrderUsecase)
+	wasteLogRepository := repository.NewWasteLogRepository(repositoryDB)
+	reportUsecase := usecase.NewReportUsecase(configConfig, wasteLogRepository, transactionRepository)
+	reportHandler := handler.NewReportHandler(reportUsecase)
+	wasteUsecase := usecase.NewWasteUsecase(wasteLogRepository, stockAlertNotifier, transactionRepository)
+	wasteHandler := handler.NewWasteHandler(wasteUsecase)
+	stocktakeRepository := repository.NewStocktakeRepository(repositoryDB)
+	stocktakeUsecase := usecase.NewStocktakeUsecase(stocktakeRepository, stockAlertNotifier, transactionRepository)
+	stocktakeHandler := handler.NewStocktakeHandler(stocktakeUsecase)
+	storageLocationRepository := repository.NewStorageLocationRepository(repositoryDB)
+	storageLocationUsecase := usecase.NewStorageLocationUsecase(storageLocationRepository, inventoryRepository, transactionRepository)
+	storageLocationHandler := handler.NewStorageLocationHandler(storageLocationUsecase)
+	taxRateRepository := repository.NewTaxRateRepository(repositoryDB)
+	taxRateUsecase := usecase.NewTaxRateUsecase(taxRateRepository, transactionRepository)
+	taxRateHandler := handler.NewTaxRateHandler(taxRateUsecase)
+	handlers := handler.NewHandlers(menuHandler, userHandler, reviewHandler, authHandler, orderHandler, tableHandler, reservationHandler, recipeHandler, inventoryHandler, ingredientHandler, supplierHandler, purchaseOrderHandler, reportHandler, wasteHandler, stocktakeHandler, storageLocationHandler, taxRateHandler)
 	return handlers, nil
 }
 
+// InitializeScheduler initializes the background jobs with dependencies
+func InitializeScheduler() (scheduler.Scheduler, error) {
+	configConfig, err := config.LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	string2 := database.ProvideDSN(configConfig)
+	db, err := database.NewMySQLConnection(string2)
+	if err != nil {
+		return nil, err
+	}
+	repositoryDB := ProvideDBConnection(db)
+	inventoryRepository := repository.NewInventoryRepository(repositoryDB)
+	inventoryMovementRepository := repository.NewInventoryMovementRepository(repositoryDB)
+	inventoryLotRepository := repository.NewInventoryLotRepository(repositoryDB)
+	stockTransferRepository := repository.NewStockTransferRepository(repositoryDB)
+	stockAlertNotifier := notifier.NewStockAlertNotifier(configConfig)
+	transactionRepository := repository.NewTransactionRepository(db)
+	inventoryUsecase := usecase.NewInventoryUsecase(inventoryRepository, inventoryMovementRepository, inventoryLotRepository, stockTransferRepository, stockAlertNotifier, transactionRepository)
+	schedulerScheduler := scheduler.NewScheduler(configConfig, inventoryUsecase)
+	return schedulerScheduler, nil
+}
+
 // wire.go:
 
-var orderMenuSet = wire.NewSet(repository.NewOrderMenuRepository)
+var orderMenuSet = wire.NewSet(repository.NewOrderMenuRepository, repository.NewOrderStatusHistoryRepository, repository.NewOrderTaxLineRepository)
 
 var orderSet = wire.NewSet(repository.NewOrderRepository, usecase.NewOrderUsecase, handler.NewOrderHandler)
 
@@ -91,9 +155,27 @@ var reservationSet = wire.NewSet(repository.NewReservationRepository, usecase.Ne
 
 var recipeSet = wire.NewSet(repository.NewRecipeRepository, usecase.NewRecipeUsecase, handler.NewRecipeHandler)
 
-var inventorySet = wire.NewSet(repository.NewInventoryRepository, usecase.NewInventoryUsecase, handler.NewInventoryHandler)
+var inventorySet = wire.NewSet(repository.NewInventoryRepository, repository.NewInventoryMovementRepository, repository.NewInventoryLotRepository, repository.NewStockTransferRepository, usecase.NewInventoryUsecase, handler.NewInventoryHandler)
+
+var ingredientSet = wire.NewSet(repository.NewIngredientRepository, repository.NewIngredientUnitConversionRepository, repository.NewIngredientCostHistoryRepository, usecase.NewIngredientUsecase, handler.NewIngredientHandler)
+
+var supplierSet = wire.NewSet(repository.NewSupplierRepository, repository.NewSupplierIngredientRepository, usecase.NewSupplierUsecase, handler.NewSupplierHandler)
+
+var purchaseOrderSet = wire.NewSet(repository.NewPurchaseOrderRepository, repository.NewPurchaseOrderItemRepository, usecase.NewPurchaseOrderUsecase, handler.NewPurchaseOrderHandler)
+
+var wasteSet = wire.NewSet(repository.NewWasteLogRepository, usecase.NewWasteUsecase, handler.NewWasteHandler)
+
+var stocktakeSet = wire.NewSet(repository.NewStocktakeRepository, usecase.NewStocktakeUsecase, handler.NewStocktakeHandler)
+
+var storageLocationSet = wire.NewSet(repository.NewStorageLocationRepository, usecase.NewStorageLocationUsecase, handler.NewStorageLocationHandler)
+
+var taxRateSet = wire.NewSet(repository.NewTaxRateRepository, usecase.NewTaxRateUsecase, handler.NewTaxRateHandler)
+
+var reportSet = wire.NewSet(usecase.NewReportUsecase, handler.NewReportHandler)
+
+var notifierSet = wire.NewSet(notifier.NewStockAlertNotifier)
 
-var ingredientSet = wire.NewSet(repository.NewIngredientRepository, usecase.NewIngredientUsecase, handler.NewIngredientHandler)
+var paymentSet = wire.NewSet(payment.NewPaymentProvider)
 
 var txSet = wire.NewSet(repository.NewTransactionRepository)
 
@@ -47,6 +47,15 @@ func NewConflictError(message string) error {
 	}
 }
 
+func NewConflictErrorWithDetails(message string, details interface{}) error {
+	return AppError{
+		HttpStatus: http.StatusConflict,
+		Code:       "CONFLICT",
+		Message:    message,
+		Details:    details,
+	}
+}
+
 func NewInternalError(message string) error {
 	return AppError{
 		HttpStatus: http.StatusInternalServerError,
@@ -63,6 +72,14 @@ func NewUnauthorizedError(message string) error {
 	}
 }
 
+func NewForbiddenError(message string) error {
+	return AppError{
+		HttpStatus: http.StatusForbidden,
+		Code:       "FORBIDDEN",
+		Message:    message,
+	}
+}
+
 func GetErrorStatus(err error) int {
 	if appErr, ok := err.(AppError); ok {
 		return appErr.HttpStatus
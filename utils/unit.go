@@ -0,0 +1,98 @@
+package utils
+
+import "strings"
+
+const (
+	DimensionMass   = "mass"
+	DimensionVolume = "volume"
+	DimensionCount  = "count"
+)
+
+type unitDefinition struct {
+	dimension string
+	factor    float64 // amount of the dimension's reference unit in one unit
+}
+
+// standardUnits are converted without any per-ingredient configuration.
+// Reference units are g, ml and pcs.
+var standardUnits = map[string]unitDefinition{
+	"mg":    {DimensionMass, 0.001},
+	"g":     {DimensionMass, 1},
+	"kg":    {DimensionMass, 1000},
+	"ml":    {DimensionVolume, 1},
+	"l":     {DimensionVolume, 1000},
+	"pcs":   {DimensionCount, 1},
+	"dozen": {DimensionCount, 12},
+}
+
+var referenceUnits = map[string]string{
+	DimensionMass:   "g",
+	DimensionVolume: "ml",
+	DimensionCount:  "pcs",
+}
+
+// NormalizeUnit lowercases and trims a unit so "KG " and "kg" are the same unit
+func NormalizeUnit(unit string) string {
+	return strings.ToLower(strings.TrimSpace(unit))
+}
+
+func IsStandardUnit(unit string) bool {
+	_, ok := standardUnits[NormalizeUnit(unit)]
+	return ok
+}
+
+// ReferenceUnit returns the smallest common unit of the dimension the given
+// standard unit belongs to, e.g. "g" for "kg"
+func ReferenceUnit(unit string) (string, bool) {
+	definition, ok := standardUnits[NormalizeUnit(unit)]
+	if !ok {
+		return "", false
+	}
+	return referenceUnits[definition.dimension], true
+}
+
+// ConvertUnit converts a quantity between two standard units of the same
+// dimension, it reports false when the units are unknown or incompatible
+func ConvertUnit(quantity float64, from, to string) (float64, bool) {
+	from, to = NormalizeUnit(from), NormalizeUnit(to)
+	if from == to {
+		return quantity, true
+	}
+	fromDefinition, ok := standardUnits[from]
+	if !ok {
+		return 0, false
+	}
+	toDefinition, ok := standardUnits[to]
+	if !ok || fromDefinition.dimension != toDefinition.dimension {
+		return 0, false
+	}
+	return quantity * fromDefinition.factor / toDefinition.factor, true
+}
+
+// ReadableQuantity expresses a mass or volume in the largest standard unit
+// of its dimension that keeps the quantity at least 1, e.g. 2500 g as 2.5 kg.
+// Counts and units that are not standard are returned as they are.
+func ReadableQuantity(quantity float64, unit string) (float64, string) {
+	unit = NormalizeUnit(unit)
+	definition, ok := standardUnits[unit]
+	if !ok || definition.dimension == DimensionCount || quantity <= 0 {
+		return quantity, unit
+	}
+
+	best, bestFactor := unit, 0.0
+	for candidate, candidateDefinition := range standardUnits {
+		if candidateDefinition.dimension != definition.dimension {
+			continue
+		}
+		converted := quantity * definition.factor / candidateDefinition.factor
+		if converted >= 1 && candidateDefinition.factor > bestFactor {
+			best, bestFactor = candidate, candidateDefinition.factor
+		}
+	}
+	if bestFactor == 0 {
+		// less than one of the smallest unit
+		return quantity, unit
+	}
+	converted, _ := ConvertUnit(quantity, unit, best)
+	return converted, best
+}
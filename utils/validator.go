@@ -30,10 +30,17 @@ func ValidateStruct(data interface{}) []*errorResponse {
 	return errors
 }
 
+// FieldError builds validation details for a check done outside the struct tags
+func FieldError(field, message string) []*errorResponse {
+	return []*errorResponse{{Field: field, Message: message}}
+}
+
 func getErrorMsg(fe validator.FieldError) string {
 	switch fe.Tag() {
 	case "required":
 		return "Field ini wajib diisi"
+	case "required_without":
+		return fmt.Sprintf("Field ini wajib diisi jika %s kosong", fe.Param())
 	case "min":
 		return fmt.Sprintf("Minimal panjang karakter adalah %s", fe.Param())
 	case "max":